@@ -0,0 +1,12 @@
+# Look up the default category, which applies to jobs without a category
+data "sabnzbd_category" "default" {
+  name = "*"
+}
+
+# Reuse the default post-processing settings for a managed category
+resource "sabnzbd_category" "movies" {
+  name   = "movies"
+  dir    = "Movies"
+  script = data.sabnzbd_category.default.script
+  pp     = data.sabnzbd_category.default.pp
+}
@@ -0,0 +1,7 @@
+# Back up the SABnzbd configuration, with passwords and API keys redacted
+data "sabnzbd_config_export" "backup" {}
+
+resource "local_sensitive_file" "sabnzbd_backup" {
+  filename = "${path.module}/sabnzbd-config.json"
+  content  = data.sabnzbd_config_export.backup.json
+}
@@ -0,0 +1,12 @@
+# Read the free disk space reported by SABnzbd
+data "sabnzbd_disk_space" "current" {}
+
+output "complete_folder_free" {
+  description = "Free space on the complete folder, as reported"
+  value       = data.sabnzbd_disk_space.current.diskspace2
+}
+
+output "complete_folder_low" {
+  description = "Whether less than 50 GiB is free on the complete folder"
+  value       = data.sabnzbd_disk_space.current.diskspace2_bytes < 50 * 1024 * 1024 * 1024
+}
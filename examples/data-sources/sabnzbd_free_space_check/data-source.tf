@@ -0,0 +1,12 @@
+# Check SABnzbd's free space against its configured minimums
+data "sabnzbd_free_space_check" "current" {}
+
+output "download_folder_low_on_space" {
+  description = "Whether the download folder is below its download_free minimum"
+  value       = data.sabnzbd_free_space_check.current.download_below_threshold
+}
+
+output "complete_folder_low_on_space" {
+  description = "Whether the complete folder is below its complete_free minimum"
+  value       = data.sabnzbd_free_space_check.current.complete_below_threshold
+}
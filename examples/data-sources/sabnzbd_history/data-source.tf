@@ -0,0 +1,17 @@
+# Read the 20 most recent history entries
+data "sabnzbd_history" "recent" {
+  max_items = 20
+}
+
+output "recent_downloads" {
+  description = "Names of the most recently finished downloads"
+  value       = [for item in data.sabnzbd_history.recent.items : item.name]
+}
+
+output "recent_failures" {
+  description = "Failed downloads among the recent history and why they failed"
+  value = {
+    for item in data.sabnzbd_history.recent.items : item.name => item.fail_message
+    if item.normalized_status == "failed"
+  }
+}
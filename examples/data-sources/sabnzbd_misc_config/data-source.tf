@@ -0,0 +1,7 @@
+# Inspect misc settings that no resource manages yet
+data "sabnzbd_misc_config" "current" {}
+
+output "queue_limit" {
+  description = "SABnzbd's queue_limit setting"
+  value       = data.sabnzbd_misc_config.current.settings["queue_limit"]
+}
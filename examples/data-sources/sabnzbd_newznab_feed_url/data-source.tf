@@ -0,0 +1,16 @@
+variable "indexer_api_key" {
+  type      = string
+  sensitive = true
+}
+
+# Build the feed URL of an indexer's SD and HD TV categories
+data "sabnzbd_newznab_feed_url" "tv" {
+  base_url   = "https://indexer.example.com"
+  api_key    = var.indexer_api_key
+  categories = [5030, 5040]
+}
+
+output "tv_feed" {
+  description = "The feed URL without the API key"
+  value       = data.sabnzbd_newznab_feed_url.tv.redacted_url
+}
@@ -0,0 +1,17 @@
+# Read the download queue
+data "sabnzbd_queue" "current" {}
+
+output "queue_empty" {
+  description = "Whether every queued download has finished"
+  value       = data.sabnzbd_queue.current.total == 0
+}
+
+output "queue_progress" {
+  description = "Progress and seconds left of each queued download"
+  value = {
+    for item in data.sabnzbd_queue.current.items : item.name => {
+      percentage = item.percentage
+      seconds    = item.timeleft_seconds
+    }
+  }
+}
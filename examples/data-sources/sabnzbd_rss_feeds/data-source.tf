@@ -0,0 +1,7 @@
+# List every RSS feed configured in SABnzbd
+data "sabnzbd_rss_feeds" "all" {}
+
+output "enabled_feeds" {
+  description = "Names of the RSS feeds SABnzbd reads"
+  value       = [for feed in data.sabnzbd_rss_feeds.all.feeds : feed.name if feed.enable]
+}
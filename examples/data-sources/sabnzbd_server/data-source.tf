@@ -0,0 +1,9 @@
+# Look up a news server that is managed outside of Terraform
+data "sabnzbd_server" "primary" {
+  name = "news.example.com"
+}
+
+output "primary_server_endpoint" {
+  description = "Host and port of the primary news server"
+  value       = "${data.sabnzbd_server.primary.host}:${data.sabnzbd_server.primary.port}"
+}
@@ -0,0 +1,7 @@
+# Read download statistics per news server
+data "sabnzbd_server_stats" "usage" {}
+
+output "primary_monthly_gib" {
+  description = "GiB downloaded from the primary server this month"
+  value       = data.sabnzbd_server_stats.usage.servers["news.example.com"].month / 1024 / 1024 / 1024
+}
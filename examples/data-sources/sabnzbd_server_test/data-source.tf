@@ -0,0 +1,14 @@
+# Check on every plan that SABnzbd can still reach a news server
+data "sabnzbd_server_test" "primary" {
+  name = "news.example.com"
+}
+
+output "primary_server_reachable" {
+  description = "Whether SABnzbd connected to the primary news server"
+  value       = data.sabnzbd_server_test.primary.reachable
+}
+
+output "primary_server_message" {
+  description = "SABnzbd's message for the connection test"
+  value       = data.sabnzbd_server_test.primary.message
+}
@@ -0,0 +1,9 @@
+# List the sorters SABnzbd applies to TV series
+data "sabnzbd_sorters" "series" {
+  sort_type = "series"
+}
+
+output "series_sorters" {
+  description = "Names of the active sorters for TV series"
+  value       = [for sorter in data.sabnzbd_sorters.series.sorters : sorter.name if sorter.is_active]
+}
@@ -0,0 +1,12 @@
+# Read the current SABnzbd status for monitoring
+data "sabnzbd_status" "current" {}
+
+output "download_speed_kbps" {
+  description = "Current download speed in KB/s"
+  value       = data.sabnzbd_status.current.kbpersec
+}
+
+output "queue_remaining" {
+  description = "Data left in the queue and the estimated time to finish it"
+  value       = "${data.sabnzbd_status.current.mbleft} MB (${data.sabnzbd_status.current.timeleft})"
+}
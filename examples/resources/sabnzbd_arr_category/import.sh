@@ -0,0 +1,2 @@
+# Import an existing category by its name
+terraform import sabnzbd_arr_category.sonarr "tv"
@@ -0,0 +1,17 @@
+# Categories for Sonarr and Radarr. In each application, add SABnzbd as a
+# download client with the same category name, and map the remote path
+# dir_absolute to where the application sees that folder.
+resource "sabnzbd_arr_category" "sonarr" {
+  name = "tv"
+  dir  = "/data/complete/tv"
+}
+
+resource "sabnzbd_arr_category" "radarr" {
+  name     = "movies"
+  dir      = "/data/complete/movies"
+  priority = 1
+}
+
+output "sonarr_remote_path" {
+  value = sabnzbd_arr_category.sonarr.dir_absolute
+}
@@ -0,0 +1,3 @@
+# The categories set is a singleton, so the import ID is always "categories".
+# Every category except the default one (*) is read from SABnzbd during import.
+terraform import sabnzbd_categories.all categories
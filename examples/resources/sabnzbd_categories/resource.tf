@@ -0,0 +1,24 @@
+# Declare the complete set of categories. Categories not listed here are
+# deleted, except the default category (*).
+resource "sabnzbd_categories" "all" {
+  categories = {
+    "*" = {
+      pp = "3" # +Repair/Unpack/Delete
+    }
+    movies = {
+      dir      = "Movies"
+      priority = 0
+      pp       = "3"
+    }
+    tv = {
+      dir      = "TV Shows"
+      priority = 1
+      pp       = "3"
+    }
+    software = {
+      dir      = "Software"
+      priority = -1 # Low priority
+      pp       = "2" # +Repair/Unpack
+    }
+  }
+}
@@ -0,0 +1,9 @@
+# Snapshot the configuration whenever the servers change
+resource "sabnzbd_config_backup" "example" {
+  path          = "${path.root}/backups/sabnzbd.json"
+  server_backup = true
+
+  triggers = {
+    server = sabnzbd_server.example.id
+  }
+}
@@ -0,0 +1,2 @@
+# The default category is a singleton, so the import ID is always "*".
+terraform import sabnzbd_default_category.default "*"
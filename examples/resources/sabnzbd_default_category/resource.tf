@@ -0,0 +1,6 @@
+# Configure the default category (*) on a fresh SABnzbd install
+resource "sabnzbd_default_category" "default" {
+  script   = "None"
+  priority = 0
+  pp       = "3" # +Repair/Unpack/Delete
+}
@@ -0,0 +1,11 @@
+data "sabnzbd_misc_config" "all" {}
+
+# Send a test email whenever the email server or recipient changes
+resource "sabnzbd_email_test" "example" {
+  fail_on_error = true
+
+  triggers = {
+    server    = data.sabnzbd_misc_config.all.settings["email_server"]
+    recipient = data.sabnzbd_misc_config.all.settings["email_to"]
+  }
+}
@@ -0,0 +1,2 @@
+# The external access configuration is a singleton, so the import ID is always "external_access".
+terraform import sabnzbd_external_access.config external_access
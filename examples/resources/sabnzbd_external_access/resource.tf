@@ -0,0 +1,6 @@
+# Answer only to the LAN hostname and let the home network change settings
+resource "sabnzbd_external_access" "config" {
+  host_whitelist = ["sabnzbd.lan"]
+  local_ranges   = ["192.168.1.0/24"]
+  inet_exposure  = "add_nzb"
+}
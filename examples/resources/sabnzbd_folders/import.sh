@@ -1 +1,3 @@
+# The folders configuration is a singleton, so the import ID is always "folders".
+# All folder settings are read from SABnzbd during import.
 terraform import sabnzbd_folders.config folders
@@ -0,0 +1,2 @@
+# The global scripts configuration is a singleton, so the import ID is always "global_scripts".
+terraform import sabnzbd_global_scripts.config global_scripts
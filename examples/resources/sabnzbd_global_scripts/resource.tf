@@ -0,0 +1,5 @@
+# Filter every job through a pre-queue script and notify when the queue is done
+resource "sabnzbd_global_scripts" "config" {
+  pre_script       = "filter.py"
+  end_queue_script = "notify_done.sh"
+}
@@ -0,0 +1,2 @@
+# The legacy sorting configuration is a singleton, so the import ID is always "legacy_sorting".
+terraform import sabnzbd_legacy_sorting.config legacy_sorting
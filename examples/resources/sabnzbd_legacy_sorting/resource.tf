@@ -0,0 +1,6 @@
+# Sort TV jobs into season folders on a SABnzbd 3.x instance
+resource "sabnzbd_legacy_sorting" "config" {
+  tv_sorting     = true
+  tv_sort_string = "%sn/Season %s/%sn - %sx%0e - %en.%ext"
+  tv_categories  = ["tv"]
+}
@@ -0,0 +1,6 @@
+# Enqueue a test download to verify the setup end to end
+resource "sabnzbd_nzb_url" "smoke_test" {
+  url      = "https://indexer.example.com/getnzb/1234567.nzb"
+  category = sabnzbd_category.software.name
+  priority = 1
+}
@@ -0,0 +1,2 @@
+# The performance configuration is a singleton, so the import ID is always "performance".
+terraform import sabnzbd_performance.config performance
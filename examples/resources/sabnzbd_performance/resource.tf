@@ -0,0 +1,5 @@
+# Give SABnzbd a larger article cache on a machine with memory to spare
+resource "sabnzbd_performance" "config" {
+  cache_limit           = "2G"
+  direct_unpack_threads = 4
+}
@@ -0,0 +1,10 @@
+data "sabnzbd_rss_feeds" "all" {}
+
+# Read the feeds again whenever their configuration changes
+resource "sabnzbd_rss_trigger" "tv" {
+  feed = "tv"
+
+  triggers = {
+    feeds = jsonencode(data.sabnzbd_rss_feeds.all.feeds)
+  }
+}
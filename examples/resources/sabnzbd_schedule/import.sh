@@ -0,0 +1,2 @@
+# Import an existing schedule entry by its schedline without the enabled flag
+terraform import sabnzbd_schedule.pause_night "0 23 1234567 pause"
@@ -0,0 +1,24 @@
+# Pause downloads every night at 23:00
+resource "sabnzbd_schedule" "pause_night" {
+  hour   = 23
+  minute = 0
+  days   = [1, 2, 3, 4, 5, 6, 7]
+  action = "pause"
+}
+
+# Resume downloads every morning at 07:00
+resource "sabnzbd_schedule" "resume_morning" {
+  hour   = 7
+  minute = 0
+  days   = [1, 2, 3, 4, 5, 6, 7]
+  action = "resume"
+}
+
+# Limit the download speed during weekday working hours
+resource "sabnzbd_schedule" "weekday_speedlimit" {
+  hour   = 9
+  minute = 0
+  days   = [1, 2, 3, 4, 5]
+  action = "speedlimit"
+  value  = "50"
+}
@@ -1,2 +1,8 @@
 # Import an existing server by its name
 terraform import sabnzbd_server.primary "news.example.com"
+
+# SABnzbd never returns server passwords. To import the password as well, set
+# SABNZBD_SERVER_PASSWORD_ followed by the server name in upper case, with
+# every character other than a letter or digit replaced by an underscore.
+SABNZBD_SERVER_PASSWORD_NEWS_EXAMPLE_COM="secret" \
+  terraform import sabnzbd_server.primary "news.example.com"
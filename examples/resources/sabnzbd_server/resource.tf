@@ -1,30 +1,33 @@
-# Configure a news server
+# Configure a news server. The write-only password is sent to SABnzbd but
+# never stored in state; increment password_wo_version to change it.
 resource "sabnzbd_server" "primary" {
-  name        = "news.example.com"
-  host        = "news.example.com"
-  port        = 563
-  username    = "myuser"
-  password    = var.news_server_password
-  connections = 20
-  ssl         = true
-  ssl_verify  = 2
-  enable      = true
-  priority    = 0
+  name                = "news.example.com"
+  host                = "news.example.com"
+  port                = 563
+  username            = "myuser"
+  password_wo         = var.news_server_password
+  password_wo_version = 1
+  connections         = 20
+  ssl                 = true
+  ssl_verify          = 2
+  enable              = true
+  priority            = 0
 }
 
 # Configure a backup/fill server
 resource "sabnzbd_server" "backup" {
-  name        = "backup.example.com"
-  host        = "backup.example.com"
-  port        = 563
-  username    = "myuser"
-  password    = var.backup_server_password
-  connections = 10
-  ssl         = true
-  ssl_verify  = 2
-  enable      = true
-  optional    = true
-  priority    = 1
+  name                = "backup.example.com"
+  host                = "backup.example.com"
+  port                = 563
+  username            = "myuser"
+  password_wo         = var.backup_server_password
+  password_wo_version = 1
+  connections         = 10
+  ssl                 = true
+  ssl_verify          = 2
+  enable              = true
+  optional            = true
+  priority            = 1
 }
 
 variable "news_server_password" {
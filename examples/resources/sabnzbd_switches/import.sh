@@ -0,0 +1,2 @@
+# The switches configuration is a singleton, so the import ID is always "switches".
+terraform import sabnzbd_switches.config switches
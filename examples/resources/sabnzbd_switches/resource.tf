@@ -0,0 +1,6 @@
+# Abort jobs that contain executables and unpack while downloading
+resource "sabnzbd_switches" "config" {
+  direct_unpack                 = true
+  unwanted_extensions           = ["exe", "com", "scr"]
+  action_on_unwanted_extensions = "abort"
+}
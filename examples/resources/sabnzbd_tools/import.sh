@@ -0,0 +1,2 @@
+# The tools configuration is a singleton, so the import ID is always "tools".
+terraform import sabnzbd_tools.config tools
@@ -0,0 +1,5 @@
+# Point SABnzbd at tools installed outside the default locations of its container image
+resource "sabnzbd_tools" "config" {
+  par2_path  = "/usr/local/bin/par2"
+  unrar_path = "/usr/local/bin/unrar"
+}
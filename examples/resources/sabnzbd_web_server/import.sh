@@ -0,0 +1,3 @@
+# The web server configuration is a singleton, so the import ID is always "web_server".
+# SABnzbd does not return the password, so set it in the configuration after import.
+terraform import sabnzbd_web_server.config web_server
@@ -0,0 +1,7 @@
+# Require a login for the web interface without moving it
+resource "sabnzbd_web_server" "config" {
+  host     = "0.0.0.0"
+  port     = 8080
+  username = "admin"
+  password = var.sabnzbd_web_password
+}
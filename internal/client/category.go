@@ -5,32 +5,109 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
+// DefaultCategoryName is the name SABnzbd uses for the default category,
+// whose settings apply to jobs without a category.
+const DefaultCategoryName = "*"
+
+// DefaultPriority is the priority that makes a category use the priority of
+// the default category. It is a sentinel rather than a level below Paused.
+const DefaultPriority = -100
+
+// priorityNames maps the priority names some SABnzbd versions write to the
+// config in place of the number.
+var priorityNames = map[string]int{
+	"default": DefaultPriority,
+	"paused":  -2,
+	"low":     -1,
+	"normal":  0,
+	"high":    1,
+	"force":   2,
+}
+
+// parseCategoryPriority decodes a category priority given as a number, a
+// numeric string or a priority name. A missing or empty priority is
+// DefaultPriority, which is what SABnzbd applies to such a category, rather
+// than 0 (Normal).
+func parseCategoryPriority(raw json.RawMessage) (int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return DefaultPriority, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return DefaultPriority, nil
+		}
+		if n, ok := priorityNames[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid category priority %q", s)
+		}
+		return n, nil
+	}
+
+	var n int
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return 0, fmt.Errorf("invalid category priority %s", raw)
+	}
+
+	return n, nil
+}
+
+// DefaultCategory holds the settings SABnzbd applies through the default
+// category when it has not been configured yet.
+var DefaultCategory = Category{
+	Name:     DefaultCategoryName,
+	Script:   "None",
+	Priority: 0,
+	PP:       "3",
+}
+
 // CategoryInput represents the input for creating/updating a category.
+// A nil Order leaves the order for SABnzbd to assign. Extra sets additional
+// settings by key; keys that have a field of their own are ignored.
 type CategoryInput struct {
 	Name     string
 	Dir      string
 	Script   string
 	Priority int
 	PP       string
-	Order    int
+	Order    *int
+	Extra    map[string]string
 }
 
 // SetCategory creates or updates a category configuration.
 func (c *Client) SetCategory(ctx context.Context, input *CategoryInput) error {
 	params := url.Values{}
+	for key, value := range input.Extra {
+		if !IsCategoryField(key) {
+			params.Set(key, value)
+		}
+	}
 	params.Set("mode", "set_config")
 	params.Set("section", "categories")
 	params.Set("name", input.Name)
+	// Always send dir, even when empty, so clearing it makes the category use
+	// the global complete folder again.
 	params.Set("dir", input.Dir)
 	params.Set("script", input.Script)
 	params.Set("priority", strconv.Itoa(input.Priority))
 	params.Set("pp", input.PP)
-	params.Set("order", strconv.Itoa(input.Order))
+	if input.Order != nil {
+		params.Set("order", strconv.Itoa(*input.Order))
+	}
 
 	var resp map[string]interface{}
 	if err := c.doRequest(ctx, params, &resp); err != nil {
@@ -40,23 +117,56 @@ func (c *Client) SetCategory(ctx context.Context, input *CategoryInput) error {
 	return nil
 }
 
-// GetCategory retrieves a specific category configuration by name.
+// GetCategory retrieves a specific category configuration by name. SABnzbd
+// lowercases category names on save, so name is matched ignoring case when no
+// category has exactly that name.
 func (c *Client) GetCategory(ctx context.Context, name string) (*Category, error) {
 	config, err := c.GetConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, cat := range config.Categories {
+	if cat, ok := FindCategory(config.Categories, name); ok {
+		return &cat, nil
+	}
+
+	return nil, fmt.Errorf("category %q %w", name, ErrNotFound)
+}
+
+// FindCategory returns the category in categories named name, preferring an
+// exact match over one that only differs in case.
+func FindCategory(categories []Category, name string) (Category, bool) {
+	for _, cat := range categories {
 		if cat.Name == name {
-			return &cat, nil
+			return cat, true
 		}
 	}
 
-	return nil, fmt.Errorf("category %q not found", name)
+	for _, cat := range categories {
+		if strings.EqualFold(cat.Name, name) {
+			return cat, true
+		}
+	}
+
+	return Category{}, false
 }
 
-// DeleteCategory removes a category configuration.
+// GetDefaultCategory retrieves the default category. It cannot be deleted,
+// but a fresh install may not list it yet, in which case DefaultCategory is
+// returned.
+func (c *Client) GetDefaultCategory(ctx context.Context) (*Category, error) {
+	category, err := c.GetCategory(ctx, DefaultCategoryName)
+	if errors.Is(err, ErrNotFound) {
+		category := DefaultCategory
+		return &category, nil
+	}
+
+	return category, err
+}
+
+// DeleteCategory removes a category configuration. Unlike set_config,
+// del_config identifies the category by keyword rather than name in every
+// SABnzbd version, and reports success even when no such category exists.
 func (c *Client) DeleteCategory(ctx context.Context, name string) error {
 	params := url.Values{}
 	params.Set("mode", "del_config")
@@ -71,6 +181,43 @@ func (c *Client) DeleteCategory(ctx context.Context, name string) error {
 	return nil
 }
 
+// NextCategoryOrder returns the order to give a new category so it is listed
+// after all of categories: one past the highest existing order, or 0 when
+// there are none.
+func NextCategoryOrder(categories []Category) int {
+	next := 0
+	for _, cat := range categories {
+		if cat.Order >= next {
+			next = cat.Order + 1
+		}
+	}
+
+	return next
+}
+
+// AddCategoryLast writes a category with the order that lists it after the
+// existing ones, and sets input.Order to it. Reading the existing orders and
+// writing the category happen under a lock, so categories added in parallel
+// through the same client do not get the same order.
+func (c *Client) AddCategoryLast(ctx context.Context, input *CategoryInput) error {
+	select {
+	case c.categoryOrderSem <- struct{}{}:
+		defer func() { <-c.categoryOrderSem }()
+	case <-ctx.Done():
+		return fmt.Errorf("waiting to order category: %w", ctx.Err())
+	}
+
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	order := NextCategoryOrder(config.Categories)
+	input.Order = &order
+
+	return c.SetCategory(ctx, input)
+}
+
 // GetCategories retrieves all category names.
 func (c *Client) GetCategories(ctx context.Context) ([]string, error) {
 	params := url.Values{}
@@ -85,3 +232,34 @@ func (c *Client) GetCategories(ctx context.Context) ([]string, error) {
 
 	return resp.Categories, nil
 }
+
+// CategoryCompleteDir returns the folder completed downloads in a category
+// land in. A relative categoryDir is placed under the global completeDir,
+// which is itself resolved against baseDir; an empty one uses completeDir.
+func CategoryCompleteDir(baseDir, completeDir, categoryDir string) string {
+	if completeDir == "" {
+		completeDir = DefaultFolders.CompleteDir
+	}
+	completeDir = ResolvePath(baseDir, completeDir)
+	if categoryDir == "" {
+		return completeDir
+	}
+
+	return ResolvePath(completeDir, categoryDir)
+}
+
+// GetCategoryCompleteDir returns the effective complete folder for a
+// category whose dir is categoryDir, using the current folder configuration.
+func (c *Client) GetCategoryCompleteDir(ctx context.Context, categoryDir string) (string, error) {
+	folders, err := c.GetFolders(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	baseDir, err := c.GetBaseDir(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return CategoryCompleteDir(baseDir, folders.CompleteDir, categoryDir), nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCategoryCompleteDir(t *testing.T) {
+	cases := []struct {
+		baseDir, completeDir, categoryDir, want string
+	}{
+		{"/config", "/data/complete", "", "/data/complete"},
+		{"/config", "/data/complete", "Movies", "/data/complete/Movies"},
+		{"/config", "/data/complete", "/media/tv", "/media/tv"},
+		{"/config", "Downloads/complete", "tv", "/config/Downloads/complete/tv"},
+		{"/config", "", "tv", "/config/Downloads/complete/tv"},
+		{`C:\sabnzbd`, `D:\complete`, "Movies", `D:\complete\Movies`},
+	}
+
+	for _, tc := range cases {
+		if got := CategoryCompleteDir(tc.baseDir, tc.completeDir, tc.categoryDir); got != tc.want {
+			t.Errorf("CategoryCompleteDir(%q, %q, %q): expected %q, got %q", tc.baseDir, tc.completeDir, tc.categoryDir, tc.want, got)
+		}
+	}
+}
+
+func TestNextCategoryOrder(t *testing.T) {
+	cases := []struct {
+		categories []Category
+		want       int
+	}{
+		{nil, 0},
+		{[]Category{{Name: DefaultCategoryName}}, 1},
+		{[]Category{{Name: DefaultCategoryName}, {Name: "movies", Order: 3}, {Name: "tv", Order: 1}}, 4},
+		{[]Category{{Name: "movies"}, {Name: "tv"}}, 1},
+	}
+
+	for _, tc := range cases {
+		if got := NextCategoryOrder(tc.categories); got != tc.want {
+			t.Errorf("NextCategoryOrder(%+v): expected %d, got %d", tc.categories, tc.want, got)
+		}
+	}
+}
+
+func TestAddCategoryLastInParallel(t *testing.T) {
+	var mu sync.Mutex
+	categories := []Category{{Name: DefaultCategoryName}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		q := r.URL.Query()
+		switch q.Get("mode") {
+		case "get_config":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"config": map[string]interface{}{"categories": categories}})
+		case "set_config":
+			order, _ := strconv.Atoi(q.Get("order"))
+			categories = append(categories, Category{Name: q.Get("name"), Order: order})
+			fmt.Fprint(w, `{"status": true}`)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.AddCategoryLast(context.Background(), &CategoryInput{Name: fmt.Sprintf("cat%d", i)}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := map[int]bool{}
+	for _, category := range categories[1:] {
+		if seen[category.Order] {
+			t.Errorf("expected distinct orders, got %+v", categories)
+			break
+		}
+		seen[category.Order] = true
+	}
+}
+
+func TestSetCategorySendsEmptyDir(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if _, ok := q["dir"]; !ok || q.Get("dir") != "" {
+			t.Errorf("expected an empty dir to be sent, got query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.SetCategory(context.Background(), &CategoryInput{Name: "movies", Dir: ""}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestDeleteCategoryParams(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.DeleteCategory(context.Background(), "tv"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// del_config reads keyword; a name parameter would be ignored and the
+	// delete would silently do nothing.
+	query.Del("apikey")
+	query.Del("output")
+	want := url.Values{"mode": {"del_config"}, "section": {"categories"}, "keyword": {"tv"}}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("expected params %v, got %v", want, query)
+	}
+}
+
+func TestFindCategory(t *testing.T) {
+	categories := []Category{{Name: "Movies", Order: 1}, {Name: "movies", Order: 2}, {Name: "tv", Order: 3}}
+
+	cases := map[string]int{
+		"movies": 2,
+		"Movies": 1,
+		"TV":     3,
+		"music":  0,
+	}
+
+	for name, wantOrder := range cases {
+		got, ok := FindCategory(categories, name)
+		if ok != (wantOrder != 0) || got.Order != wantOrder {
+			t.Errorf("%s: expected order %d, got %+v (found %t)", name, wantOrder, got, ok)
+		}
+	}
+}
@@ -6,52 +6,739 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Client is the SABnzbd API client.
 type Client struct {
 	baseURL    string
+	apiPath    string
 	apiKey     string
 	httpClient *http.Client
+	postWrites bool
+	userAgent  string
+
+	// baseQuery holds the query parameters of the configured URL, which are
+	// sent with every request.
+	baseQuery url.Values
+
+	// outputFormat is the value of the output parameter, or empty to leave
+	// it unset.
+	outputFormat string
+
+	// maxResponseSize is the largest response body, in bytes, the client
+	// reads.
+	maxResponseSize int64
+
+	// serializeWrites makes writeSem guard every config write, since SABnzbd
+	// rewrites its ini file on each one and concurrent writes can be lost.
+	// writeSem is a one-slot semaphore rather than a mutex so that waiting
+	// for it can be cancelled.
+	serializeWrites bool
+	writeSem        chan struct{}
+
+	// categoryOrderSem guards choosing the order of a new category and
+	// writing it, so categories created in parallel get distinct orders.
+	categoryOrderSem chan struct{}
+
+	// schedulesSem guards reading, changing and writing back the schedlines,
+	// so schedules changed in parallel do not overwrite each other.
+	schedulesSem chan struct{}
+
+	// configLockDelay is the wait before retrying a write that SABnzbd
+	// rejected while saving its config file.
+	configLockDelay time.Duration
+
+	// validateOnly stops requests that change SABnzbd from being sent.
+	validateOnly bool
+
+	// restartWindow is how long a read that cannot reach SABnzbd is retried
+	// once SABnzbd has answered before, in case it is restarting, and
+	// restartDelay is the wait between those retries.
+	restartWindow time.Duration
+	restartDelay  time.Duration
+
+	// reached records that a request to SABnzbd has succeeded.
+	reached atomic.Bool
+
+	// httpsURL is the base URL SABnzbd redirected an http request to, or
+	// empty if it never did.
+	httpsMu  sync.Mutex
+	httpsURL string
+
+	// connsReused and connsDialed count the HTTP connections requests were
+	// sent on, by whether an idle one was reused.
+	connsReused atomic.Int64
+	connsDialed atomic.Int64
+}
+
+// Option configures optional Client behavior.
+type Option func(*Client)
+
+// WithPostWrites sends config-modifying requests as form-encoded POSTs so the
+// API key and values stay out of the request URL (and proxy/server logs).
+func WithPostWrites(enabled bool) Option {
+	return func(c *Client) {
+		c.postWrites = enabled
+	}
+}
+
+// WithSerializedWrites makes the client issue config-modifying requests one at
+// a time. Terraform applies resources in parallel, and concurrent set_config
+// calls can clobber each other while SABnzbd rewrites its config file.
+func WithSerializedWrites(enabled bool) Option {
+	return func(c *Client) {
+		c.serializeWrites = enabled
+	}
+}
+
+// WithValidateOnly stops the client from sending requests that change
+// SABnzbd, such as config writes and queue additions. They fail with a
+// *ValidateOnlyError describing the request instead. Reads are sent as usual.
+func WithValidateOnly(enabled bool) Option {
+	return func(c *Client) {
+		c.validateOnly = enabled
+	}
+}
+
+// DefaultRestartWindow is how long reads wait for SABnzbd to come back when
+// no window is configured. SABnzbd restarts to apply some settings, which
+// usually takes well under a minute.
+const DefaultRestartWindow = 60 * time.Second
+
+// WithRestartWindow sets how long a read that cannot reach SABnzbd is
+// retried, provided SABnzbd answered an earlier request and so is likely
+// restarting rather than misconfigured. 0 disables the retries. Writes are
+// never retried this way, since SABnzbd may have applied them.
+func WithRestartWindow(window time.Duration) Option {
+	return func(c *Client) {
+		c.restartWindow = window
+	}
 }
 
-// NewClient creates a new SABnzbd API client.
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
-		baseURL: strings.TrimSuffix(baseURL, "/"),
-		apiKey:  apiKey,
+// WithConnectTimeout limits how long establishing a connection to SABnzbd may
+// take, so an unreachable instance fails fast while responses still have the
+// client's full timeout to arrive. 0 keeps the default transport's limit.
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout <= 0 {
+			return
+		}
+
+		transport := newTransport()
+		transport.DialContext = (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		c.httpClient.Transport = transport
+	}
+}
+
+// newTransport returns a copy of http.DefaultTransport, or a transport with
+// the same settings if another package has replaced it with a different type.
+func newTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// DefaultUserAgent is the User-Agent sent when none is configured.
+const DefaultUserAgent = "terraform-provider-sabnzbd"
+
+// WithUserAgent sets the User-Agent header sent with every request, so
+// provider traffic can be told apart in SABnzbd and proxy logs.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// DefaultOutputFormat is the output parameter sent when none is configured.
+// Responses are decoded as JSON, so other formats only suit endpoints that
+// answer with a plain-text "ok".
+const DefaultOutputFormat = "json"
+
+// WithOutputFormat sets the output parameter sent with every request. An
+// empty format leaves it unset, so that SABnzbd's default or a value in the
+// base URL applies.
+func WithOutputFormat(format string) Option {
+	return func(c *Client) {
+		c.outputFormat = format
+	}
+}
+
+// DefaultMaxResponseSize is the largest response body, in bytes, read when no
+// limit is configured. It leaves room for long histories while stopping a
+// misbehaving endpoint from exhausting memory.
+const DefaultMaxResponseSize = 50 << 20
+
+// WithMaxResponseSize sets the largest response body, in bytes, the client
+// reads. Larger responses fail with a *ResponseTooLargeError.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Client) {
+		c.maxResponseSize = size
+	}
+}
+
+// DefaultAPIPath is the path of the SABnzbd API below the base URL.
+const DefaultAPIPath = "/api"
+
+// WithAPIPath sets the path of the API below the base URL, for setups where
+// SABnzbd's API is not served at /api. path must start with a slash.
+func WithAPIPath(path string) Option {
+	return func(c *Client) {
+		c.apiPath = path
+	}
+}
+
+// NewClient creates a new SABnzbd API client. baseURL may include a path
+// when SABnzbd is served below the root, e.g. https://example.com/sabnzbd.
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	// Query parameters in the base URL are kept apart so the API path can
+	// be appended to its path.
+	baseURL, rawQuery, _ := strings.Cut(baseURL, "?")
+	baseQuery, _ := url.ParseQuery(rawQuery)
+
+	c := &Client{
+		// Trim every trailing slash so appending the API path never yields "//api".
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		baseQuery:       baseQuery,
+		outputFormat:    DefaultOutputFormat,
+		maxResponseSize: DefaultMaxResponseSize,
+		apiPath:         DefaultAPIPath,
+		apiKey:          apiKey,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		userAgent:       DefaultUserAgent,
+		configLockDelay: defaultConfigLockDelay,
+		restartWindow:   DefaultRestartWindow,
+		restartDelay:    defaultRestartDelay,
+		writeSem:        make(chan struct{}, 1),
+
+		categoryOrderSem: make(chan struct{}, 1),
+		schedulesSem:     make(chan struct{}, 1),
+	}
+
+	c.httpClient.CheckRedirect = c.checkRedirect
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// maxRedirects is how many redirects a request follows, as http.Client does
+// by default.
+const maxRedirects = 10
+
+// checkRedirect follows redirects from http to https, which SABnzbd sends
+// when it is set to serve HTTPS only. A query string the redirect drops is
+// restored, and the new address is remembered so callers can suggest
+// updating the URL. A POST that the redirect would turn into a GET without
+// its body is refused, since the write would be lost.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	prev := via[len(via)-1]
+	if prev.URL.Scheme != "http" || req.URL.Scheme != "https" {
+		return nil
+	}
+
+	if req.Method != via[0].Method {
+		return fmt.Errorf("SABnzbd redirected a %s request from http to https and the request would be sent "+
+			"without its body; set the provider url to %s", via[0].Method, redirectBaseURL(req.URL, c.apiPath))
+	}
+
+	if req.URL.RawQuery == "" {
+		req.URL.RawQuery = prev.URL.RawQuery
+	}
+
+	c.httpsMu.Lock()
+	c.httpsURL = redirectBaseURL(req.URL, c.apiPath)
+	c.httpsMu.Unlock()
+
+	return nil
+}
+
+// redirectBaseURL returns the base URL of a redirect target, without the API
+// path and query.
+func redirectBaseURL(target *url.URL, apiPath string) string {
+	base := url.URL{Scheme: target.Scheme, Host: target.Host, Path: strings.TrimSuffix(target.Path, apiPath)}
+	return strings.TrimRight(base.String(), "/")
+}
+
+// HTTPSRedirect returns the https base URL SABnzbd redirected the client to,
+// and whether it did. Requests keep working through the redirect, but each
+// one sends the API key over plain http first.
+func (c *Client) HTTPSRedirect() (string, bool) {
+	c.httpsMu.Lock()
+	defer c.httpsMu.Unlock()
+
+	return c.httpsURL, c.httpsURL != ""
+}
+
+// ConnectionStats returns how many requests were sent on a reused idle
+// connection and how many needed a new one.
+func (c *Client) ConnectionStats() (reused, dialed int64) {
+	return c.connsReused.Load(), c.connsDialed.Load()
+}
+
+// connectionTrace returns a trace that counts the connection a request with
+// mode is sent on and logs it at trace level, to see whether connections are
+// reused during an apply.
+func (c *Client) connectionTrace(ctx context.Context, mode string) *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				c.connsReused.Add(1)
+			} else {
+				c.connsDialed.Add(1)
+			}
+
+			reused, dialed := c.ConnectionStats()
+			tflog.Trace(ctx, "SABnzbd HTTP connection", map[string]interface{}{
+				"mode":               mode,
+				"reused":             info.Reused,
+				"idle_time":          info.IdleTime.String(),
+				"connections_reused": reused,
+				"connections_dialed": dialed,
+			})
+		},
+	}
+}
+
+// BaseURL returns the address the client sends requests to, without a
+// trailing slash or query parameters.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// ValidateBaseURL checks that baseURL is an absolute http or https URL with a
+// host and no fragment, so API paths can be appended to it. Query parameters
+// are allowed, except the API key, which belongs in the api_key setting.
+func ValidateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", baseURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid URL %q: must start with http:// or https://", baseURL)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", baseURL)
+	}
+
+	if u.ForceQuery {
+		return fmt.Errorf("invalid URL %q: must not end with an empty query string", baseURL)
+	}
+
+	if u.Query().Has("apikey") {
+		return fmt.Errorf("invalid URL %q: must not include the API key, set it separately", baseURL)
+	}
+
+	if u.Fragment != "" {
+		return fmt.Errorf("invalid URL %q: must not include a fragment", baseURL)
+	}
+
+	return nil
+}
+
+// Ping checks that SABnzbd is reachable at the configured URL and accepts the
+// API key. It returns an *AuthError for a rejected key and a *url.Error when
+// SABnzbd cannot be reached.
+func (c *Client) Ping(ctx context.Context) error {
+	// mode=version does not need the API key, so ask for something that does.
+	params := url.Values{}
+	params.Set("mode", "get_scripts")
+
+	var resp map[string]json.RawMessage
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("connecting to SABnzbd at %s: %w", c.baseURL, err)
+	}
+
+	if _, ok := resp["scripts"]; !ok {
+		return fmt.Errorf("connecting to SABnzbd at %s: unexpected response, check that the URL points at SABnzbd", c.baseURL)
+	}
+
+	return nil
+}
+
+// isWriteMode reports whether the API mode modifies SABnzbd's configuration.
+func isWriteMode(mode string) bool {
+	return mode == "set_config" || mode == "del_config"
+}
+
+// isMutatingRequest reports whether a request changes SABnzbd's
+// configuration, queue or feeds.
+func isMutatingRequest(params url.Values) bool {
+	switch mode := params.Get("mode"); mode {
+	case "addurl", "rss_now":
+		return true
+	case "queue":
+		return params.Get("name") == "delete"
+	case "config":
+		return params.Get("name") == "create_backup"
+	default:
+		return isWriteMode(mode)
 	}
 }
 
+// ValidateOnlyError is returned instead of sending a request that changes
+// SABnzbd while the client is in validate-only mode.
+type ValidateOnlyError struct {
+	// Mode is the API mode of the request, e.g. set_config.
+	Mode string
+
+	// Params are the request parameters without the API key, with
+	// credentials replaced by RedactedValue.
+	Params url.Values
+}
+
+func (e *ValidateOnlyError) Error() string {
+	return fmt.Sprintf("validate_only is set, so this mode=%s request was not sent: %s", e.Mode, e.Params.Encode())
+}
+
+// newValidateOnlyError describes the request with params.
+func newValidateOnlyError(params url.Values) *ValidateOnlyError {
+	described := url.Values{}
+	for key, values := range params {
+		switch {
+		case key == "apikey" || key == "output":
+			continue
+		case isSecretConfigKey(key) && params.Get(key) != "":
+			described.Set(key, RedactedValue)
+		default:
+			described[key] = values
+		}
+	}
+
+	return &ValidateOnlyError{Mode: params.Get("mode"), Params: described}
+}
+
+// ErrNotFound is wrapped by errors returned when a requested configuration
+// item does not exist.
+var ErrNotFound = errors.New("not found")
+
+// maxErrorBodyLen is how much of a failed response body APIError keeps.
+const maxErrorBodyLen = 512
+
 // APIError represents an error returned by the SABnzbd API.
 type APIError struct {
 	Message string
+
+	// Mode is the API mode of the failed request, e.g. set_config.
+	Mode string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body is the raw response body, truncated to maxErrorBodyLen bytes.
+	Body string
 }
 
 func (e *APIError) Error() string {
-	return e.Message
+	if e.Mode == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (mode=%s, HTTP %d)", e.Message, e.Mode, e.StatusCode)
+}
+
+// newAPIError returns an APIError for a failed response to a request in mode.
+func newAPIError(message, mode string, statusCode int, body []byte) *APIError {
+	if len(body) > maxErrorBodyLen {
+		body = body[:maxErrorBodyLen]
+	}
+
+	return &APIError{
+		Message:    message,
+		Mode:       mode,
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+}
+
+// AuthError is returned when SABnzbd rejects the configured API key, which
+// typically happens after the key has been regenerated in the SABnzbd UI.
+type AuthError struct {
+	Message string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("SABnzbd rejected the API key (%s); the key may have been regenerated, "+
+		"update the provider api_key or SABNZBD_API_KEY to the current value", e.Message)
+}
+
+// AccessError is returned when SABnzbd, or a proxy in front of it, refuses
+// the request outright with HTTP 401 or 403, or answers successfully with an
+// HTML page instead of an API response. This happens when the API is disabled or
+// restricted to other addresses, when a login page intercepts the request,
+// or when the API key is wrong.
+type AccessError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// HTML reports whether the response was an HTML page.
+	HTML bool
+}
+
+func (e *AccessError) Error() string {
+	what := fmt.Sprintf("SABnzbd refused API access (HTTP %d)", e.StatusCode)
+	if e.HTML {
+		what = fmt.Sprintf("SABnzbd answered with an HTML page instead of an API response (HTTP %d)", e.StatusCode)
+	}
+
+	return what + "; the API may be disabled or restricted to other addresses in SABnzbd's settings, " +
+		"a login page or proxy may be intercepting requests, or the API key may be wrong"
+}
+
+// ResponseTooLargeError is returned when a response body exceeds the
+// client's size limit. The rest of the body is not read.
+type ResponseTooLargeError struct {
+	// Mode is the API mode of the request, e.g. history.
+	Mode string
+
+	// Limit is the size limit in bytes.
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("SABnzbd response to mode=%s exceeds the limit of %d bytes; "+
+		"raise the provider max_response_mb if SABnzbd legitimately returns this much, e.g. for a long history", e.Mode, e.Limit)
+}
+
+// isHTMLResponse reports whether a response is an HTML page rather than
+// JSON or plain text.
+func isHTMLResponse(contentType string, body []byte) bool {
+	if strings.HasPrefix(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+
+	trimmed := strings.ToLower(strings.TrimSpace(string(body)))
+	return strings.HasPrefix(trimmed, "<!doctype html") || strings.HasPrefix(trimmed, "<html")
+}
+
+// isAuthErrorMessage reports whether an API error message indicates an
+// authentication failure.
+func isAuthErrorMessage(msg string) bool {
+	switch strings.ToLower(strings.TrimSpace(msg)) {
+	case "api key incorrect", "api key required", "access denied":
+		return true
+	}
+	return false
+}
+
+// isPlainTextOK reports whether body is one of the plain-text affirmatives
+// SABnzbd returns from action-style endpoints.
+func isPlainTextOK(body []byte) bool {
+	switch strings.ToLower(strings.TrimSpace(string(body))) {
+	case "ok", "true":
+		return true
+	}
+	return false
+}
+
+// configLockRetries is how many times a write rejected because SABnzbd is
+// saving its config file is retried.
+const configLockRetries = 3
+
+// defaultConfigLockDelay is the default wait before retrying such a write.
+const defaultConfigLockDelay = 500 * time.Millisecond
+
+// retryJitter is the fraction by which a retry delay is randomly lengthened
+// or shortened, so that parallel writes rejected together do not all retry
+// at the same moment.
+const retryJitter = 0.2
+
+// jitterDelay returns delay moved by up to retryJitter of itself in either
+// direction. random returns a number in [0, 1).
+func jitterDelay(delay time.Duration, random func() float64) time.Duration {
+	return delay + time.Duration(float64(delay)*retryJitter*(2*random()-1))
+}
+
+// isConfigLockedMessage reports whether an API error message indicates that
+// SABnzbd refused a change because it is busy writing its config file.
+func isConfigLockedMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "config") &&
+		(strings.Contains(msg, "being saved") || strings.Contains(msg, "locked"))
 }
 
-// doRequest performs an API request and decodes the JSON response.
+// defaultRestartDelay is the first wait between reads retried while SABnzbd
+// restarts; later waits grow as pollUntil backs off.
+const defaultRestartDelay = 2 * time.Second
+
+// isUnreachableError reports whether err shows that SABnzbd could not be
+// reached at all: the connection was refused or dropped, or a proxy in front
+// of SABnzbd reported it unavailable.
+func isUnreachableError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	return false
+}
+
+// doRequest performs an API request and decodes the JSON response. Writes
+// that fail because SABnzbd is saving its config are retried after a short,
+// jittered delay, and reads that cannot reach SABnzbd while it restarts are
+// retried until restartWindow passes.
 func (c *Client) doRequest(ctx context.Context, params url.Values, result interface{}) error {
+	// The request's own parameters win over those in the base URL, so none
+	// is sent twice.
+	for key, values := range c.baseQuery {
+		if !params.Has(key) {
+			params[key] = values
+		}
+	}
 	params.Set("apikey", c.apiKey)
-	params.Set("output", "json")
+	if c.outputFormat != "" {
+		params.Set("output", c.outputFormat)
+	}
+
+	if c.validateOnly && isMutatingRequest(params) {
+		return newValidateOnlyError(params)
+	}
+
+	if !isMutatingRequest(params) {
+		return c.doRead(ctx, params, result)
+	}
+
+	write := isWriteMode(params.Get("mode"))
+	if write && c.serializeWrites {
+		select {
+		case c.writeSem <- struct{}{}:
+			defer func() { <-c.writeSem }()
+		case <-ctx.Done():
+			return fmt.Errorf("waiting to write config: %w", ctx.Err())
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, params, write, result)
+		if err == nil {
+			c.reached.Store(true)
+		}
+
+		var apiErr *APIError
+		if !write || attempt >= configLockRetries || !errors.As(err, &apiErr) || !isConfigLockedMessage(apiErr.Message) {
+			return err
+		}
+
+		timer := time.NewTimer(jitterDelay(c.configLockDelay, rand.Float64))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retrying after %q: %w", apiErr.Message, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
 
-	reqURL := fmt.Sprintf("%s/api?%s", c.baseURL, params.Encode())
+// doRead performs a read request. SABnzbd restarts to apply some settings, so
+// a read that cannot reach it is retried, backing off, until restartWindow
+// passes. Before any request has succeeded, reads fail at once, since SABnzbd
+// is then more likely down or misconfigured than restarting.
+func (c *Client) doRead(ctx context.Context, params url.Values, result interface{}) error {
+	if c.restartWindow <= 0 || !c.reached.Load() {
+		err := c.send(ctx, params, false, result)
+		if err == nil {
+			c.reached.Store(true)
+		}
+		return err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	deadline := time.Now().Add(c.restartWindow)
+	attempt := 0
+	var lastErr, fatal error
+	err := pollUntil(ctx, c.restartDelay, c.restartWindow, func(context.Context) (bool, string, error) {
+		attempt++
+		// The request runs under ctx rather than the poll's, so a slow
+		// response is not cut off by the restart window.
+		err := c.send(ctx, params, false, result)
+		if err == nil {
+			return true, "", nil
+		}
+		if !isUnreachableError(err) {
+			fatal = err
+			return false, "", err
+		}
+
+		lastErr = err
+		tflog.Info(ctx, "SABnzbd is unreachable, waiting for it to restart", map[string]interface{}{
+			"mode":      params.Get("mode"),
+			"attempt":   attempt,
+			"remaining": time.Until(deadline).Round(time.Second).String(),
+			"error":     err.Error(),
+		})
+		return false, err.Error(), nil
+	})
+
+	switch {
+	case fatal != nil:
+		return fatal
+	case errors.Is(err, ErrPollTimeout):
+		return fmt.Errorf("SABnzbd did not come back within %s, it may not be restarting: %w", c.restartWindow, lastErr)
+	case err != nil:
+		return fmt.Errorf("waiting for SABnzbd to restart: %w", err)
+	}
+	return nil
+}
+
+// send performs a single API request and decodes the JSON response.
+func (c *Client) send(ctx context.Context, params url.Values, write bool, result interface{}) error {
+	ctx = httptrace.WithClientTrace(ctx, c.connectionTrace(ctx, params.Get("mode")))
+
+	var req *http.Request
+	var err error
+	if c.postWrites && write {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+c.apiPath, strings.NewReader(params.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", c.baseURL, c.apiPath, params.Encode()), nil)
+	}
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
+	req.Header.Set("User-Agent", c.userAgent)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -59,17 +746,44 @@ func (c *Client) doRequest(ctx context.Context, params url.Values, result interf
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// Read one byte past the limit to tell a body of exactly the limit from
+	// a larger one.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseSize+1))
 	if err != nil {
 		return fmt.Errorf("reading response body: %w", err)
 	}
+	if int64(len(body)) > c.maxResponseSize {
+		return &ResponseTooLargeError{Mode: params.Get("mode"), Limit: c.maxResponseSize}
+	}
+
+	// Some action endpoints answer with a bare "ok" even when JSON output is
+	// requested.
+	if isPlainTextOK(body) {
+		return nil
+	}
 
 	// Check for API errors in response.
 	var errorResp struct {
 		Error string `json:"error"`
 	}
 	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-		return &APIError{Message: errorResp.Error}
+		if isAuthErrorMessage(errorResp.Error) {
+			return &AuthError{Message: errorResp.Error}
+		}
+		return newAPIError(errorResp.Error, params.Get("mode"), resp.StatusCode, body)
+	}
+	if isAuthErrorMessage(string(body)) {
+		return &AuthError{Message: strings.TrimSpace(string(body))}
+	}
+	// A login page served with 200 is as much a refusal as a 403; other
+	// failed statuses, such as a proxy's 502 page, are reported as they are.
+	html := isHTMLResponse(resp.Header.Get("Content-Type"), body)
+	denied := resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden
+	if denied || (html && resp.StatusCode >= 200 && resp.StatusCode <= 299) {
+		return &AccessError{StatusCode: resp.StatusCode, HTML: html}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError("unexpected HTTP status "+resp.Status, params.Get("mode"), resp.StatusCode, body)
 	}
 
 	if result != nil {
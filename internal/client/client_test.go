@@ -0,0 +1,906 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client pointed at a test server that answers every
+// request with handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return NewClient(srv.URL, "test-key")
+}
+
+func TestDoRequestPlainTextOK(t *testing.T) {
+	for _, body := range []string{"ok", "ok\n", "True", " true "} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+
+		var resp map[string]interface{}
+		if err := c.doRequest(context.Background(), url.Values{"mode": {"addurl"}}, &resp); err != nil {
+			t.Errorf("body %q: unexpected error: %s", body, err)
+		}
+	}
+}
+
+func TestDoRequestJSON(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": true, "version": "4.3.2"}`)
+	})
+
+	var resp struct {
+		Version string `json:"version"`
+	}
+	if err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, &resp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.Version != "4.3.2" {
+		t.Errorf("expected version 4.3.2, got %q", resp.Version)
+	}
+}
+
+func TestDoRequestAuthError(t *testing.T) {
+	for _, body := range []string{
+		`{"status": false, "error": "API Key Incorrect"}`,
+		`{"status": false, "error": "API Key Required"}`,
+		"Access denied",
+	} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+
+		err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil)
+		var authErr *AuthError
+		if !errors.As(err, &authErr) {
+			t.Errorf("body %q: expected AuthError, got %v", body, err)
+		}
+	}
+}
+
+func TestDoRequestAPIError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": false, "error": "Not implemented"}`)
+	})
+
+	err := c.doRequest(context.Background(), url.Values{"mode": {"bogus"}}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.Message != "Not implemented" {
+		t.Errorf("expected message %q, got %q", "Not implemented", apiErr.Message)
+	}
+	if apiErr.Mode != "bogus" || apiErr.StatusCode != http.StatusOK {
+		t.Errorf("expected mode bogus and status 200, got %q and %d", apiErr.Mode, apiErr.StatusCode)
+	}
+	if apiErr.Body != `{"status": false, "error": "Not implemented"}` {
+		t.Errorf("unexpected body %q", apiErr.Body)
+	}
+	if want := "Not implemented (mode=bogus, HTTP 200)"; apiErr.Error() != want {
+		t.Errorf("expected error %q, got %q", want, apiErr.Error())
+	}
+}
+
+func TestDoRequestHTTPError(t *testing.T) {
+	page := "<html>" + strings.Repeat("x", 2*maxErrorBodyLen) + "</html>"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, page)
+	})
+
+	err := c.doRequest(context.Background(), url.Values{"mode": {"get_config"}}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.Mode != "get_config" || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected mode get_config and status 502, got %q and %d", apiErr.Mode, apiErr.StatusCode)
+	}
+	if apiErr.Body != page[:maxErrorBodyLen] {
+		t.Errorf("expected the body truncated to %d bytes, got %d", maxErrorBodyLen, len(apiErr.Body))
+	}
+}
+
+func TestDoRequestRetriesConfigLock(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			fmt.Fprint(w, `{"status": false, "error": "Config is being saved, try again"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": true}`)
+	})
+	c.configLockDelay = time.Millisecond
+
+	if err := c.doRequest(context.Background(), url.Values{"mode": {"set_config"}}, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestDoRequestConfigLockLimits(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"status": false, "error": "Config file is locked"}`)
+	})
+	c.configLockDelay = time.Millisecond
+
+	// Writes give up after the retry budget.
+	err := c.doRequest(context.Background(), url.Values{"mode": {"set_config"}}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != configLockRetries+1 {
+		t.Errorf("expected %d requests, got %d", configLockRetries+1, calls)
+	}
+
+	// Reads are never retried.
+	atomic.StoreInt32(&calls, 0)
+	_ = c.doRequest(context.Background(), url.Values{"mode": {"get_config"}}, nil)
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected a single read request, got %d", calls)
+	}
+}
+
+func TestJitterDelay(t *testing.T) {
+	delay := 500 * time.Millisecond
+	low, high := 400*time.Millisecond, 600*time.Millisecond
+
+	if got := jitterDelay(delay, func() float64 { return 0 }); got != low {
+		t.Errorf("expected %s at the lower bound, got %s", low, got)
+	}
+	if got := jitterDelay(delay, func() float64 { return 0.5 }); got != delay {
+		t.Errorf("expected %s in the middle, got %s", delay, got)
+	}
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 1000; i++ {
+		got := jitterDelay(delay, rand.Float64)
+		if got < low || got >= high {
+			t.Fatalf("expected a delay in [%s, %s), got %s", low, high, got)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected the delays to vary")
+	}
+}
+
+func TestDoRequestCancelDuringConfigLockRetry(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"status": false, "error": "Config is being saved, try again"}`)
+	})
+	c.configLockDelay = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := c.doRequest(ctx, url.Values{"mode": {"set_config"}}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected a prompt return after cancellation, took %s", elapsed)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected no retry after cancellation, got %d requests", calls)
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	if c := NewClient("http://localhost:8080", "test-key", WithConnectTimeout(0)); c.httpClient.Transport != nil {
+		t.Error("expected the default transport without a connect timeout")
+	}
+
+	// 10.255.255.1 is not routed, so connecting to it hangs until the
+	// connect timeout rather than being refused.
+	c := NewClient("http://10.255.255.1:8080", "test-key", WithConnectTimeout(200*time.Millisecond))
+
+	start := time.Now()
+	err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil)
+	if err == nil {
+		t.Fatal("expected an error connecting to an unroutable address")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the connect timeout to end the request early, took %s", elapsed)
+	}
+}
+
+func TestConnectTimeoutReplacedDefaultTransport(t *testing.T) {
+	// Another package may swap http.DefaultTransport for its own type.
+	saved := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return saved.RoundTrip(r)
+	})
+	t.Cleanup(func() { http.DefaultTransport = saved })
+
+	c := NewClient("http://localhost:8080", "test-key", WithConnectTimeout(time.Second))
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok || transport.DialContext == nil {
+		t.Errorf("expected a transport with the connect timeout, got %T", c.httpClient.Transport)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestDoRequestWaitsForRestart(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version": "4.3.2"}`)
+	})
+	srv := httptest.NewServer(handler)
+	c := NewClient(srv.URL, "test-key")
+	c.restartDelay = 10 * time.Millisecond
+
+	if err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// SABnzbd goes down to restart and comes back on the same address.
+	addr := srv.Listener.Addr().String()
+	srv.Close()
+	restarted := make(chan *httptest.Server, 1)
+	time.AfterFunc(100*time.Millisecond, func() {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			restarted <- nil
+			return
+		}
+		up := &httptest.Server{Listener: l, Config: &http.Server{Handler: handler}}
+		up.Start()
+		restarted <- up
+	})
+
+	err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil)
+	up := <-restarted
+	if up == nil {
+		t.Skipf("unable to listen on %s again", addr)
+	}
+	defer up.Close()
+
+	if err != nil {
+		t.Fatalf("expected the read to wait for the restart, got %s", err)
+	}
+}
+
+func TestDoRequestRestartLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": true}`)
+	}))
+	addr := srv.URL
+	srv.Close()
+
+	// A client that never reached SABnzbd fails at once.
+	c := NewClient(addr, "test-key")
+	c.restartDelay = time.Minute
+	start := time.Now()
+	if err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil); err == nil {
+		t.Fatal("expected an error from a stopped server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected no wait before SABnzbd was reached, took %s", elapsed)
+	}
+
+	// Once it has, reads give up after the window and writes are not retried.
+	c = NewClient(addr, "test-key", WithRestartWindow(50*time.Millisecond))
+	c.restartDelay = 10 * time.Millisecond
+	c.reached.Store(true)
+
+	err := c.doRequest(context.Background(), url.Values{"mode": {"version"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "did not come back") {
+		t.Errorf("expected the read to give up after the window, got %v", err)
+	}
+
+	c.restartDelay = time.Minute
+	start = time.Now()
+	if err := c.doRequest(context.Background(), url.Values{"mode": {"set_config"}}, nil); err == nil {
+		t.Fatal("expected an error from a stopped server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected writes not to wait for a restart, took %s", elapsed)
+	}
+}
+
+func TestDoRequestCancelWaitingForWrite(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": true}`)
+	})
+	c.serializeWrites = true
+
+	// Hold the write slot as if another write were in flight.
+	c.writeSem <- struct{}{}
+	defer func() { <-c.writeSem }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.doRequest(ctx, url.Values{"mode": {"set_config"}}, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestDoRequestSubpath(t *testing.T) {
+	cases := map[string]string{
+		"":           "/api",
+		"/":          "/api",
+		"/sabnzbd":   "/sabnzbd/api",
+		"/sabnzbd/":  "/sabnzbd/api",
+		"/sabnzbd//": "/sabnzbd/api",
+		"/apps/sab":  "/apps/sab/api",
+	}
+
+	for suffix, wantPath := range cases {
+		for _, postWrites := range []bool{false, true} {
+			var gotPath, gotMode string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				gotPath = r.URL.Path
+				gotMode = r.Form.Get("mode")
+				fmt.Fprint(w, `{"status": true}`)
+			}))
+
+			c := NewClient(srv.URL+suffix, "test-key", WithPostWrites(postWrites))
+
+			if _, err := c.GetVersion(context.Background()); err != nil {
+				t.Errorf("%q: unexpected read error: %s", suffix, err)
+			}
+			if gotPath != wantPath || gotMode != "version" {
+				t.Errorf("%q: expected read of %s with mode=version, got %s with mode=%q", suffix, wantPath, gotPath, gotMode)
+			}
+
+			if err := c.DeleteCategory(context.Background(), "tv"); err != nil {
+				t.Errorf("%q postWrites=%t: unexpected write error: %s", suffix, postWrites, err)
+			}
+			if gotPath != wantPath || gotMode != "del_config" {
+				t.Errorf("%q postWrites=%t: expected write to %s with mode=del_config, got %s with mode=%q", suffix, postWrites, wantPath, gotPath, gotMode)
+			}
+
+			srv.Close()
+		}
+	}
+}
+
+func TestDoRequestAPIPath(t *testing.T) {
+	for _, postWrites := range []bool{false, true} {
+		var gotPath string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			fmt.Fprint(w, `{"status": true}`)
+		}))
+
+		c := NewClient(srv.URL+"/sabnzbd", "test-key", WithAPIPath("/sab-api"), WithPostWrites(postWrites))
+
+		if _, err := c.GetVersion(context.Background()); err != nil {
+			t.Errorf("postWrites=%t: unexpected read error: %s", postWrites, err)
+		}
+		if gotPath != "/sabnzbd/sab-api" {
+			t.Errorf("postWrites=%t: expected read of /sabnzbd/sab-api, got %s", postWrites, gotPath)
+		}
+
+		if err := c.DeleteCategory(context.Background(), "tv"); err != nil {
+			t.Errorf("postWrites=%t: unexpected write error: %s", postWrites, err)
+		}
+		if gotPath != "/sabnzbd/sab-api" {
+			t.Errorf("postWrites=%t: expected write to /sabnzbd/sab-api, got %s", postWrites, gotPath)
+		}
+
+		srv.Close()
+	}
+}
+
+func TestDoRequestOutputFormat(t *testing.T) {
+	cases := map[string]struct {
+		query  string
+		opts   []Option
+		output []string
+	}{
+		"default":             {"", nil, []string{"json"}},
+		"url output replaced": {"?output=xml", nil, []string{"json"}},
+		"none":                {"", []Option{WithOutputFormat("")}, nil},
+		"none keeps url":      {"?output=xml", []Option{WithOutputFormat("")}, []string{"xml"}},
+	}
+
+	for name, tc := range cases {
+		var query url.Values
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			fmt.Fprint(w, `{"version": "4.3.2"}`)
+		}))
+
+		c := NewClient(srv.URL+"/"+tc.query, "test-key", tc.opts...)
+		if _, err := c.GetVersion(context.Background()); err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !reflect.DeepEqual(query["output"], tc.output) {
+			t.Errorf("%s: expected output %q, got %q", name, tc.output, query["output"])
+		}
+
+		srv.Close()
+	}
+}
+
+func TestDoRequestMaxResponseSize(t *testing.T) {
+	body := `{"version": "4.3.2"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	// A body of exactly the limit is read in full.
+	c := NewClient(srv.URL, "test-key", WithMaxResponseSize(int64(len(body))))
+	if _, err := c.GetVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error at the limit: %s", err)
+	}
+
+	c = NewClient(srv.URL, "test-key", WithMaxResponseSize(int64(len(body))-1))
+	_, err := c.GetVersion(context.Background())
+
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected ResponseTooLargeError, got %v", err)
+	}
+	if tooLarge.Mode != "version" || tooLarge.Limit != int64(len(body))-1 {
+		t.Errorf("unexpected error fields: %+v", tooLarge)
+	}
+	if !strings.Contains(err.Error(), "max_response_mb") {
+		t.Errorf("expected the error to mention max_response_mb, got %q", err)
+	}
+}
+
+func TestDoRequestOversizedBody(t *testing.T) {
+	// The server streams far more than the default limit; the client must
+	// stop reading rather than buffer it all.
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		chunk := []byte(strings.Repeat("x", 1<<20))
+		for i := 0; i < DefaultMaxResponseSize>>20+10; i++ {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	})
+
+	var resp map[string]interface{}
+	err := c.doRequest(context.Background(), url.Values{"mode": {"history"}}, &resp)
+
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != DefaultMaxResponseSize {
+		t.Fatalf("expected ResponseTooLargeError at the default limit, got %v", err)
+	}
+}
+
+func TestDoRequestValidateOnly(t *testing.T) {
+	var modes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modes = append(modes, r.URL.Query().Get("mode")+" "+r.URL.Query().Get("name"))
+		fmt.Fprint(w, `{"status": true}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "test-key", WithValidateOnly(true))
+
+	cases := map[string]struct {
+		params url.Values
+		sent   bool
+	}{
+		"get_config":   {url.Values{"mode": {"get_config"}}, true},
+		"test_server":  {url.Values{"mode": {"config"}, "name": {"test_server"}}, true},
+		"queue list":   {url.Values{"mode": {"queue"}}, true},
+		"set_config":   {url.Values{"mode": {"set_config"}, "section": {"misc"}}, false},
+		"del_config":   {url.Values{"mode": {"del_config"}, "section": {"servers"}}, false},
+		"addurl":       {url.Values{"mode": {"addurl"}, "name": {"http://example.com/a.nzb"}}, false},
+		"rss_now":      {url.Values{"mode": {"rss_now"}}, false},
+		"queue delete": {url.Values{"mode": {"queue"}, "name": {"delete"}, "value": {"SABnzbd_nzo_1"}}, false},
+		"backup":       {url.Values{"mode": {"config"}, "name": {"create_backup"}}, false},
+	}
+
+	for name, tc := range cases {
+		modes = nil
+
+		var resp map[string]interface{}
+		err := c.doRequest(context.Background(), tc.params, &resp)
+
+		var validateOnly *ValidateOnlyError
+		if tc.sent {
+			if err != nil || len(modes) != 1 {
+				t.Errorf("%s: expected the request to be sent, got %v and %d requests", name, err, len(modes))
+			}
+			continue
+		}
+		if !errors.As(err, &validateOnly) {
+			t.Errorf("%s: expected ValidateOnlyError, got %v", name, err)
+		} else if validateOnly.Mode != tc.params.Get("mode") {
+			t.Errorf("%s: expected mode %q, got %q", name, tc.params.Get("mode"), validateOnly.Mode)
+		}
+		if len(modes) != 0 {
+			t.Errorf("%s: expected no request, got %q", name, modes)
+		}
+	}
+}
+
+func TestValidateOnlyErrorRedacts(t *testing.T) {
+	c := NewClient("http://sabnzbd.invalid", "test-key", WithValidateOnly(true))
+
+	err := c.SetServer(context.Background(), &ServerInput{Name: "news", Host: "news.example.com", Password: "hunter2"})
+
+	var validateOnly *ValidateOnlyError
+	if !errors.As(err, &validateOnly) {
+		t.Fatalf("expected ValidateOnlyError, got %v", err)
+	}
+	if validateOnly.Params.Has("apikey") || validateOnly.Params.Has("output") {
+		t.Errorf("expected apikey and output to be left out, got %v", validateOnly.Params)
+	}
+	if got := validateOnly.Params.Get("password"); got != RedactedValue {
+		t.Errorf("expected the password to be redacted, got %q", got)
+	}
+	if validateOnly.Params.Get("host") != "news.example.com" {
+		t.Errorf("expected host to be kept, got %v", validateOnly.Params)
+	}
+	if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "test-key") {
+		t.Errorf("expected no secrets in the error, got %q", err)
+	}
+}
+
+func TestDoRequestBaseURLQuery(t *testing.T) {
+	var gotPath string
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"version": "4.3.2"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/sabnzbd/?token=abc&mode=queue&apikey=other", "test-key")
+	if _, err := c.GetVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotPath != "/sabnzbd/api" {
+		t.Errorf("expected path /sabnzbd/api, got %s", gotPath)
+	}
+
+	// The request's own parameters win, and none is sent twice.
+	want := url.Values{"token": {"abc"}, "mode": {"version"}, "apikey": {"test-key"}, "output": {"json"}}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("expected query %v, got %v", want, query)
+	}
+}
+
+// newRedirectingClient returns a client pointed at an http server below
+// /sabnzbd that redirects every request with status to an https server
+// answering with handler. The redirect keeps the path but drops the query.
+func newRedirectingClient(t *testing.T, status int, handler http.HandlerFunc, opts ...Option) (*Client, string) {
+	t.Helper()
+
+	tlsSrv := httptest.NewTLSServer(handler)
+	t.Cleanup(tlsSrv.Close)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, tlsSrv.URL+r.URL.Path, status)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.URL+"/sabnzbd", "test-key", opts...)
+	c.httpClient.Transport = tlsSrv.Client().Transport
+
+	return c, tlsSrv.URL + "/sabnzbd"
+}
+
+func TestDoRequestHTTPSRedirect(t *testing.T) {
+	var query url.Values
+	c, httpsURL := newRedirectingClient(t, http.StatusMovedPermanently, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"version": "4.3.2"}`)
+	})
+
+	if _, ok := c.HTTPSRedirect(); ok {
+		t.Fatal("expected no redirect before the first request")
+	}
+
+	if _, err := c.GetVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if query.Get("apikey") != "test-key" || query.Get("mode") != "version" {
+		t.Errorf("expected the dropped query to be restored, got %v", query)
+	}
+	if got, ok := c.HTTPSRedirect(); !ok || got != httpsURL {
+		t.Errorf("expected redirect to %s, got %q (%t)", httpsURL, got, ok)
+	}
+}
+
+func TestDoRequestHTTPSRedirectPost(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("apikey") != "test-key" {
+			t.Errorf("expected the form to survive the redirect, got %v (%v)", r.PostForm, err)
+		}
+		fmt.Fprint(w, `{"status": true}`)
+	}
+
+	// A 307 resends the POST with its body.
+	c, _ := newRedirectingClient(t, http.StatusTemporaryRedirect, handler, WithPostWrites(true))
+	if err := c.SetCategory(context.Background(), &CategoryInput{Name: "tv"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// A 302 would turn it into a bodyless GET.
+	c, httpsURL := newRedirectingClient(t, http.StatusFound, handler, WithPostWrites(true))
+	err := c.SetCategory(context.Background(), &CategoryInput{Name: "tv"})
+	if err == nil || !strings.Contains(err.Error(), httpsURL) {
+		t.Errorf("expected an error naming %s, got %v", httpsURL, err)
+	}
+}
+
+func TestConnectionStats(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version": "4.3.2"}`)
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetVersion(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first request dials; the others reuse its idle connection.
+	if reused, dialed := c.ConnectionStats(); reused != 2 || dialed != 1 {
+		t.Errorf("expected 2 reused and 1 dialed connections, got %d and %d", reused, dialed)
+	}
+}
+
+func TestDoRequestUserAgent(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.UserAgent()
+		fmt.Fprint(w, `{"status": true}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := NewClient(srv.URL, "test-key").GetVersion(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != DefaultUserAgent {
+		t.Errorf("expected User-Agent %q, got %q", DefaultUserAgent, got)
+	}
+
+	c := NewClient(srv.URL, "test-key", WithUserAgent("terraform-provider-sabnzbd/1.2.3"), WithPostWrites(true))
+	if err := c.DeleteCategory(context.Background(), "tv"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "terraform-provider-sabnzbd/1.2.3" {
+		t.Errorf("expected the configured User-Agent on writes, got %q", got)
+	}
+}
+
+func TestDoRequestWriteMethods(t *testing.T) {
+	for _, postWrites := range []bool{false, true} {
+		var method string
+		var received url.Values
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("parsing form: %s", err)
+			}
+			method = r.Method
+			if r.Method == http.MethodPost {
+				if r.URL.RawQuery != "" {
+					t.Errorf("POST request leaked params into the URL: %s", r.URL.RawQuery)
+				}
+				received = r.PostForm
+			} else {
+				received = r.URL.Query()
+			}
+			fmt.Fprint(w, `{"status": true}`)
+		}))
+		defer srv.Close()
+
+		c := NewClient(srv.URL, "test-key", WithPostWrites(postWrites))
+
+		if err := c.SetCategory(context.Background(), &CategoryInput{Name: "tv", Dir: "TV Shows"}); err != nil {
+			t.Fatalf("postWrites=%t: unexpected error: %s", postWrites, err)
+		}
+
+		wantMethod := http.MethodGet
+		if postWrites {
+			wantMethod = http.MethodPost
+		}
+		if method != wantMethod {
+			t.Errorf("postWrites=%t: expected %s, got %s", postWrites, wantMethod, method)
+		}
+		for key, want := range map[string]string{"mode": "set_config", "section": "categories", "name": "tv", "dir": "TV Shows", "apikey": "test-key", "output": "json"} {
+			if got := received.Get(key); got != want {
+				t.Errorf("postWrites=%t: expected %s=%q, got %q", postWrites, key, want, got)
+			}
+		}
+
+		// Reads always use GET.
+		if _, err := c.GetVersion(context.Background()); err != nil {
+			t.Fatalf("postWrites=%t: unexpected error: %s", postWrites, err)
+		}
+		if method != http.MethodGet {
+			t.Errorf("postWrites=%t: expected reads to use GET, got %s", postWrites, method)
+		}
+	}
+}
+
+// maxConcurrentWrites issues n parallel category writes against a server that
+// holds each request briefly, and returns the peak number in flight at once.
+func maxConcurrentWrites(t *testing.T, n int, opts ...Option) int32 {
+	t.Helper()
+
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"status": true}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "test-key", opts...)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := c.SetCategory(context.Background(), &CategoryInput{Name: fmt.Sprintf("cat%d", i)}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return peak
+}
+
+func TestSerializedWrites(t *testing.T) {
+	if peak := maxConcurrentWrites(t, 8); peak < 2 {
+		t.Errorf("expected overlapping writes without serialization, peak was %d", peak)
+	}
+
+	if peak := maxConcurrentWrites(t, 8, WithSerializedWrites(true)); peak != 1 {
+		t.Errorf("expected writes to be serialized, peak was %d", peak)
+	}
+}
+
+func TestValidateBaseURL(t *testing.T) {
+	for _, u := range []string{
+		"http://localhost:8080",
+		"https://sabnzbd.example.com",
+		"http://localhost:8080/",
+		"https://example.com/sabnzbd",
+		"http://localhost:8080/?output=json",
+	} {
+		if err := ValidateBaseURL(u); err != nil {
+			t.Errorf("%q: unexpected error: %s", u, err)
+		}
+	}
+
+	for _, u := range []string{
+		"localhost:8080",
+		"localhost",
+		"ftp://localhost:8080",
+		"http://",
+		"http:///sabnzbd",
+		"http://localhost:8080/?apikey=abc",
+		"http://localhost:8080/?",
+		"http://localhost:8080/#config",
+		"://localhost",
+	} {
+		if err := ValidateBaseURL(u); err == nil {
+			t.Errorf("%q: expected error, got nil", u)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apikey") != "test-key" {
+			fmt.Fprint(w, `{"status": false, "error": "API Key Incorrect"}`)
+			return
+		}
+		fmt.Fprint(w, `{"scripts": ["None"]}`)
+	})
+	if err := c.Ping(context.Background()); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.apiKey = "wrong-key"
+	var authErr *AuthError
+	if err := c.Ping(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError for a wrong key, got %v", err)
+	}
+
+	// A web server that is not SABnzbd.
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+	if err := c.Ping(context.Background()); err == nil {
+		t.Error("expected an error for a response without scripts")
+	}
+
+	var urlErr *url.Error
+	if err := NewClient("http://127.0.0.1:1", "test-key").Ping(context.Background()); !errors.As(err, &urlErr) {
+		t.Errorf("expected url.Error for an unreachable host, got %v", err)
+	}
+}
+
+func TestDoRequestAccessDenied(t *testing.T) {
+	const loginPage = "<!DOCTYPE html>\n<html><head><title>SABnzbd Login</title></head><body><form></form></body></html>"
+
+	cases := map[string]struct {
+		status      int
+		contentType string
+		body        string
+		html        bool
+	}{
+		"html 403":     {http.StatusForbidden, "text/html; charset=utf-8", loginPage, true},
+		"plain 403":    {http.StatusForbidden, "text/plain", "Access denied by proxy", false},
+		"401":          {http.StatusUnauthorized, "", "", false},
+		"login page":   {http.StatusOK, "text/html", loginPage, true},
+		"untyped html": {http.StatusOK, "", "  <html><body>Login</body></html>", true},
+	}
+
+	for name, tc := range cases {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if tc.contentType != "" {
+				w.Header().Set("Content-Type", tc.contentType)
+			}
+			w.WriteHeader(tc.status)
+			fmt.Fprint(w, tc.body)
+		})
+
+		var resp map[string]interface{}
+		err := c.doRequest(context.Background(), url.Values{"mode": {"get_config"}}, &resp)
+
+		var accessErr *AccessError
+		if !errors.As(err, &accessErr) {
+			t.Errorf("%s: expected AccessError, got %v", name, err)
+			continue
+		}
+		if accessErr.StatusCode != tc.status || accessErr.HTML != tc.html {
+			t.Errorf("%s: expected status %d and html %t, got %d and %t", name, tc.status, tc.html, accessErr.StatusCode, accessErr.HTML)
+		}
+		if !strings.Contains(err.Error(), "API may be disabled") {
+			t.Errorf("%s: expected the error to mention a disabled API, got %q", name, err)
+		}
+	}
+}
@@ -4,46 +4,66 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-// GetConfigResponse represents the full configuration response.
-type GetConfigResponse struct {
-	Config Config `json:"config"`
-}
-
 // Config represents SABnzbd configuration sections.
 type Config struct {
 	Misc       map[string]interface{} `json:"misc"`
-	Servers    []Server               `json:"servers"`
-	Categories []Category             `json:"categories"`
-	RSS        []RSSFeed              `json:"rss"`
+	Servers    ServerList             `json:"servers"`
+	Categories CategoryList           `json:"categories"`
+	RSS        RSSFeedList            `json:"rss"`
 	Sorters    []Sorter               `json:"sorters"`
 }
 
 // Server represents a news server configuration.
 type Server struct {
-	Name        string `json:"name"`
-	Host        string `json:"host"`
-	Port        int    `json:"port"`
-	Username    string `json:"username"`
-	Password    string `json:"password"`
-	Connections int    `json:"connections"`
-	SSL         int    `json:"ssl"`
-	SSLVerify   int    `json:"ssl_verify"`
-	SSLCiphers  string `json:"ssl_ciphers"`
-	Enable      int    `json:"enable"`
-	Optional    int    `json:"optional"`
-	Retention   int    `json:"retention"`
-	Timeout     int    `json:"timeout"`
-	Priority    int    `json:"priority"`
-	Required    int    `json:"required"`
-	Notes       string `json:"notes"`
-}
-
-// Category represents a download category configuration.
+	Name        string  `json:"name"`
+	Host        string  `json:"host"`
+	Port        int     `json:"port"`
+	Username    string  `json:"username"`
+	Password    string  `json:"password"`
+	Connections int     `json:"connections"`
+	SSL         IntBool `json:"ssl"`
+	SSLVerify   int     `json:"ssl_verify"`
+	SSLCiphers  string  `json:"ssl_ciphers"`
+	Enable      IntBool `json:"enable"`
+	Optional    IntBool `json:"optional"`
+	Retention   int     `json:"retention"`
+	Timeout     int     `json:"timeout"`
+	Priority    int     `json:"priority"`
+	Required    IntBool `json:"required"`
+	Notes       string  `json:"notes"`
+	SendGroup   IntBool `json:"send_group"`
+	ExpireDate  string  `json:"expire_date"`
+	Quota       string  `json:"quota"`
+}
+
+// UnmarshalJSON decodes a server, treating a missing enable field as enabled
+// to match SABnzbd's default.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type server Server
+
+	v := server{Enable: 1}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = Server(v)
+	return nil
+}
+
+// Category represents a download category configuration. Script is the
+// post-processing script; SABnzbd has no per-category pre-queue script, only
+// the global pre_script setting in misc.
 type Category struct {
 	Name     string `json:"name"`
 	Dir      string `json:"dir"`
@@ -51,17 +71,260 @@ type Category struct {
 	Priority int    `json:"priority"`
 	PP       string `json:"pp"`
 	Order    int    `json:"order"`
+
+	// Extra holds the category settings without a field above, such as
+	// newzbin, with non-string values in their JSON form.
+	Extra map[string]string `json:"-"`
+}
+
+// categoryFields are the JSON keys decoded into Category's typed fields.
+var categoryFields = map[string]bool{
+	"name":     true,
+	"dir":      true,
+	"script":   true,
+	"priority": true,
+	"pp":       true,
+	"order":    true,
+}
+
+// UnmarshalJSON decodes a category, collecting unknown settings in Extra.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	type category Category
+
+	// Priority is decoded separately since SABnzbd may write it as a string.
+	var decoded struct {
+		category
+		Priority json.RawMessage `json:"priority"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	v := decoded.category
+	priority, err := parseCategoryPriority(decoded.Priority)
+	if err != nil {
+		return fmt.Errorf("category %q: %w", v.Name, err)
+	}
+	v.Priority = priority
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key, raw := range fields {
+		if categoryFields[key] {
+			continue
+		}
+
+		value := string(raw)
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			value = s
+		}
+
+		if v.Extra == nil {
+			v.Extra = map[string]string{}
+		}
+		v.Extra[key] = value
+	}
+
+	*c = Category(v)
+	return nil
+}
+
+// MarshalJSON encodes a category with its Extra settings alongside the
+// typed ones.
+func (c Category) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(categoryFields)+len(c.Extra))
+	for key, value := range c.Extra {
+		fields[key] = value
+	}
+	fields["name"] = c.Name
+	fields["dir"] = c.Dir
+	fields["script"] = c.Script
+	fields["priority"] = c.Priority
+	fields["pp"] = c.PP
+	fields["order"] = c.Order
+
+	return json.Marshal(fields)
+}
+
+// IsCategoryField reports whether key is a category setting with its own
+// field, which cannot be set through Extra.
+func IsCategoryField(key string) bool {
+	return categoryFields[key]
+}
+
+// StringList is a list of strings that SABnzbd may encode either as a JSON
+// array or, when it holds a single entry, as a bare string.
+type StringList []string
+
+// UnmarshalJSON accepts both the array and the single-string forms.
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single == "" {
+			*l = StringList{}
+		} else {
+			*l = StringList{single}
+		}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected string or array of strings: %w", err)
+	}
+	*l = list
+
+	return nil
+}
+
+// IntBool is a 0/1 flag that SABnzbd encodes as a number, a numeric string
+// or, in some versions, a JSON boolean.
+type IntBool int
+
+// UnmarshalJSON accepts the number, string and boolean forms. An empty string
+// decodes as zero.
+func (b *IntBool) UnmarshalJSON(data []byte) error {
+	var flag bool
+	if err := json.Unmarshal(data, &flag); err == nil {
+		*b = 0
+		if flag {
+			*b = 1
+		}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "", "0", "false":
+			*b = 0
+		case "1", "true":
+			*b = 1
+		default:
+			return fmt.Errorf("expected 0, 1, true or false, got %q", s)
+		}
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("expected 0, 1, true or false: %w", err)
+	}
+	*b = IntBool(n)
+
+	return nil
+}
+
+// ServerList is the servers section of the configuration. Depending on the
+// SABnzbd version it is encoded as a JSON array or as an object keyed by
+// server name.
+type ServerList []Server
+
+// UnmarshalJSON accepts both the array and the object forms.
+func (l *ServerList) UnmarshalJSON(data []byte) error {
+	servers, err := unmarshalNamedList(data, func(s *Server, name string) {
+		if s.Name == "" {
+			s.Name = name
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("decoding servers: %w", err)
+	}
+	*l = servers
+
+	return nil
+}
+
+// CategoryList is the categories section of the configuration. Like
+// ServerList, it may be encoded as a JSON array or as an object keyed by
+// category name.
+type CategoryList []Category
+
+// UnmarshalJSON accepts both the array and the object forms.
+func (l *CategoryList) UnmarshalJSON(data []byte) error {
+	categories, err := unmarshalNamedList(data, func(c *Category, name string) {
+		if c.Name == "" {
+			c.Name = name
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("decoding categories: %w", err)
+	}
+	*l = categories
+
+	return nil
+}
+
+// RSSFeedList is the rss section of the configuration. Like ServerList, it
+// may be encoded as a JSON array or as an object keyed by feed name.
+type RSSFeedList []RSSFeed
+
+// UnmarshalJSON accepts both the array and the object forms.
+func (l *RSSFeedList) UnmarshalJSON(data []byte) error {
+	feeds, err := unmarshalNamedList(data, func(f *RSSFeed, name string) {
+		if f.Name == "" {
+			f.Name = name
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("decoding rss feeds: %w", err)
+	}
+	*l = feeds
+
+	return nil
+}
+
+// unmarshalNamedList decodes a configuration section that is either a JSON
+// array of items or an object mapping names to items. For the object form,
+// setName is called with each key, and the items keep the order in which they
+// appear in the document.
+func unmarshalNamedList[T any](data []byte, setName func(*T, string)) ([]T, error) {
+	var list []T
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		err := json.Unmarshal(data, &list)
+		return list, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	list = []T{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an item name, got %v", tok)
+		}
+
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return nil, err
+		}
+		setName(&item, name)
+		list = append(list, item)
+	}
+
+	return list, nil
 }
 
 // RSSFeed represents an RSS feed configuration.
 type RSSFeed struct {
-	Name     string   `json:"name"`
-	URI      []string `json:"uri"`
-	Cat      string   `json:"cat"`
-	PP       string   `json:"pp"`
-	Script   string   `json:"script"`
-	Enable   int      `json:"enable"`
-	Priority int      `json:"priority"`
+	Name     string     `json:"name"`
+	URI      StringList `json:"uri"`
+	Cat      string     `json:"cat"`
+	PP       string     `json:"pp"`
+	Script   string     `json:"script"`
+	Enable   IntBool    `json:"enable"`
+	Priority int        `json:"priority"`
 }
 
 // Sorter represents a sorting rule configuration.
@@ -70,21 +333,328 @@ type Sorter struct {
 	Order      int      `json:"order"`
 	SortString string   `json:"sort_string"`
 	SortCats   []string `json:"sort_cats"`
-	SortType   []int    `json:"sort_type"`
-	IsActive   int      `json:"is_active"`
+	SortType   []int    `json:"sort_type"` // see SortTypes
+	IsActive   IntBool  `json:"is_active"`
 }
 
 // GetConfig retrieves the full SABnzbd configuration.
 func (c *Client) GetConfig(ctx context.Context) (*Config, error) {
+	raw, err := c.getConfigJSON(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("getting config: decoding response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// ErrMissingMisc is returned when the configuration has no misc section.
+var ErrMissingMisc = errors.New("SABnzbd returned no misc section in its configuration; " +
+	"this points at an API, proxy or version problem rather than an empty configuration")
+
+// GetMiscConfig retrieves the misc section of the configuration. Every SABnzbd
+// version returns it, so an absent or null section fails with ErrMissingMisc
+// rather than reading as one where every setting is unset.
+func (c *Client) GetMiscConfig(ctx context.Context) (map[string]interface{}, error) {
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Misc == nil {
+		return nil, fmt.Errorf("getting config: %w", ErrMissingMisc)
+	}
+
+	return config.Misc, nil
+}
+
+// configSections are top-level sections every SABnzbd version returns,
+// used to recognize a configuration that is not wrapped in "config".
+var configSections = []string{"misc", "servers", "categories"}
+
+// getConfigJSON retrieves the get_config response and returns the object
+// holding the configuration sections. Current SABnzbd versions wrap the
+// sections in a "config" object, while some older versions, and proxies that
+// unwrap responses, return them at the top level. The shape of the response
+// is checked rather than the version, which would cost a request on every
+// read; the version is only fetched to explain a response of neither shape.
+func (c *Client) getConfigJSON(ctx context.Context) (json.RawMessage, error) {
 	params := url.Values{}
 	params.Set("mode", "get_config")
 
-	var resp GetConfigResponse
+	var resp map[string]json.RawMessage
 	if err := c.doRequest(ctx, params, &resp); err != nil {
 		return nil, fmt.Errorf("getting config: %w", err)
 	}
 
-	return &resp.Config, nil
+	if config, ok := resp["config"]; ok {
+		return config, nil
+	}
+
+	for _, section := range configSections {
+		if _, ok := resp[section]; ok {
+			raw, err := json.Marshal(resp)
+			if err != nil {
+				return nil, fmt.Errorf("getting config: %w", err)
+			}
+			return raw, nil
+		}
+	}
+
+	keys := make([]string, 0, len(resp))
+	for key := range resp {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	version, err := c.GetVersion(ctx)
+	if err != nil || version == "" {
+		version = "of unknown version"
+	}
+
+	return nil, fmt.Errorf("getting config: unrecognized response from SABnzbd %s: expected a config object "+
+		"or the %s sections, got keys [%s]", version, strings.Join(configSections, ", "), strings.Join(keys, ", "))
+}
+
+// miscInt reads a number from the misc section, which SABnzbd stores as a
+// string. A missing or empty value is 0.
+func miscInt(misc map[string]interface{}, key string) (int, error) {
+	switch v := misc[key].(type) {
+	case float64:
+		return int(v), nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", key, v, err)
+		}
+		return n, nil
+	}
+
+	return 0, nil
+}
+
+// miscIntBool reads a 0/1 flag from the misc section in any of the forms
+// IntBool accepts. A missing value is 0.
+func miscIntBool(misc map[string]interface{}, key string) (IntBool, error) {
+	var b IntBool
+
+	v, ok := misc[key]
+	if !ok {
+		return b, nil
+	}
+
+	raw, _ := json.Marshal(v)
+	if err := b.UnmarshalJSON(raw); err != nil {
+		return b, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return b, nil
+}
+
+// miscList reads a list from the misc section. SABnzbd returns lists as JSON
+// arrays, but a value stored as a comma-separated string is split, as
+// SABnzbd does when it parses one.
+func miscList(misc map[string]interface{}, key string) ([]string, error) {
+	v, ok := misc[key]
+	if !ok {
+		return []string{}, nil
+	}
+
+	var list StringList
+	raw, _ := json.Marshal(v)
+	if err := list.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	items := []string{}
+	for _, entry := range list {
+		for _, item := range strings.Split(entry, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// DeleteConfigSection removes every entry of the servers, categories or rss
+// section. SABnzbd's del_config only deletes a single named entry, so the
+// entries are listed and deleted one by one. The default category cannot be
+// deleted and is kept.
+//
+// This is destructive; it is meant for callers that explicitly ask to reset
+// a section, such as sabnzbd_categories with reset_on_create, and is never
+// used implicitly.
+func (c *Client) DeleteConfigSection(ctx context.Context, section string) error {
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	switch section {
+	case "servers":
+		for _, server := range config.Servers {
+			names = append(names, server.Name)
+		}
+	case "categories":
+		for _, cat := range config.Categories {
+			if cat.Name != DefaultCategoryName {
+				names = append(names, cat.Name)
+			}
+		}
+	case "rss":
+		for _, feed := range config.RSS {
+			names = append(names, feed.Name)
+		}
+	default:
+		return fmt.Errorf("deleting config section %q: only servers, categories and rss can be deleted", section)
+	}
+
+	for _, name := range names {
+		params := url.Values{}
+		params.Set("mode", "del_config")
+		params.Set("section", section)
+		params.Set("keyword", name)
+
+		var resp map[string]interface{}
+		if err := c.doRequest(ctx, params, &resp); err != nil {
+			return fmt.Errorf("deleting %s entry %q: %w", section, name, err)
+		}
+	}
+
+	return nil
+}
+
+// RedactedValue replaces secrets in a redacted configuration.
+const RedactedValue = "**REDACTED**"
+
+// secretConfigKeys are the configuration keys holding credentials.
+var secretConfigKeys = map[string]bool{
+	"api_key":   true,
+	"nzb_key":   true,
+	"password":  true,
+	"email_pwd": true,
+}
+
+// isSecretConfigKey reports whether a configuration key holds a credential.
+func isSecretConfigKey(key string) bool {
+	return secretConfigKeys[key] || strings.HasSuffix(key, "_password")
+}
+
+// GetRawConfig retrieves the full SABnzbd configuration as returned by the
+// API, including the sections the provider does not model.
+func (c *Client) GetRawConfig(ctx context.Context) (map[string]interface{}, error) {
+	raw, err := c.getConfigJSON(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("getting config: decoding response: %w", err)
+	}
+
+	return config, nil
+}
+
+// RedactConfig replaces the non-empty credentials anywhere in a raw
+// configuration, such as server passwords and the API keys, with
+// RedactedValue. The configuration is modified in place.
+func RedactConfig(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if s, ok := item.(string); ok {
+				if s != "" && isSecretConfigKey(key) {
+					v[key] = RedactedValue
+				}
+				continue
+			}
+			RedactConfig(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			RedactConfig(item)
+		}
+	}
+}
+
+// ExportConfig retrieves the full SABnzbd configuration as indented JSON.
+// Credentials are replaced with RedactedValue unless includeSecrets is set.
+func (c *Client) ExportConfig(ctx context.Context, includeSecrets bool) ([]byte, error) {
+	config, err := c.GetRawConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeSecrets {
+		RedactConfig(config)
+	}
+
+	// Map keys are sorted when encoding, so the export is stable across reads.
+	encoded, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("exporting config: encoding: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// CreateBackup asks SABnzbd to write a backup of its configuration and
+// database to its backup folder, and returns the path of the backup file on
+// the SABnzbd host.
+func (c *Client) CreateBackup(ctx context.Context) (string, error) {
+	params := url.Values{}
+	params.Set("mode", "config")
+	params.Set("name", "create_backup")
+
+	var resp struct {
+		Value *struct {
+			Result  bool   `json:"result"`
+			Message string `json:"message"`
+		} `json:"value"`
+	}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return "", fmt.Errorf("creating backup: %w", err)
+	}
+
+	if resp.Value == nil || !resp.Value.Result {
+		return "", errors.New("creating backup: SABnzbd could not write the backup; check that a backup folder is set")
+	}
+
+	return resp.Value.Message, nil
+}
+
+// StringifyConfigValues converts a raw configuration section into strings.
+// Strings are kept as they are, null becomes an empty string, and numbers,
+// booleans, lists and nested objects are JSON-encoded.
+func StringifyConfigValues(section map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string, len(section))
+	for key, value := range section {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case nil:
+			values[key] = ""
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s: %w", key, err)
+			}
+			values[key] = string(encoded)
+		}
+	}
+
+	return values, nil
 }
 
 // GetConfigSection retrieves a specific section of the configuration.
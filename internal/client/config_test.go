@@ -0,0 +1,373 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// configArrayPayload is a get_config response in the shape returned by
+// current SABnzbd releases, which list servers and categories as arrays.
+const configArrayPayload = `{"config": {
+	"misc": {"complete_dir": "/data/complete"},
+	"servers": [
+		{"name": "news.example.com", "displayname": "news.example.com", "host": "news.example.com", "port": 563, "connections": 8, "ssl": 1, "enable": 1},
+		{"name": "backup.example.com", "displayname": "backup.example.com", "host": "backup.example.com", "port": 119, "connections": 4, "ssl": 0, "enable": 0, "optional": 1}
+	],
+	"categories": [
+		{"name": "*", "order": 0, "pp": "3", "script": "None", "dir": "", "newzbin": "", "priority": 0},
+		{"name": "movies", "order": 1, "pp": "", "script": "Default", "dir": "Movies", "newzbin": "", "priority": -100}
+	]
+}}`
+
+// configObjectPayload is the same response in the shape returned by older
+// releases, which key servers and categories by name.
+const configObjectPayload = `{"config": {
+	"misc": {"complete_dir": "/data/complete"},
+	"servers": {
+		"news.example.com": {"displayname": "news.example.com", "host": "news.example.com", "port": 563, "connections": 8, "ssl": 1, "enable": 1},
+		"backup.example.com": {"displayname": "backup.example.com", "host": "backup.example.com", "port": 119, "connections": 4, "ssl": 0, "enable": 0, "optional": 1}
+	},
+	"categories": {
+		"*": {"order": 0, "pp": "3", "script": "None", "dir": "", "newzbin": "", "priority": 0},
+		"movies": {"order": 1, "pp": "", "script": "Default", "dir": "Movies", "newzbin": "", "priority": -100}
+	}
+}}`
+
+func TestGetConfigSectionShapes(t *testing.T) {
+	wantServers := ServerList{
+		{Name: "news.example.com", Host: "news.example.com", Port: 563, Connections: 8, SSL: 1, Enable: 1},
+		{Name: "backup.example.com", Host: "backup.example.com", Port: 119, Connections: 4, Enable: 0, Optional: 1},
+	}
+	wantCategories := CategoryList{
+		{Name: "*", PP: "3", Script: "None", Extra: map[string]string{"newzbin": ""}},
+		{Name: "movies", Order: 1, Script: "Default", Dir: "Movies", Priority: -100, Extra: map[string]string{"newzbin": ""}},
+	}
+
+	for name, payload := range map[string]string{"array": configArrayPayload, "object": configObjectPayload} {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, payload)
+			})
+
+			config, err := c.GetConfig(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(config.Servers, wantServers) {
+				t.Errorf("servers:\nexpected %+v\ngot      %+v", wantServers, config.Servers)
+			}
+			if !reflect.DeepEqual(config.Categories, wantCategories) {
+				t.Errorf("categories:\nexpected %+v\ngot      %+v", wantCategories, config.Categories)
+			}
+		})
+	}
+}
+
+func TestCategoryListInvalid(t *testing.T) {
+	for _, input := range []string{`"movies"`, `[{"name": 1}]`, `{"movies": []}`} {
+		var got CategoryList
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("%s: expected error, got %+v", input, got)
+		}
+	}
+}
+
+func TestCategoryPriorityUnmarshal(t *testing.T) {
+	cases := map[string]int{
+		`{"name": "tv", "priority": -100}`:      DefaultPriority,
+		`{"name": "tv", "priority": 0}`:         0,
+		`{"name": "tv", "priority": "-100"}`:    DefaultPriority,
+		`{"name": "tv", "priority": "Default"}`: DefaultPriority,
+		`{"name": "tv", "priority": "force"}`:   2,
+		`{"name": "tv", "priority": ""}`:        DefaultPriority,
+		`{"name": "tv"}`:                        DefaultPriority,
+	}
+
+	for input, want := range cases {
+		var got Category
+		if err := json.Unmarshal([]byte(input), &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", input, err)
+			continue
+		}
+		if got.Priority != want {
+			t.Errorf("%s: expected priority %d, got %d", input, want, got.Priority)
+		}
+		if _, ok := got.Extra["priority"]; ok {
+			t.Errorf("%s: expected priority to stay out of Extra", input)
+		}
+	}
+
+	var got Category
+	if err := json.Unmarshal([]byte(`{"name": "tv", "priority": "urgent"}`), &got); err == nil {
+		t.Errorf("expected an error for an unknown priority, got %d", got.Priority)
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {
+			"misc": {"api_key": "abc123", "nzb_key": "def456", "password": "", "password_file": "/config/passwords.txt", "port": "8080"},
+			"servers": [{"name": "news.example.com", "username": "user", "password": "secret", "connections": 8}],
+			"proxy": {"proxy_password": "hunter2"}
+		}}`)
+	})
+
+	config, err := c.GetRawConfig(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	RedactConfig(config)
+
+	want := map[string]interface{}{
+		"misc": map[string]interface{}{
+			"api_key":       RedactedValue,
+			"nzb_key":       RedactedValue,
+			"password":      "", // nothing to hide
+			"password_file": "/config/passwords.txt",
+			"port":          "8080",
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"name": "news.example.com", "username": "user", "password": RedactedValue, "connections": float64(8)},
+		},
+		"proxy": map[string]interface{}{"proxy_password": RedactedValue},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("expected %v, got %v", want, config)
+	}
+}
+
+func TestGetConfigResponseShapes(t *testing.T) {
+	payloads := map[string]string{
+		"wrapped lists": `{"config": {
+			"misc": {"port": "8080"},
+			"servers": [{"name": "news.example.com", "host": "news.example.com"}],
+			"categories": [{"name": "*"}, {"name": "tv"}]
+		}}`,
+		"wrapped keyed": `{"config": {
+			"misc": {"port": "8080"},
+			"servers": {"news.example.com": {"host": "news.example.com"}},
+			"categories": {"*": {}, "tv": {}}
+		}}`,
+		"unwrapped": `{
+			"misc": {"port": "8080"},
+			"servers": [{"name": "news.example.com", "host": "news.example.com"}],
+			"categories": [{"name": "*"}, {"name": "tv"}]
+		}`,
+	}
+
+	for name, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		config, err := c.GetConfig(context.Background())
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		if config.Misc["port"] != "8080" || len(config.Servers) != 1 || config.Servers[0].Name != "news.example.com" {
+			t.Errorf("%s: unexpected config: %+v", name, config)
+		}
+		if len(config.Categories) != 2 {
+			t.Errorf("%s: expected 2 categories, got %+v", name, config.Categories)
+		}
+
+		raw, err := c.GetRawConfig(context.Background())
+		if err != nil || raw["misc"] == nil {
+			t.Errorf("%s: expected the raw config to hold misc, got %v (%v)", name, raw, err)
+		}
+	}
+}
+
+func TestGetConfigUnrecognized(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") == "version" {
+			fmt.Fprint(w, `{"version": "0.5.6"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": true, "settings": {}}`)
+	})
+
+	_, err := c.GetConfig(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized response")
+	}
+	for _, want := range []string{"SABnzbd 0.5.6", "[settings, status]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to contain %q, got %q", want, err)
+		}
+	}
+}
+
+func TestGetMiscConfigMissing(t *testing.T) {
+	payloads := map[string]string{
+		"absent": `{"config": {"servers": [], "categories": []}}`,
+		"null":   `{"config": {"misc": null, "servers": []}}`,
+	}
+
+	for name, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		if _, err := c.GetMiscConfig(context.Background()); !errors.Is(err, ErrMissingMisc) {
+			t.Errorf("%s: expected ErrMissingMisc, got %v", name, err)
+		}
+		// Readers of misc settings must not report every setting as unset.
+		if folders, err := c.GetFolders(context.Background()); !errors.Is(err, ErrMissingMisc) {
+			t.Errorf("%s: expected GetFolders to fail with ErrMissingMisc, got %+v and %v", name, folders, err)
+		}
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {}}}`)
+	})
+	misc, err := c.GetMiscConfig(context.Background())
+	if err != nil || misc == nil || len(misc) != 0 {
+		t.Errorf("expected an empty misc section to be accepted, got %v and %v", misc, err)
+	}
+}
+
+func TestStringifyConfigValues(t *testing.T) {
+	got, err := StringifyConfigValues(map[string]interface{}{
+		"host":                "::",
+		"port":                float64(8080),
+		"enable_https":        false,
+		"unwanted_extensions": []interface{}{"exe", "com"},
+		"nested":              map[string]interface{}{"a": float64(1)},
+		"unset":               nil,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"host":                "::",
+		"port":                "8080",
+		"enable_https":        "false",
+		"unwanted_extensions": `["exe","com"]`,
+		"nested":              `{"a":1}`,
+		"unset":               "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestIntBoolUnmarshal(t *testing.T) {
+	cases := map[string]IntBool{
+		`1`:       1,
+		`0`:       0,
+		`true`:    1,
+		`false`:   0,
+		`"1"`:     1,
+		`"0"`:     0,
+		`"True"`:  1,
+		`"false"`: 0,
+		`""`:      0,
+	}
+
+	for payload, want := range cases {
+		var got IntBool
+		if err := json.Unmarshal([]byte(payload), &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", payload, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: expected %d, got %d", payload, want, got)
+		}
+	}
+
+	for _, payload := range []string{`"yes"`, `[1]`, `{}`} {
+		var got IntBool
+		if err := json.Unmarshal([]byte(payload), &got); err == nil {
+			t.Errorf("%s: expected an error", payload)
+		}
+	}
+}
+
+func TestServerUnmarshalBooleanFlags(t *testing.T) {
+	payload := `{"name": "a", "ssl": true, "enable": false, "optional": "1", "required": 0}`
+
+	var server Server
+	if err := json.Unmarshal([]byte(payload), &server); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if server.SSL != 1 || server.Enable != 0 || server.Optional != 1 || server.Required != 0 {
+		t.Errorf("unexpected flags: ssl=%d enable=%d optional=%d required=%d",
+			server.SSL, server.Enable, server.Optional, server.Required)
+	}
+}
+
+func TestDeleteConfigSection(t *testing.T) {
+	var deleted []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("mode") {
+		case "get_config":
+			fmt.Fprint(w, configArrayPayload)
+		case "del_config":
+			if q.Get("section") != "categories" {
+				t.Errorf("unexpected section %q", q.Get("section"))
+			}
+			deleted = append(deleted, q.Get("keyword"))
+			fmt.Fprint(w, `{"status": true}`)
+		}
+	})
+
+	if err := c.DeleteConfigSection(context.Background(), "categories"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The default category cannot be deleted, so only movies goes.
+	if want := []string{"movies"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("expected deletes of %v, got %v", want, deleted)
+	}
+
+	if err := c.DeleteConfigSection(context.Background(), "misc"); err == nil {
+		t.Error("expected an error for a section that cannot be deleted")
+	}
+}
+
+func TestCreateBackup(t *testing.T) {
+	cases := map[string]struct {
+		payload string
+		want    string
+		wantErr bool
+	}{
+		"written": {
+			payload: `{"value": {"result": true, "message": "/config/backup/sabnzbd_backup_sab_2026.10.16_12.00.00.zip"}}`,
+			want:    "/config/backup/sabnzbd_backup_sab_2026.10.16_12.00.00.zip",
+		},
+		"no backup folder": {payload: `{"value": {"result": false, "message": ""}}`, wantErr: true},
+		"unsupported":      {payload: `{"status": false, "error": "Not implemented"}`, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if q := r.URL.Query(); q.Get("mode") != "config" || q.Get("name") != "create_backup" {
+				t.Errorf("%s: unexpected request %s", name, r.URL.RawQuery)
+			}
+			fmt.Fprint(w, tc.payload)
+		})
+
+		got, err := c.CreateBackup(context.Background())
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: expected error %t, got %v", name, tc.wantErr, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %q, got %q", name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// emailSucceededMessage is the message SABnzbd reports for a test email that
+// was sent.
+const emailSucceededMessage = "Email succeeded"
+
+// TestEmail asks SABnzbd to send a test email with its current notification
+// settings. It reports whether the email was sent along with SABnzbd's
+// message; a failed send is not an error, only a failure to ask is.
+func (c *Client) TestEmail(ctx context.Context) (bool, string, error) {
+	params := url.Values{}
+	params.Set("mode", "config")
+	params.Set("name", "test_email")
+
+	// Some versions answer with a plain-text "ok", which leaves resp unset.
+	resp := struct {
+		Status bool `json:"status"`
+	}{Status: true}
+	err := c.doRequest(ctx, params, &resp)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false, apiErr.Message, nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("sending test email: %w", err)
+	}
+
+	if !resp.Status {
+		return false, "", nil
+	}
+
+	return true, emailSucceededMessage, nil
+}
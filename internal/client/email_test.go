@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClientTestEmail(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		sent    bool
+		message string
+	}{
+		"json success": {`{"status": true}`, true, emailSucceededMessage},
+		"plain text":   {"ok", true, emailSucceededMessage},
+		"failure":      {`{"status": false, "error": "Failed to connect to mail server"}`, false, "Failed to connect to mail server"},
+		"bare failure": {`{"status": false}`, false, ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("mode") != "config" || r.URL.Query().Get("name") != "test_email" {
+					t.Errorf("unexpected query %q", r.URL.RawQuery)
+				}
+				fmt.Fprint(w, tc.body)
+			})
+
+			sent, message, err := c.TestEmail(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if sent != tc.sent || message != tc.message {
+				t.Errorf("expected (%t, %q), got (%t, %q)", tc.sent, tc.message, sent, message)
+			}
+		})
+	}
+}
+
+func TestClientTestEmailAuthError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": false, "error": "API Key Incorrect"}`)
+	})
+
+	_, _, err := c.TestEmail(context.Background())
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Access SABnzbd grants to clients outside local_ranges.
+const (
+	InetExposureNone          = 0
+	InetExposureAddNZB        = 1
+	InetExposureAPI           = 2
+	InetExposureFullAPI       = 3
+	InetExposureWebUI         = 4
+	InetExposureExternalLogin = 5
+)
+
+// InetExposureLevels names the external access levels, indexed by the value
+// SABnzbd stores.
+var InetExposureLevels = []string{"none", "add_nzb", "api", "full_api", "web_ui", "external_login"}
+
+// ExternalAccessInput represents the input for updating the settings that
+// control which clients may reach SABnzbd.
+type ExternalAccessInput struct {
+	HostWhitelist []string
+	LocalRanges   []string
+	InetExposure  int
+}
+
+// ExternalAccess represents the external access settings from SABnzbd's misc
+// section. HostWhitelist lists the hostnames SABnzbd answers to besides IP
+// addresses, LocalRanges the networks it treats as local, and InetExposure
+// what clients outside them may do.
+type ExternalAccess struct {
+	HostWhitelist []string
+	LocalRanges   []string
+	InetExposure  int
+}
+
+// SetExternalAccess updates the external access settings.
+func (c *Client) SetExternalAccess(ctx context.Context, input *ExternalAccessInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("host_whitelist", strings.Join(input.HostWhitelist, ","))
+	params.Set("local_ranges", strings.Join(input.LocalRanges, ","))
+	params.Set("inet_exposure", strconv.Itoa(input.InetExposure))
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting external access config: %w", err)
+	}
+
+	return nil
+}
+
+// GetExternalAccess retrieves the external access settings.
+func (c *Client) GetExternalAccess(ctx context.Context) (*ExternalAccess, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &ExternalAccess{}
+
+	if access.HostWhitelist, err = miscList(misc, "host_whitelist"); err != nil {
+		return nil, err
+	}
+	if access.LocalRanges, err = miscList(misc, "local_ranges"); err != nil {
+		return nil, err
+	}
+	if access.InetExposure, err = miscInt(misc, "inet_exposure"); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
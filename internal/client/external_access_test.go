@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetExternalAccess(t *testing.T) {
+	payloads := map[string]string{
+		"lists":   `{"config": {"misc": {"host_whitelist": ["sabnzbd.lan", "nas"], "local_ranges": ["192.168.0.0/16"], "inet_exposure": 3}}}`,
+		"strings": `{"config": {"misc": {"host_whitelist": "sabnzbd.lan, nas", "local_ranges": "192.168.0.0/16", "inet_exposure": "3"}}}`,
+	}
+
+	for name, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		got, err := c.GetExternalAccess(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		want := &ExternalAccess{HostWhitelist: []string{"sabnzbd.lan", "nas"}, LocalRanges: []string{"192.168.0.0/16"}, InetExposure: InetExposureFullAPI}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func TestSetExternalAccess(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	input := &ExternalAccessInput{HostWhitelist: []string{"sabnzbd.lan", "nas"}, InetExposure: InetExposureWebUI}
+	if err := c.SetExternalAccess(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":        "misc",
+		"host_whitelist": "sabnzbd.lan,nas",
+		"local_ranges":   "",
+		"inet_exposure":  "4",
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
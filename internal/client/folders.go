@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // FoldersInput represents the input for updating folder configuration.
@@ -47,6 +48,27 @@ type Folders struct {
 	LogDir              string `json:"log_dir"`
 }
 
+// DefaultFolders holds the folder settings of a freshly installed SABnzbd,
+// which are also the sabnzbd_folders defaults.
+var DefaultFolders = Folders{
+	DownloadDir:         "Downloads/incomplete",
+	CompleteDir:         "Downloads/complete",
+	WatchedDirScanSpeed: 5,
+	AdminDir:            "admin",
+	BackupDir:           "backup",
+	LogDir:              "logs",
+}
+
+// folderFallbacks holds the values GetFolders reports for settings SABnzbd
+// leaves out of its config. They are taken from DefaultFolders, so an omitted
+// setting does not show up as drift.
+var folderFallbacks = Folders{
+	WatchedDirScanSpeed: DefaultFolders.WatchedDirScanSpeed,
+	AdminDir:            DefaultFolders.AdminDir,
+	BackupDir:           DefaultFolders.BackupDir,
+	LogDir:              DefaultFolders.LogDir,
+}
+
 // SetFolders updates the folder configuration.
 func (c *Client) SetFolders(ctx context.Context, input *FoldersInput) error {
 	params := url.Values{}
@@ -107,13 +129,12 @@ func (c *Client) SetFolders(ctx context.Context, input *FoldersInput) error {
 
 // GetFolders retrieves the folder configuration.
 func (c *Client) GetFolders(ctx context.Context) (*Folders, error) {
-	config, err := c.GetConfig(ctx)
+	misc, err := c.GetMiscConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	folders := &Folders{}
-	misc := config.Misc
+	folders := folderFallbacks
 
 	if v, ok := misc["download_dir"].(string); ok {
 		folders.DownloadDir = v
@@ -161,5 +182,64 @@ func (c *Client) GetFolders(ctx context.Context) (*Folders, error) {
 		folders.LogDir = v
 	}
 
-	return folders, nil
+	return &folders, nil
+}
+
+// GetBaseDir retrieves the folder SABnzbd resolves relative paths against,
+// which is the directory containing its configuration file.
+func (c *Client) GetBaseDir(ctx context.Context) (string, error) {
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if status.ConfigFn == "" {
+		return "", fmt.Errorf("SABnzbd did not report its configuration file location")
+	}
+
+	i := strings.LastIndexAny(status.ConfigFn, `/\`)
+	if i < 0 {
+		return "", fmt.Errorf("unable to determine base folder from %q", status.ConfigFn)
+	}
+	if i == 0 {
+		return status.ConfigFn[:1], nil
+	}
+
+	return status.ConfigFn[:i], nil
+}
+
+// IsAbsolutePath reports whether p is an absolute path on either a POSIX or
+// Windows host, since SABnzbd may run on a different OS than Terraform.
+func IsAbsolutePath(p string) bool {
+	if strings.HasPrefix(p, "/") || strings.HasPrefix(p, `\`) {
+		return true
+	}
+
+	return len(p) >= 3 && p[1] == ':' && (p[2] == '\\' || p[2] == '/') &&
+		((p[0] >= 'a' && p[0] <= 'z') || (p[0] >= 'A' && p[0] <= 'Z'))
+}
+
+// ResolvePath returns the effective absolute path of p, joining relative paths
+// onto baseDir using baseDir's separator. Empty paths resolve to empty.
+func ResolvePath(baseDir, p string) string {
+	if p == "" || IsAbsolutePath(p) {
+		return p
+	}
+
+	sep := "/"
+	if strings.Contains(baseDir, `\`) && !strings.Contains(baseDir, "/") {
+		sep = `\`
+	}
+
+	return strings.TrimRight(baseDir, `/\`) + sep + strings.TrimLeft(p, `/\`)
+}
+
+// ParseFreeThreshold converts a download_free or complete_free setting into
+// bytes. An empty setting disables the check and parses as 0.
+func ParseFreeThreshold(s string) (int64, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+
+	return ParseSize(s)
 }
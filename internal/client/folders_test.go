@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	cases := []struct {
+		baseDir string
+		path    string
+		want    string
+	}{
+		{"/config", "", ""},
+		{"/config", "Downloads/complete", "/config/Downloads/complete"},
+		{"/config/", "Downloads", "/config/Downloads"},
+		{"/config", "/data/complete", "/data/complete"},
+		{"/", "Downloads", "/Downloads"},
+		{`C:\Users\sab\AppData\Local\sabnzbd`, "Downloads", `C:\Users\sab\AppData\Local\sabnzbd\Downloads`},
+		{`C:\sabnzbd`, `D:\Downloads`, `D:\Downloads`},
+		{`C:\sabnzbd`, `\\nas\downloads`, `\\nas\downloads`},
+		{"/config", "C:/Downloads", "C:/Downloads"},
+	}
+
+	for _, tc := range cases {
+		if got := ResolvePath(tc.baseDir, tc.path); got != tc.want {
+			t.Errorf("ResolvePath(%q, %q): expected %q, got %q", tc.baseDir, tc.path, tc.want, got)
+		}
+	}
+}
+
+func TestGetBaseDir(t *testing.T) {
+	cases := map[string]string{
+		"/config/sabnzbd.ini":                    "/config",
+		"/sabnzbd.ini":                           "/",
+		`C:\Users\sab\AppData\Local\sabnzbd.ini`: `C:\Users\sab\AppData\Local`,
+	}
+
+	for configFn, want := range cases {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"status": {"configfn": %q}}`, configFn)
+		})
+
+		got, err := c.GetBaseDir(context.Background())
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", configFn, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %q, got %q", configFn, want, got)
+		}
+	}
+}
+
+func TestParseFreeThreshold(t *testing.T) {
+	cases := map[string]int64{
+		"":     0,
+		"  ":   0,
+		"10G":  10 << 30,
+		"500M": 500 << 20,
+		"1.5T": 3 << 39,
+	}
+
+	for input, want := range cases {
+		got, err := ParseFreeThreshold(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d, got %d", input, want, got)
+		}
+	}
+
+	if _, err := ParseFreeThreshold("10 furlongs"); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}
+
+func TestGetFoldersMissingKeys(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {"download_dir": "/data/incomplete", "admin_dir": "/config/admin"}}}`)
+	})
+
+	folders, err := c.GetFolders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if folders.DownloadDir != "/data/incomplete" || folders.AdminDir != "/config/admin" {
+		t.Errorf("expected returned keys to be kept, got %q and %q", folders.DownloadDir, folders.AdminDir)
+	}
+	if folders.BackupDir != "backup" || folders.LogDir != "logs" || folders.WatchedDirScanSpeed != 5 {
+		t.Errorf("expected defaults for missing keys, got backup_dir %q, log_dir %q, dirscan_speed %d",
+			folders.BackupDir, folders.LogDir, folders.WatchedDirScanSpeed)
+	}
+	if folders.CompleteDir != "" {
+		t.Errorf("expected complete_dir to stay empty, got %q", folders.CompleteDir)
+	}
+}
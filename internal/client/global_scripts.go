@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GlobalScriptsInput represents the input for updating the global script
+// hooks. An empty script is sent as NoScript.
+type GlobalScriptsInput struct {
+	PreScript      string
+	EndQueueScript string
+}
+
+// GlobalScripts represents the global script hooks from SABnzbd's misc
+// section: the pre-queue script run before a job is added, and the script
+// run when the queue finishes.
+type GlobalScripts struct {
+	PreScript      string
+	EndQueueScript string
+}
+
+// SetGlobalScripts updates the global script hooks.
+func (c *Client) SetGlobalScripts(ctx context.Context, input *GlobalScriptsInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("pre_script", scriptOrNone(input.PreScript))
+	params.Set("end_queue_script", scriptOrNone(input.EndQueueScript))
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting global scripts config: %w", err)
+	}
+
+	return nil
+}
+
+// GetGlobalScripts retrieves the global script hooks. A missing or empty
+// setting is reported as NoScript.
+func (c *Client) GetGlobalScripts(ctx context.Context) (*GlobalScripts, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := &GlobalScripts{PreScript: NoScript, EndQueueScript: NoScript}
+
+	if v, ok := misc["pre_script"].(string); ok {
+		scripts.PreScript = scriptOrNone(v)
+	}
+	if v, ok := misc["end_queue_script"].(string); ok {
+		scripts.EndQueueScript = scriptOrNone(v)
+	}
+
+	return scripts, nil
+}
+
+// scriptOrNone returns script, or NoScript if it is empty.
+func scriptOrNone(script string) string {
+	if script == "" {
+		return NoScript
+	}
+	return script
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetGlobalScripts(t *testing.T) {
+	payloads := map[string]*GlobalScripts{
+		`{"config": {"misc": {"pre_script": "filter.py", "end_queue_script": "None"}}}`: {PreScript: "filter.py", EndQueueScript: NoScript},
+		`{"config": {"misc": {"pre_script": "", "end_queue_script": "done.sh"}}}`:       {PreScript: NoScript, EndQueueScript: "done.sh"},
+		`{"config": {"misc": {}}}`: {PreScript: NoScript, EndQueueScript: NoScript},
+	}
+
+	for payload, want := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		got, err := c.GetGlobalScripts(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", payload, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", payload, want, got)
+		}
+	}
+}
+
+func TestSetGlobalScripts(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.SetGlobalScripts(context.Background(), &GlobalScriptsInput{PreScript: "filter.py"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":          "misc",
+		"pre_script":       "filter.py",
+		"end_queue_script": NoScript,
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
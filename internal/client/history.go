@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryStatus is a normalized history entry status.
+type HistoryStatus string
+
+const (
+	HistoryStatusCompleted  HistoryStatus = "completed"
+	HistoryStatusFailed     HistoryStatus = "failed"
+	HistoryStatusQueued     HistoryStatus = "queued"
+	HistoryStatusProcessing HistoryStatus = "processing"
+	HistoryStatusUnknown    HistoryStatus = "unknown"
+)
+
+// ParseHistoryStatus normalizes a status reported by SABnzbd. Jobs that are
+// still being post-processed (verifying, repairing, extracting and so on)
+// are reported as processing.
+func ParseHistoryStatus(status string) HistoryStatus {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "completed":
+		return HistoryStatusCompleted
+	case "failed":
+		return HistoryStatusFailed
+	case "queued":
+		return HistoryStatusQueued
+	case "fetching", "quickcheck", "verifying", "repairing", "extracting", "moving", "running":
+		return HistoryStatusProcessing
+	default:
+		return HistoryStatusUnknown
+	}
+}
+
+// HistorySlot represents a single entry in the SABnzbd history.
+type HistorySlot struct {
+	NzoID       string `json:"nzo_id"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Status      string `json:"status"`
+	Bytes       int64  `json:"bytes"`
+	Completed   int64  `json:"completed"`
+	FailMessage string `json:"fail_message"`
+}
+
+// FormatCompletedTime converts a Unix timestamp as reported in a history
+// slot to an RFC 3339 string in UTC. Jobs that have not completed report 0,
+// which is formatted as an empty string.
+func FormatCompletedTime(epoch int64) string {
+	if epoch <= 0 {
+		return ""
+	}
+
+	return time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+}
+
+// History represents one page of the SABnzbd history. NoOfSlots is the
+// total number of entries in the history, not the number in this page.
+type History struct {
+	NoOfSlots int           `json:"noofslots"`
+	Slots     []HistorySlot `json:"slots"`
+}
+
+// GetHistory retrieves up to limit history entries starting at offset start,
+// newest first.
+func (c *Client) GetHistory(ctx context.Context, start, limit int) (*History, error) {
+	params := url.Values{}
+	params.Set("mode", "history")
+	params.Set("start", strconv.Itoa(start))
+	params.Set("limit", strconv.Itoa(limit))
+
+	var resp struct {
+		History History `json:"history"`
+	}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return nil, fmt.Errorf("getting history: %w", err)
+	}
+
+	return &resp.History, nil
+}
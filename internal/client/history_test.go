@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetHistory(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("mode") != "history" || q.Get("start") != "20" || q.Get("limit") != "10" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"history": {"noofslots": 21, "slots": [
+			{"nzo_id": "SABnzbd_nzo_1", "name": "Some.Show.S01E01", "category": "tv", "status": "Completed", "bytes": 1048576, "completed": 1700000000, "fail_message": ""},
+			{"nzo_id": "SABnzbd_nzo_2", "name": "Broken.Release", "category": "tv", "status": "Failed", "bytes": 0, "completed": 1700000100, "fail_message": "Aborted, cannot be completed"}
+		]}}`)
+	})
+
+	history, err := c.GetHistory(context.Background(), 20, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if history.NoOfSlots != 21 {
+		t.Errorf("expected noofslots 21, got %d", history.NoOfSlots)
+	}
+	if len(history.Slots) != 2 {
+		t.Fatalf("expected 2 slots, got %d", len(history.Slots))
+	}
+	slot := history.Slots[0]
+	if slot.NzoID != "SABnzbd_nzo_1" || slot.Category != "tv" || slot.Bytes != 1048576 || slot.Completed != 1700000000 {
+		t.Errorf("unexpected slot: %+v", slot)
+	}
+	if msg := history.Slots[1].FailMessage; msg != "Aborted, cannot be completed" {
+		t.Errorf("expected fail_message to be parsed, got %q", msg)
+	}
+}
+
+func TestParseHistoryStatus(t *testing.T) {
+	tests := map[string]HistoryStatus{
+		"Completed":  HistoryStatusCompleted,
+		"Failed":     HistoryStatusFailed,
+		"Queued":     HistoryStatusQueued,
+		"Extracting": HistoryStatusProcessing,
+		"Verifying":  HistoryStatusProcessing,
+		" failed ":   HistoryStatusFailed,
+		"":           HistoryStatusUnknown,
+		"Deleted":    HistoryStatusUnknown,
+	}
+
+	for in, want := range tests {
+		if got := ParseHistoryStatus(in); got != want {
+			t.Errorf("ParseHistoryStatus(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestFormatCompletedTime(t *testing.T) {
+	tests := map[int64]string{
+		1700000000: "2023-11-14T22:13:20Z",
+		1:          "1970-01-01T00:00:01Z",
+		0:          "",
+		-5:         "",
+	}
+
+	for in, want := range tests {
+		if got := FormatCompletedTime(in); got != want {
+			t.Errorf("FormatCompletedTime(%d): expected %q, got %q", in, want, got)
+		}
+	}
+}
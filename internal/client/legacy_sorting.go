@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SortersVersion is the SABnzbd version that replaced the TV, movie and date
+// sorting settings of the misc section with the sorters section.
+const SortersVersion = "4.0.0"
+
+// UsesLegacySorting reports whether SABnzbd version keeps its sorting
+// settings in the misc section rather than in sorters.
+func UsesLegacySorting(version string) (bool, error) {
+	modern, err := VersionAtLeast(version, SortersVersion)
+	if err != nil {
+		return false, err
+	}
+
+	return !modern, nil
+}
+
+// LegacySortingInput represents the input for updating the sorting settings
+// of SABnzbd versions before SortersVersion.
+type LegacySortingInput struct {
+	TVSorting       bool
+	TVSortString    string
+	TVCategories    []string
+	MovieSorting    bool
+	MovieSortString string
+	MovieSortExtra  string
+	MovieCategories []string
+	DateSorting     bool
+	DateSortString  string
+	DateCategories  []string
+}
+
+// LegacySorting represents the sorting settings from the misc section of
+// SABnzbd versions before SortersVersion.
+type LegacySorting struct {
+	TVSorting       IntBool
+	TVSortString    string
+	TVCategories    []string
+	MovieSorting    IntBool
+	MovieSortString string
+	MovieSortExtra  string
+	MovieCategories []string
+	DateSorting     IntBool
+	DateSortString  string
+	DateCategories  []string
+}
+
+// SetLegacySorting updates the legacy sorting settings.
+func (c *Client) SetLegacySorting(ctx context.Context, input *LegacySortingInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("enable_tv_sorting", boolToInt(input.TVSorting))
+	params.Set("tv_sort_string", input.TVSortString)
+	// SABnzbd parses list settings from a comma-separated string.
+	params.Set("tv_categories", strings.Join(input.TVCategories, ","))
+	params.Set("enable_movie_sorting", boolToInt(input.MovieSorting))
+	params.Set("movie_sort_string", input.MovieSortString)
+	params.Set("movie_sort_extra", input.MovieSortExtra)
+	params.Set("movie_categories", strings.Join(input.MovieCategories, ","))
+	params.Set("enable_date_sorting", boolToInt(input.DateSorting))
+	params.Set("date_sort_string", input.DateSortString)
+	params.Set("date_categories", strings.Join(input.DateCategories, ","))
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting legacy sorting config: %w", err)
+	}
+
+	return nil
+}
+
+// GetLegacySorting retrieves the legacy sorting settings.
+func (c *Client) GetLegacySorting(ctx context.Context) (*LegacySorting, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sorting := &LegacySorting{}
+
+	for key, field := range map[string]*IntBool{
+		"enable_tv_sorting":    &sorting.TVSorting,
+		"enable_movie_sorting": &sorting.MovieSorting,
+		"enable_date_sorting":  &sorting.DateSorting,
+	} {
+		if *field, err = miscIntBool(misc, key); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, field := range map[string]*[]string{
+		"tv_categories":    &sorting.TVCategories,
+		"movie_categories": &sorting.MovieCategories,
+		"date_categories":  &sorting.DateCategories,
+	} {
+		if *field, err = miscList(misc, key); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, field := range map[string]*string{
+		"tv_sort_string":    &sorting.TVSortString,
+		"movie_sort_string": &sorting.MovieSortString,
+		"movie_sort_extra":  &sorting.MovieSortExtra,
+		"date_sort_string":  &sorting.DateSortString,
+	} {
+		if v, ok := misc[key].(string); ok {
+			*field = v
+		}
+	}
+
+	return sorting, nil
+}
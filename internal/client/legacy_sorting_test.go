@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUsesLegacySorting(t *testing.T) {
+	cases := map[string]bool{
+		"3.7.2":      true,
+		"3.0":        true,
+		"4.0.0Beta1": false,
+		"4.0.0":      false,
+		"4.3.2":      false,
+		"10.1.0":     false,
+	}
+
+	for version, want := range cases {
+		got, err := UsesLegacySorting(version)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", version, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %t, got %t", version, want, got)
+		}
+	}
+
+	for _, version := range []string{"", "develop"} {
+		if _, err := UsesLegacySorting(version); err == nil {
+			t.Errorf("%q: expected an error", version)
+		}
+	}
+}
+
+func TestGetLegacySorting(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"config": {"misc": {"enable_tv_sorting": 1, "tv_sort_string": "%sn/%en.%ext", ` +
+			`"tv_categories": ["tv", "anime"], "enable_movie_sorting": "0", "movie_sort_extra": " CD%1", ` +
+			`"movie_categories": "movies", "enable_date_sorting": false}}}`))
+	})
+
+	got, err := c.GetLegacySorting(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &LegacySorting{
+		TVSorting:       1,
+		TVSortString:    "%sn/%en.%ext",
+		TVCategories:    []string{"tv", "anime"},
+		MovieSortExtra:  " CD%1",
+		MovieCategories: []string{"movies"},
+		DateCategories:  []string{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PerformanceInput represents the input for updating the performance
+// settings of the misc section.
+type PerformanceInput struct {
+	CacheLimit          string
+	DirectUnpackThreads int
+}
+
+// Performance represents the performance settings from SABnzbd's misc
+// section. CacheLimit is the size of the in-memory article cache, such as
+// "1G", and DirectUnpackThreads how many jobs are unpacked while downloading
+// at once.
+type Performance struct {
+	CacheLimit          string
+	DirectUnpackThreads int
+}
+
+// DefaultPerformance holds the performance settings of a freshly installed
+// SABnzbd.
+var DefaultPerformance = Performance{
+	CacheLimit:          "1G",
+	DirectUnpackThreads: 3,
+}
+
+// SetPerformance updates the performance settings.
+func (c *Client) SetPerformance(ctx context.Context, input *PerformanceInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("cache_limit", input.CacheLimit)
+	params.Set("direct_unpack_threads", strconv.Itoa(input.DirectUnpackThreads))
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting performance config: %w", err)
+	}
+
+	return nil
+}
+
+// GetPerformance retrieves the performance settings.
+func (c *Client) GetPerformance(ctx context.Context) (*Performance, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	performance := &Performance{}
+
+	// A cache limit without a unit is a number of bytes, which SABnzbd may
+	// return as a JSON number.
+	switch v := misc["cache_limit"].(type) {
+	case string:
+		performance.CacheLimit = v
+	case float64:
+		performance.CacheLimit = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if performance.DirectUnpackThreads, err = miscInt(misc, "direct_unpack_threads"); err != nil {
+		return nil, err
+	}
+
+	return performance, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetPerformance(t *testing.T) {
+	cases := map[string]struct {
+		payload string
+		want    *Performance
+	}{
+		"strings": {
+			payload: `{"config": {"misc": {"cache_limit": "512M", "direct_unpack_threads": "2"}}}`,
+			want:    &Performance{CacheLimit: "512M", DirectUnpackThreads: 2},
+		},
+		"numbers": {
+			payload: `{"config": {"misc": {"cache_limit": 1048576, "direct_unpack_threads": 2}}}`,
+			want:    &Performance{CacheLimit: "1048576", DirectUnpackThreads: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, tc.payload)
+		})
+
+		got, err := c.GetPerformance(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %+v, got %+v", name, tc.want, got)
+		}
+	}
+}
+
+func TestSetPerformance(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	input := &PerformanceInput{CacheLimit: "2G", DirectUnpackThreads: 4}
+	if err := c.SetPerformance(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":               "misc",
+		"cache_limit":           "2G",
+		"direct_unpack_threads": "4",
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
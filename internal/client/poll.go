@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrPollTimeout is wrapped by errors returned when a polled condition does
+// not hold before the timeout.
+var ErrPollTimeout = errors.New("timed out waiting for condition")
+
+// pollCondition checks a condition once. It reports whether the condition
+// holds and a description of the state it observed, used in timeout errors.
+// An error stops polling.
+type pollCondition func(ctx context.Context) (done bool, state string, err error)
+
+// maxPollInterval caps the wait between checks as pollUntil backs off.
+const maxPollInterval = 15 * time.Second
+
+// nextPollInterval returns the wait after one of delay: twice as long, up to
+// maxPollInterval. A delay already above the cap is kept.
+func nextPollInterval(delay time.Duration) time.Duration {
+	if delay >= maxPollInterval {
+		return delay
+	}
+	return min(2*delay, maxPollInterval)
+}
+
+// pollUntil calls check until it reports done, returns an error, the timeout
+// elapses or ctx is cancelled. The first check runs immediately and the wait
+// before the next starts at interval, doubling after each check up to
+// maxPollInterval. Each wait is jittered like other retries, so clients
+// polling the same restart do not check in lockstep. On timeout the error
+// wraps ErrPollTimeout and includes the last observed state.
+func pollUntil(ctx context.Context, interval, timeout time.Duration, check pollCondition) error {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	delay := interval
+	var last string
+	for {
+		done, state, err := check(ctx)
+		// A check cut short by the timeout is reported as a timeout below.
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		if err == nil {
+			if done {
+				return nil
+			}
+			last = state
+		}
+
+		timer := time.NewTimer(jitterDelay(delay, rand.Float64))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err := parent.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w after %s (last state: %q)", ErrPollTimeout, timeout, last)
+		case <-timer.C:
+		}
+		delay = nextPollInterval(delay)
+	}
+}
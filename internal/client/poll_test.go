@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPollUntilSucceeds(t *testing.T) {
+	calls := 0
+	err := pollUntil(context.Background(), time.Millisecond, time.Second, func(ctx context.Context) (bool, string, error) {
+		calls++
+		return calls == 3, fmt.Sprintf("call %d", calls), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 checks, got %d", calls)
+	}
+}
+
+func TestPollUntilTimeout(t *testing.T) {
+	err := pollUntil(context.Background(), time.Millisecond, 20*time.Millisecond, func(ctx context.Context) (bool, string, error) {
+		return false, "restarting", nil
+	})
+	if !errors.Is(err, ErrPollTimeout) {
+		t.Fatalf("expected ErrPollTimeout, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"restarting"`) {
+		t.Errorf("expected the last state in the error, got %q", err)
+	}
+}
+
+func TestPollUntilCheckError(t *testing.T) {
+	want := errors.New("boom")
+	err := pollUntil(context.Background(), time.Millisecond, time.Second, func(ctx context.Context) (bool, string, error) {
+		return false, "", want
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("expected the check error, got %v", err)
+	}
+}
+
+func TestPollUntilCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := pollUntil(ctx, 5*time.Millisecond, time.Minute, func(ctx context.Context) (bool, string, error) {
+		return false, "waiting", nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected a prompt return after cancellation, took %s", elapsed)
+	}
+}
+
+func TestNextPollInterval(t *testing.T) {
+	tests := []struct {
+		delay, want time.Duration
+	}{
+		{time.Second, 2 * time.Second},
+		{4 * time.Second, 8 * time.Second},
+		{10 * time.Second, maxPollInterval},
+		{maxPollInterval, maxPollInterval},
+		{time.Minute, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := nextPollInterval(tt.delay); got != tt.want {
+			t.Errorf("nextPollInterval(%s) = %s, want %s", tt.delay, got, tt.want)
+		}
+	}
+}
+
+func TestPollUntilBacksOff(t *testing.T) {
+	const interval = 5 * time.Millisecond
+
+	var calls []time.Time
+	err := pollUntil(context.Background(), interval, time.Second, func(ctx context.Context) (bool, string, error) {
+		calls = append(calls, time.Now())
+		return len(calls) == 4, "waiting", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The waits are interval, twice it and four times it, each jittered.
+	shortest := time.Duration(float64(4*interval) * (1 - retryJitter))
+	if gap := calls[3].Sub(calls[2]); gap < shortest {
+		t.Errorf("expected the third wait to be at least %s, got %s", shortest, gap)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidateNZBURL checks that nzbURL is an absolute http or https URL.
+func ValidateNZBURL(nzbURL string) error {
+	u, err := url.Parse(nzbURL)
+	if err != nil {
+		return fmt.Errorf("invalid NZB URL %q: %w", nzbURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid NZB URL %q: scheme must be http or https", nzbURL)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid NZB URL %q: missing host", nzbURL)
+	}
+
+	return nil
+}
+
+// ErrJobNotIdentified is returned by AddURL when SABnzbd accepted the URL but
+// the job it queued could not be told apart from the others.
+var ErrJobNotIdentified = errors.New("SABnzbd accepted the URL but the queued job could not be identified")
+
+// AddURL enqueues an NZB fetched from nzbURL and returns its nzo_id.
+func (c *Client) AddURL(ctx context.Context, nzbURL, category, priority string) (string, error) {
+	if err := ValidateNZBURL(nzbURL); err != nil {
+		return "", err
+	}
+
+	// Older SABnzbd versions answer with a plain-text "ok" and no nzo_id, so
+	// note the jobs already queued to find the new one afterwards.
+	before, err := c.GetQueue(ctx)
+	if err != nil {
+		return "", fmt.Errorf("adding URL: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("mode", "addurl")
+	params.Set("name", nzbURL)
+	if category != "" {
+		params.Set("cat", category)
+	}
+	if priority != "" {
+		params.Set("priority", priority)
+	}
+
+	var resp struct {
+		Status bool     `json:"status"`
+		NzoIDs []string `json:"nzo_ids"`
+	}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return "", fmt.Errorf("adding URL: %w", err)
+	}
+
+	if len(resp.NzoIDs) > 0 {
+		return resp.NzoIDs[0], nil
+	}
+
+	after, err := c.GetQueue(ctx)
+	if err != nil {
+		return "", fmt.Errorf("adding URL: finding the queued job: %w", err)
+	}
+
+	nzoID, ok := addedJob(before.Slots, after.Slots, nzbURL)
+	if !ok {
+		return "", fmt.Errorf("adding URL %s: %w", nzbURL, ErrJobNotIdentified)
+	}
+
+	return nzoID, nil
+}
+
+// addedJob returns the nzo_id of the job in after that is not in before. A
+// job still named after nzbURL, as SABnzbd names jobs until the NZB is
+// fetched, is preferred when several were added at once.
+func addedJob(before, after []QueueSlot, nzbURL string) (string, bool) {
+	queued := make(map[string]bool, len(before))
+	for _, slot := range before {
+		queued[slot.NzoID] = true
+	}
+
+	var added []QueueSlot
+	for _, slot := range after {
+		if !queued[slot.NzoID] {
+			added = append(added, slot)
+		}
+	}
+
+	for _, slot := range added {
+		if slot.Filename == nzbURL {
+			return slot.NzoID, true
+		}
+	}
+
+	if len(added) != 1 {
+		return "", false
+	}
+
+	return added[0].NzoID, true
+}
+
+// DeleteQueueItem removes an item from the download queue.
+func (c *Client) DeleteQueueItem(ctx context.Context, nzoID string) error {
+	params := url.Values{}
+	params.Set("mode", "queue")
+	params.Set("name", "delete")
+	params.Set("value", nzoID)
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("deleting queue item %s: %w", nzoID, err)
+	}
+
+	return nil
+}
+
+// QueueSlot represents a single job in the SABnzbd download queue.
+type QueueSlot struct {
+	NzoID      string `json:"nzo_id"`
+	Filename   string `json:"filename"`
+	Category   string `json:"cat"`
+	Status     string `json:"status"`
+	Percentage Float  `json:"percentage"`
+	TimeLeft   string `json:"timeleft"`
+	ETA        string `json:"eta"`
+}
+
+// Queue represents the SABnzbd download queue. TimeLeft is formatted as
+// H:MM:SS, or D:HH:MM:SS from a day on, and ETA is the local time the queue
+// is expected to finish, or "unknown".
+type Queue struct {
+	Status    string      `json:"status"`
+	Paused    bool        `json:"paused"`
+	TimeLeft  string      `json:"timeleft"`
+	ETA       string      `json:"eta"`
+	NoOfSlots int         `json:"noofslots"`
+	Slots     []QueueSlot `json:"slots"`
+}
+
+// GetQueue retrieves the download queue with every job in it.
+func (c *Client) GetQueue(ctx context.Context) (*Queue, error) {
+	params := url.Values{}
+	params.Set("mode", "queue")
+
+	var resp struct {
+		Queue Queue `json:"queue"`
+	}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return nil, fmt.Errorf("getting queue: %w", err)
+	}
+
+	return &resp.Queue, nil
+}
+
+// ParseTimeLeft converts a time left as reported by SABnzbd, such as
+// "0:12:34" or "1:02:03:04" with days in front, into seconds. An empty value
+// parses as 0.
+func ParseTimeLeft(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return 0, fmt.Errorf("invalid time left %q: expected H:MM:SS", s)
+	}
+
+	// Seconds, minutes and hours are base 60; a fourth part counts days.
+	units := []int64{1, 60, 60 * 60, 24 * 60 * 60}
+	var seconds int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time left %q: %q is not a number", s, part)
+		}
+		seconds += n * units[len(parts)-1-i]
+	}
+
+	return seconds, nil
+}
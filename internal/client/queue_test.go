@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidateNZBURL(t *testing.T) {
+	for _, u := range []string{"http://indexer.example.com/get/123.nzb", "https://example.com/a.nzb?apikey=x"} {
+		if err := ValidateNZBURL(u); err != nil {
+			t.Errorf("%q: unexpected error: %s", u, err)
+		}
+	}
+
+	for _, u := range []string{"", "example.com/a.nzb", "ftp://example.com/a.nzb", "http://", "://bad"} {
+		if err := ValidateNZBURL(u); err == nil {
+			t.Errorf("%q: expected error, got nil", u)
+		}
+	}
+}
+
+func TestAddURL(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("mode") == "queue" {
+			fmt.Fprint(w, `{"queue": {"slots": []}}`)
+			return
+		}
+		if q.Get("mode") != "addurl" || q.Get("name") != "https://example.com/a.nzb" || q.Get("cat") != "tv" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"status": true, "nzo_ids": ["SABnzbd_nzo_abc123"]}`)
+	})
+
+	nzoID, err := c.AddURL(context.Background(), "https://example.com/a.nzb", "tv", "0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if nzoID != "SABnzbd_nzo_abc123" {
+		t.Errorf("expected nzo_id SABnzbd_nzo_abc123, got %q", nzoID)
+	}
+}
+
+func TestAddURLPlainTextOK(t *testing.T) {
+	cases := map[string]struct {
+		added   []string
+		want    string
+		wantErr bool
+	}{
+		"found by name":   {added: []string{"Other.Job", "https://example.com/a.nzb"}, want: "SABnzbd_nzo_2"},
+		"only new job":    {added: []string{"A.Fetched.Release"}, want: "SABnzbd_nzo_1"},
+		"several new":     {added: []string{"Other.Job", "Another.Job"}, wantErr: true},
+		"nothing new yet": {wantErr: true},
+	}
+
+	for name, tc := range cases {
+		queued := []QueueSlot{{NzoID: "SABnzbd_nzo_0", Filename: "Existing"}}
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("mode") {
+			case "addurl":
+				for i, filename := range tc.added {
+					queued = append(queued, QueueSlot{NzoID: fmt.Sprintf("SABnzbd_nzo_%d", i+1), Filename: filename})
+				}
+				fmt.Fprint(w, "ok\n")
+			case "queue":
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"queue": Queue{Slots: queued}})
+			}
+		})
+
+		got, err := c.AddURL(context.Background(), "https://example.com/a.nzb", "", "")
+		if tc.wantErr {
+			if !errors.Is(err, ErrJobNotIdentified) {
+				t.Errorf("%s: expected ErrJobNotIdentified, got %v", name, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%s: expected %q, got %q and %v", name, tc.want, got, err)
+		}
+	}
+}
+
+func TestGetQueue(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") != "queue" {
+			t.Errorf("expected mode=queue, got %q", r.URL.Query().Get("mode"))
+		}
+		fmt.Fprint(w, `{"queue": {"status": "Downloading", "paused": false, "timeleft": "0:12:34", "eta": "21:45 Fri 16 Oct",
+			"noofslots": 1, "slots": [{"nzo_id": "SABnzbd_nzo_1", "filename": "Show.S01E01", "cat": "tv",
+			"status": "Downloading", "percentage": "45", "timeleft": "0:05:12", "eta": "21:38 Fri 16 Oct"}]}}`)
+	})
+
+	got, err := c.GetQueue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &Queue{
+		Status:    "Downloading",
+		TimeLeft:  "0:12:34",
+		ETA:       "21:45 Fri 16 Oct",
+		NoOfSlots: 1,
+		Slots: []QueueSlot{{
+			NzoID: "SABnzbd_nzo_1", Filename: "Show.S01E01", Category: "tv", Status: "Downloading",
+			Percentage: 45, TimeLeft: "0:05:12", ETA: "21:38 Fri 16 Oct",
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParseTimeLeft(t *testing.T) {
+	valid := map[string]int64{
+		"":           0,
+		"0:00:00":    0,
+		"0:12:34":    754,
+		"12:05":      725,
+		"25:00:00":   90000,
+		"1:02:03:04": 93784,
+		" 0:00:09 ":  9,
+	}
+	for input, want := range valid {
+		got, err := ParseTimeLeft(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d seconds, got %d", input, want, got)
+		}
+	}
+
+	for _, input := range []string{"12", "unknown", "0:xx:00", "1:2:3:4:5", "0:-1:00"} {
+		if _, err := ParseTimeLeft(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
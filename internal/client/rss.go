@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GetRSSFeeds retrieves all RSS feed configurations, in the order SABnzbd
+// lists them. It returns an empty, non-nil slice when there are none.
+func (c *Client) GetRSSFeeds(ctx context.Context) ([]RSSFeed, error) {
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := []RSSFeed{}
+	return append(feeds, config.RSS...), nil
+}
+
+// GetRSSFeed retrieves a specific RSS feed configuration by name. The feed's
+// URIs are returned in the order SABnzbd stores them.
+func (c *Client) GetRSSFeed(ctx context.Context, name string) (*RSSFeed, error) {
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feed := range config.RSS {
+		if feed.Name == name {
+			return &feed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("rss feed %q %w", name, ErrNotFound)
+}
+
+// TriggerRSS asks SABnzbd to read its RSS feeds now instead of waiting for
+// the next scheduled check. SABnzbd's rss_now reads every enabled feed and
+// cannot target one, so a non-empty feedName is only checked to exist.
+func (c *Client) TriggerRSS(ctx context.Context, feedName string) error {
+	if feedName != "" {
+		if _, err := c.GetRSSFeed(ctx, feedName); err != nil {
+			return err
+		}
+	}
+
+	params := url.Values{}
+	params.Set("mode", "rss_now")
+
+	// Older versions answer with a plain-text "ok", newer ones with
+	// {"status": true}.
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("triggering rss feeds: %w", err)
+	}
+
+	return nil
+}
+
+// feedKeyParams are the query parameters indexers carry API keys in: apikey
+// (or api_key) in newznab API URLs and r in newznab RSS URLs.
+var feedKeyParams = map[string]bool{
+	"apikey":  true,
+	"api_key": true,
+	"r":       true,
+}
+
+// RedactFeedURI returns uri with the value of every API key parameter in its
+// query replaced by RedactedValue, and reports whether it had any. The rest
+// of the URI is left as it is.
+func RedactFeedURI(uri string) (string, bool) {
+	base, query, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri, false
+	}
+
+	redacted := false
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(key); err == nil && feedKeyParams[strings.ToLower(name)] && value != "" {
+			pairs[i] = key + "=" + RedactedValue
+			redacted = true
+		}
+	}
+
+	return base + "?" + strings.Join(pairs, "&"), redacted
+}
+
+// NewznabFeedURL builds the URL of a newznab indexer feed listing the newest
+// items in categories, for use as an RSS feed URI. baseURL is the indexer's
+// address, with or without the trailing /api.
+func NewznabFeedURL(baseURL, apiKey string, categories []int) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid indexer URL %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid indexer URL %q: scheme must be http or https", baseURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid indexer URL %q: missing host", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid indexer URL %q: must not include a query string or fragment", baseURL)
+	}
+
+	if strings.TrimSpace(apiKey) == "" {
+		return "", fmt.Errorf("missing indexer API key")
+	}
+
+	if len(categories) == 0 {
+		return "", fmt.Errorf("at least one category is required")
+	}
+	cats := make([]string, len(categories))
+	for i, category := range categories {
+		if category <= 0 {
+			return "", fmt.Errorf("invalid newznab category %d: must be positive", category)
+		}
+		cats[i] = strconv.Itoa(category)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api") {
+		u.Path += "/api"
+	}
+
+	params := url.Values{}
+	params.Set("t", "search")
+	params.Set("cat", strings.Join(cats, ","))
+	params.Set("dl", "1")
+	params.Set("apikey", apiKey)
+	u.RawQuery = params.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetRSSFeedURIShapes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"rss": [
+			{"name": "single", "uri": "https://indexer.example.com/rss?t=5000"},
+			{"name": "multiple", "uri": ["https://b.example.com/rss", "https://a.example.com/rss", "https://c.example.com/rss"]},
+			{"name": "empty", "uri": ""}
+		]}}`)
+	})
+
+	cases := map[string][]string{
+		"single":   {"https://indexer.example.com/rss?t=5000"},
+		"multiple": {"https://b.example.com/rss", "https://a.example.com/rss", "https://c.example.com/rss"},
+		"empty":    {},
+	}
+
+	for name, want := range cases {
+		feed, err := c.GetRSSFeed(context.Background(), name)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if !reflect.DeepEqual([]string(feed.URI), want) {
+			t.Errorf("%s: expected %q, got %q", name, want, feed.URI)
+		}
+	}
+
+	if _, err := c.GetRSSFeed(context.Background(), "missing"); err == nil {
+		t.Error("expected not found error, got nil")
+	}
+}
+
+func TestGetRSSFeeds(t *testing.T) {
+	payloads := map[string]string{
+		"array":  `{"config": {"rss": [{"name": "tv", "uri": "https://a.example.com/rss", "enable": 1}, {"name": "movies", "uri": ["https://b.example.com/rss"], "enable": true}]}}`,
+		"object": `{"config": {"rss": {"tv": {"uri": "https://a.example.com/rss", "enable": 1}, "movies": {"uri": ["https://b.example.com/rss"], "enable": "1"}}}}`,
+	}
+
+	for shape, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		feeds, err := c.GetRSSFeeds(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", shape, err)
+		}
+
+		want := []RSSFeed{
+			{Name: "tv", URI: StringList{"https://a.example.com/rss"}, Enable: 1},
+			{Name: "movies", URI: StringList{"https://b.example.com/rss"}, Enable: 1},
+		}
+		if !reflect.DeepEqual(feeds, want) {
+			t.Errorf("%s: expected %+v, got %+v", shape, want, feeds)
+		}
+	}
+}
+
+func TestGetRSSFeedsEmpty(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {}}}`)
+	})
+
+	feeds, err := c.GetRSSFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if feeds == nil || len(feeds) != 0 {
+		t.Errorf("expected an empty, non-nil list, got %#v", feeds)
+	}
+}
+
+func TestTriggerRSS(t *testing.T) {
+	for _, body := range []string{"ok\n", `{"status": true}`} {
+		var modes []string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			mode := r.URL.Query().Get("mode")
+			modes = append(modes, mode)
+			if mode == "get_config" {
+				fmt.Fprint(w, `{"config": {"rss": [{"name": "tv", "uri": "https://a.example.com/rss"}]}}`)
+				return
+			}
+			fmt.Fprint(w, body)
+		})
+
+		if err := c.TriggerRSS(context.Background(), "tv"); err != nil {
+			t.Fatalf("%q: unexpected error: %s", body, err)
+		}
+		if want := []string{"get_config", "rss_now"}; !reflect.DeepEqual(modes, want) {
+			t.Errorf("%q: expected requests %q, got %q", body, want, modes)
+		}
+	}
+}
+
+func TestTriggerRSSAllFeeds(t *testing.T) {
+	var modes []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		modes = append(modes, r.URL.Query().Get("mode"))
+		fmt.Fprint(w, "ok")
+	})
+
+	if err := c.TriggerRSS(context.Background(), ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"rss_now"}; !reflect.DeepEqual(modes, want) {
+		t.Errorf("expected requests %q, got %q", want, modes)
+	}
+}
+
+func TestTriggerRSSUnknownFeed(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") == "rss_now" {
+			t.Error("rss_now sent for an unknown feed")
+		}
+		fmt.Fprint(w, `{"config": {"rss": []}}`)
+	})
+
+	if err := c.TriggerRSS(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRedactFeedURI(t *testing.T) {
+	cases := []struct {
+		uri, want string
+		redacted  bool
+	}{
+		{"https://indexer.example.com/api?t=search&cat=5030&apikey=secret&dl=1", "https://indexer.example.com/api?t=search&cat=5030&apikey=" + RedactedValue + "&dl=1", true},
+		{"https://indexer.example.com/rss?t=5000&dl=1&i=42&r=secret", "https://indexer.example.com/rss?t=5000&dl=1&i=42&r=" + RedactedValue, true},
+		{"https://indexer.example.com/api?API_KEY=secret", "https://indexer.example.com/api?API_KEY=" + RedactedValue, true},
+		{"https://indexer.example.com/rss?t=5000", "https://indexer.example.com/rss?t=5000", false},
+		{"https://indexer.example.com/api?apikey=", "https://indexer.example.com/api?apikey=", false},
+		{"https://indexer.example.com/rss", "https://indexer.example.com/rss", false},
+	}
+
+	for _, tc := range cases {
+		got, redacted := RedactFeedURI(tc.uri)
+		if got != tc.want || redacted != tc.redacted {
+			t.Errorf("%q: expected %q (%t), got %q (%t)", tc.uri, tc.want, tc.redacted, got, redacted)
+		}
+	}
+}
+
+func TestNewznabFeedURL(t *testing.T) {
+	for _, base := range []string{"https://indexer.example.com", "https://indexer.example.com/", "https://indexer.example.com/api"} {
+		got, err := NewznabFeedURL(base, "secret", []int{5030, 5040})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", base, err)
+		}
+		if want := "https://indexer.example.com/api?apikey=secret&cat=5030%2C5040&dl=1&t=search"; got != want {
+			t.Errorf("%q: expected %q, got %q", base, want, got)
+		}
+	}
+
+	invalid := []struct {
+		base       string
+		apiKey     string
+		categories []int
+	}{
+		{"indexer.example.com", "secret", []int{5030}},
+		{"ftp://indexer.example.com", "secret", []int{5030}},
+		{"https://indexer.example.com/api?t=search", "secret", []int{5030}},
+		{"https://indexer.example.com", "", []int{5030}},
+		{"https://indexer.example.com", "secret", nil},
+		{"https://indexer.example.com", "secret", []int{0}},
+	}
+	for _, tc := range invalid {
+		if _, err := NewznabFeedURL(tc.base, tc.apiKey, tc.categories); err == nil {
+			t.Errorf("%+v: expected error, got nil", tc)
+		}
+	}
+}
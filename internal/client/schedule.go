@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ScheduleActions lists the scheduler actions understood by SABnzbd.
+var ScheduleActions = []string{
+	"resume",
+	"pause",
+	"pause_all",
+	"shutdown",
+	"restart",
+	"speedlimit",
+	"pause_post",
+	"resume_post",
+	"scan_folder",
+	"rss_scan",
+	"remove_failed",
+	"remove_completed",
+	"pause_all_low",
+	"pause_all_normal",
+	"pause_all_high",
+	"resume_all_low",
+	"resume_all_normal",
+	"resume_all_high",
+	"enable_server",
+	"disable_server",
+	"enable_quota",
+	"disable_quota",
+	"create_backup",
+}
+
+// IsValidScheduleAction reports whether action is a known scheduler action.
+func IsValidScheduleAction(action string) bool {
+	for _, a := range ScheduleActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Schedule represents a single scheduler entry. SABnzbd stores these in the
+// misc section's schedlines as "<enabled> <minute> <hour> <days> <action> [value]",
+// where days is a string of weekday digits (1=Monday through 7=Sunday).
+type Schedule struct {
+	Enabled bool
+	Minute  int
+	Hour    int
+	Days    []int
+	Action  string
+	Value   string
+}
+
+// ParseSchedule parses a SABnzbd schedline into a Schedule.
+func ParseSchedule(line string) (*Schedule, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("schedule %q: expected at least 5 fields, got %d", line, len(fields))
+	}
+
+	enabled, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("schedule %q: invalid enabled flag: %w", line, err)
+	}
+
+	minute, err := strconv.Atoi(fields[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("schedule %q: invalid minute %q", line, fields[1])
+	}
+
+	hour, err := strconv.Atoi(fields[2])
+	if err != nil || hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("schedule %q: invalid hour %q", line, fields[2])
+	}
+
+	var days []int
+	for _, d := range fields[3] {
+		if d < '1' || d > '7' {
+			return nil, fmt.Errorf("schedule %q: invalid day %q", line, d)
+		}
+		days = append(days, int(d-'0'))
+	}
+
+	return &Schedule{
+		Enabled: enabled == 1,
+		Minute:  minute,
+		Hour:    hour,
+		Days:    normalizeDays(days),
+		Action:  fields[4],
+		Value:   strings.Join(fields[5:], " "),
+	}, nil
+}
+
+// String formats the schedule as a SABnzbd schedline.
+func (s *Schedule) String() string {
+	var days strings.Builder
+	for _, d := range normalizeDays(s.Days) {
+		days.WriteString(strconv.Itoa(d))
+	}
+
+	line := fmt.Sprintf("%s %d %d %s %s", boolToInt(s.Enabled), s.Minute, s.Hour, days.String(), s.Action)
+	if s.Value != "" {
+		line += " " + s.Value
+	}
+
+	return line
+}
+
+// Key returns the schedline without its enabled flag. SABnzbd's web interface
+// can only toggle or remove a schedule, so the key identifies an entry for as
+// long as it exists.
+func (s *Schedule) Key() string {
+	_, key, _ := strings.Cut(s.String(), " ")
+	return key
+}
+
+// ParseScheduleKey parses a key returned by Schedule.Key. The schedule is
+// enabled unless key is a full schedline, which is also accepted.
+func ParseScheduleKey(key string) (*Schedule, error) {
+	// In a full schedline the fourth field holds the days, where a key has
+	// the action.
+	if fields := strings.Fields(key); len(fields) >= 5 && strings.Trim(fields[3], "1234567") == "" {
+		if schedule, err := ParseSchedule(key); err == nil {
+			return schedule, nil
+		}
+	}
+
+	schedule, err := ParseSchedule("1 " + key)
+	if err != nil {
+		return nil, fmt.Errorf("schedule key %q: %w", key, err)
+	}
+
+	return schedule, nil
+}
+
+// normalizeDays returns the days sorted with duplicates removed.
+func normalizeDays(days []int) []int {
+	seen := make(map[int]bool, len(days))
+	result := make([]int, 0, len(days))
+	for _, d := range days {
+		if !seen[d] {
+			seen[d] = true
+			result = append(result, d)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// GetSchedules retrieves the raw schedlines from the misc section.
+func (c *Client) GetSchedules(ctx context.Context) ([]string, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	switch v := misc["schedlines"].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				lines = append(lines, s)
+			}
+		}
+	case string:
+		if v != "" {
+			lines = append(lines, v)
+		}
+	}
+
+	return lines, nil
+}
+
+// UpdateSchedules reads the schedlines, passes them to update and writes back
+// the lines it returns, unless it reports no change. The whole sequence holds
+// a lock, so schedules updated in parallel through the same client are not
+// lost.
+func (c *Client) UpdateSchedules(ctx context.Context, update func(lines []string) ([]string, bool)) error {
+	select {
+	case c.schedulesSem <- struct{}{}:
+		defer func() { <-c.schedulesSem }()
+	case <-ctx.Done():
+		return fmt.Errorf("waiting to update schedules: %w", ctx.Err())
+	}
+
+	lines, err := c.GetSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	lines, changed := update(lines)
+	if !changed {
+		return nil
+	}
+
+	return c.SetSchedules(ctx, lines)
+}
+
+// SetSchedules replaces the schedlines in the misc section. Each line is sent
+// as its own parameter, which SABnzbd receives as a list, since joining them
+// would split a line whose argument contains a comma. A single line is padded
+// with an empty one, which SABnzbd skips, so it is still received as a list
+// rather than split on spaces.
+func (c *Client) SetSchedules(ctx context.Context, lines []string) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	switch len(lines) {
+	case 0:
+		params.Set("schedlines", "")
+	case 1:
+		params["schedlines"] = []string{lines[0], ""}
+	default:
+		params["schedlines"] = lines
+	}
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting schedules: %w", err)
+	}
+
+	return nil
+}
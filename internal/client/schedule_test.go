@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := []struct {
+		line string
+		want Schedule
+	}{
+		{
+			line: "1 0 7 1234567 pause",
+			want: Schedule{Enabled: true, Minute: 0, Hour: 7, Days: []int{1, 2, 3, 4, 5, 6, 7}, Action: "pause"},
+		},
+		{
+			line: "0 30 23 71 speedlimit 500K",
+			want: Schedule{Enabled: false, Minute: 30, Hour: 23, Days: []int{1, 7}, Action: "speedlimit", Value: "500K"},
+		},
+		{
+			line: "1 15 2 12345 disable_server news example com",
+			want: Schedule{Enabled: true, Minute: 15, Hour: 2, Days: []int{1, 2, 3, 4, 5}, Action: "disable_server", Value: "news example com"},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseSchedule(tc.line)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tc.want) {
+			t.Errorf("%q: expected %+v, got %+v", tc.line, tc.want, *got)
+		}
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"1 0 7 1234567",
+		"x 0 7 1234567 pause",
+		"1 60 7 1234567 pause",
+		"1 0 24 1234567 pause",
+		"1 0 7 1238 pause",
+	} {
+		if _, err := ParseSchedule(line); err == nil {
+			t.Errorf("%q: expected error, got nil", line)
+		}
+	}
+}
+
+func TestScheduleString(t *testing.T) {
+	cases := []struct {
+		schedule Schedule
+		want     string
+	}{
+		{
+			schedule: Schedule{Enabled: true, Minute: 0, Hour: 7, Days: []int{7, 1, 3, 1}, Action: "resume"},
+			want:     "1 0 7 137 resume",
+		},
+		{
+			schedule: Schedule{Enabled: false, Minute: 45, Hour: 22, Days: []int{6, 7}, Action: "speedlimit", Value: "50"},
+			want:     "0 45 22 67 speedlimit 50",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := tc.schedule.String(); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+
+		parsed, err := ParseSchedule(tc.want)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", tc.want, err)
+		}
+		if got := parsed.String(); got != tc.want {
+			t.Errorf("round trip: expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestScheduleKey(t *testing.T) {
+	schedule := Schedule{Enabled: false, Minute: 30, Hour: 23, Days: []int{7, 1}, Action: "speedlimit", Value: "500K"}
+	if got, want := schedule.Key(), "30 23 17 speedlimit 500K"; got != want {
+		t.Fatalf("expected key %q, got %q", want, got)
+	}
+
+	// Both a key and a full schedline, as earlier IDs held, parse to the
+	// same key.
+	for _, key := range []string{"30 23 17 speedlimit 500K", "0 30 23 17 speedlimit 500K"} {
+		parsed, err := ParseScheduleKey(key)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", key, err)
+			continue
+		}
+		if parsed.Key() != schedule.Key() {
+			t.Errorf("%q: expected key %q, got %q", key, schedule.Key(), parsed.Key())
+		}
+	}
+
+	if _, err := ParseScheduleKey("30 23 17"); err == nil {
+		t.Error("expected an error for a key without an action")
+	}
+}
+
+func TestSetSchedules(t *testing.T) {
+	cases := []struct {
+		lines []string
+		want  []string
+	}{
+		{lines: nil, want: []string{""}},
+		{lines: []string{"1 0 7 1234567 pause"}, want: []string{"1 0 7 1234567 pause", ""}},
+		{
+			lines: []string{"1 0 7 1234567 pause", "1 0 1 12345 disable_server news,example"},
+			want:  []string{"1 0 7 1234567 pause", "1 0 1 12345 disable_server news,example"},
+		},
+	}
+
+	for _, tc := range cases {
+		var got []string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Query()["schedlines"]
+			fmt.Fprint(w, `{"status": true}`)
+		})
+
+		if err := c.SetSchedules(context.Background(), tc.lines); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: expected schedlines %q, got %q", tc.lines, tc.want, got)
+		}
+	}
+}
@@ -5,9 +5,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 // ServerInput represents the input for creating/updating a server.
@@ -28,6 +31,9 @@ type ServerInput struct {
 	Priority    int
 	Required    bool
 	Notes       string
+	SendGroup   bool
+	ExpireDate  string
+	Quota       string
 }
 
 // SetServer creates or updates a news server configuration.
@@ -46,11 +52,17 @@ func (c *Client) SetServer(ctx context.Context, input *ServerInput) error {
 	params.Set("ssl_ciphers", input.SSLCiphers)
 	params.Set("enable", boolToInt(input.Enable))
 	params.Set("optional", boolToInt(input.Optional))
+	// Always send retention: 0 means unlimited, not "keep the current value".
 	params.Set("retention", strconv.Itoa(input.Retention))
 	params.Set("timeout", strconv.Itoa(input.Timeout))
 	params.Set("priority", strconv.Itoa(input.Priority))
 	params.Set("required", boolToInt(input.Required))
 	params.Set("notes", input.Notes)
+	params.Set("send_group", boolToInt(input.SendGroup))
+	// expire_date and quota were added in SABnzbd 3.2.0; older versions
+	// ignore them. Empty values clear them.
+	params.Set("expire_date", input.ExpireDate)
+	params.Set("quota", input.Quota)
 
 	var resp map[string]interface{}
 	if err := c.doRequest(ctx, params, &resp); err != nil {
@@ -73,10 +85,11 @@ func (c *Client) GetServer(ctx context.Context, name string) (*Server, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("server %q not found", name)
+	return nil, fmt.Errorf("server %q %w", name, ErrNotFound)
 }
 
-// DeleteServer removes a server configuration.
+// DeleteServer removes a server configuration. As with DeleteCategory, the
+// server is identified by keyword, not name.
 func (c *Client) DeleteServer(ctx context.Context, name string) error {
 	params := url.Values{}
 	params.Set("mode", "del_config")
@@ -91,6 +104,107 @@ func (c *Client) DeleteServer(ctx context.Context, name string) error {
 	return nil
 }
 
+// TestServer asks SABnzbd to connect to the named server with its stored
+// settings. It reports whether the connection succeeded along with SABnzbd's
+// message; an unreachable server is not an error, only a failure to ask is.
+func (c *Client) TestServer(ctx context.Context, name string) (bool, string, error) {
+	server, err := c.GetServer(ctx, name)
+	if err != nil {
+		return false, "", err
+	}
+
+	// test_server takes the settings to try rather than a server name; the
+	// name only lets SABnzbd substitute the stored password for the masked
+	// one get_config returns.
+	params := url.Values{}
+	params.Set("mode", "config")
+	params.Set("name", "test_server")
+	params.Set("server", server.Name)
+	params.Set("host", server.Host)
+	params.Set("port", strconv.Itoa(server.Port))
+	params.Set("username", server.Username)
+	params.Set("password", server.Password)
+	params.Set("connections", strconv.Itoa(server.Connections))
+	params.Set("ssl", strconv.Itoa(int(server.SSL)))
+	params.Set("ssl_verify", strconv.Itoa(server.SSLVerify))
+	params.Set("ssl_ciphers", server.SSLCiphers)
+	params.Set("timeout", strconv.Itoa(server.Timeout))
+
+	// Some versions answer with a plain-text "ok", which leaves resp unset.
+	var resp struct {
+		Value *struct {
+			Result  bool   `json:"result"`
+			Message string `json:"message"`
+		} `json:"value"`
+	}
+	err = c.doRequest(ctx, params, &resp)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false, apiErr.Message, nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("testing server %q: %w", name, err)
+	}
+
+	if resp.Value == nil {
+		return true, "", nil
+	}
+
+	return resp.Value.Result, resp.Value.Message, nil
+}
+
+// SSLCipherPresets maps named cipher presets to OpenSSL cipher strings for a
+// server's ssl_ciphers. They only restrict TLS 1.2 and earlier; TLS 1.3
+// always uses its own suites.
+//
+//   - modern: forward-secret AEAD ciphers only, which rules out TLS 1.1 and
+//     earlier.
+//   - compatible: adds DHE key exchange for older servers.
+//   - legacy: adds CBC ciphers for servers that offer nothing newer.
+var SSLCipherPresets = map[string]string{
+	"modern":     "ECDHE+AESGCM:ECDHE+CHACHA20",
+	"compatible": "ECDHE+AESGCM:ECDHE+CHACHA20:DHE+AESGCM:DHE+CHACHA20",
+	"legacy":     "ECDHE+AESGCM:ECDHE+CHACHA20:DHE+AESGCM:DHE+CHACHA20:ECDHE+AES:DHE+AES:AES:!aNULL:!eNULL:!MD5:!RC4:!3DES",
+}
+
+// SSLCipherPresetNames returns the names of SSLCipherPresets, sorted.
+func SSLCipherPresetNames() []string {
+	names := make([]string, 0, len(SSLCipherPresets))
+	for name := range SSLCipherPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ParseRetentionDays converts a retention period such as "90", "90d", "12w"
+// or "6m" into the number of days SABnzbd expects. Weeks are 7 days and
+// months are 30 days.
+func ParseRetentionDays(s string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "d"):
+		value = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		multiplier = 7
+		value = strings.TrimSuffix(value, "w")
+	case strings.HasSuffix(value, "m"):
+		multiplier = 30
+		value = strings.TrimSuffix(value, "m")
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid retention %q: expected a non-negative number with an optional d, w or m suffix", s)
+	}
+
+	return n * multiplier, nil
+}
+
 func boolToInt(b bool) string {
 	if b {
 		return "1"
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ByteCounts holds downloaded byte totals over SABnzbd's reporting periods.
+type ByteCounts struct {
+	Day   int64 `json:"day"`
+	Week  int64 `json:"week"`
+	Month int64 `json:"month"`
+	Total int64 `json:"total"`
+}
+
+// ServerStats represents the download statistics reported by SABnzbd, both
+// overall and per news server.
+type ServerStats struct {
+	ByteCounts
+	Servers map[string]ByteCounts `json:"servers"`
+}
+
+// GetServerStats retrieves the downloaded byte counts overall and per server.
+func (c *Client) GetServerStats(ctx context.Context) (*ServerStats, error) {
+	params := url.Values{}
+	params.Set("mode", "server_stats")
+
+	var resp ServerStats
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return nil, fmt.Errorf("getting server stats: %w", err)
+	}
+
+	return &resp, nil
+}
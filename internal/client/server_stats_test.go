@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// serverStatsPayload is a server_stats response recorded from SABnzbd 4.3.
+const serverStatsPayload = `{
+	"day": 2147483648,
+	"week": 10737418240,
+	"month": 53687091200,
+	"total": 1099511627776,
+	"servers": {
+		"news.example.com": {
+			"day": 2147483648,
+			"week": 8589934592,
+			"month": 42949672960,
+			"total": 966367641600,
+			"daily": {"2024-05-01": 2147483648},
+			"articles_tried": {"2024-05-01": 290000},
+			"articles_success": {"2024-05-01": 289500}
+		},
+		"backup.example.com": {
+			"day": 0,
+			"week": 2147483648,
+			"month": 10737418240,
+			"total": 133143986176,
+			"daily": {},
+			"articles_tried": {},
+			"articles_success": {}
+		}
+	}
+}`
+
+func TestGetServerStats(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if mode := r.URL.Query().Get("mode"); mode != "server_stats" {
+			t.Errorf("expected mode=server_stats, got %q", mode)
+		}
+		fmt.Fprint(w, serverStatsPayload)
+	})
+
+	stats, err := c.GetServerStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := ByteCounts{Day: 2147483648, Week: 10737418240, Month: 53687091200, Total: 1099511627776}
+	if stats.ByteCounts != want {
+		t.Errorf("expected totals %+v, got %+v", want, stats.ByteCounts)
+	}
+
+	if len(stats.Servers) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(stats.Servers))
+	}
+	backup := stats.Servers["backup.example.com"]
+	if want := (ByteCounts{Day: 0, Week: 2147483648, Month: 10737418240, Total: 133143986176}); backup != want {
+		t.Errorf("expected backup.example.com %+v, got %+v", want, backup)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseRetentionDays(t *testing.T) {
+	cases := map[string]int{
+		"0":    0,
+		"90":   90,
+		"90d":  90,
+		"12w":  84,
+		"6m":   180,
+		" 2W ": 14,
+		"1 m":  30,
+	}
+
+	for input, want := range cases {
+		got, err := ParseRetentionDays(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d, got %d", input, want, got)
+		}
+	}
+
+	for _, input := range []string{"", "d", "-5d", "10y", "abc", "1.5m"} {
+		if _, err := ParseRetentionDays(input); err == nil {
+			t.Errorf("%q: expected error, got nil", input)
+		}
+	}
+}
+
+func TestServerUnmarshalEnable(t *testing.T) {
+	cases := map[string]IntBool{
+		`{"name": "a", "enable": 1}`: 1,
+		`{"name": "a", "enable": 0}`: 0,
+		`{"name": "a"}`:              1,
+	}
+
+	for payload, want := range cases {
+		var server Server
+		if err := json.Unmarshal([]byte(payload), &server); err != nil {
+			t.Fatalf("%s: unexpected error: %s", payload, err)
+		}
+		if server.Enable != want {
+			t.Errorf("%s: expected enable %d, got %d", payload, want, server.Enable)
+		}
+	}
+}
+
+func TestSetServerSendsDisabledExplicitly(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if _, ok := q["enable"]; !ok || q.Get("enable") != "0" {
+			t.Errorf("expected enable=0 to be sent, got query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.SetServer(context.Background(), &ServerInput{Name: "a", Host: "news.example.com", Enable: false}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestSetServerSendsUnlimitedRetention(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if _, ok := q["retention"]; !ok || q.Get("retention") != "0" {
+			t.Errorf("expected retention=0 to be sent, got query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.SetServer(context.Background(), &ServerInput{Name: "a", Host: "news.example.com", Retention: 0}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestClientTestServer(t *testing.T) {
+	const config = `{"config": {"servers": [{"name": "news", "host": "news.example.com", "port": 563, "password": "*****", "ssl": 1, "timeout": 60}]}}`
+
+	cases := map[string]struct {
+		body      string
+		reachable bool
+		message   string
+	}{
+		"success":    {`{"value": {"result": true, "message": "Connection Successful!"}}`, true, "Connection Successful!"},
+		"plain text": {"ok", true, ""},
+		"failure":    {`{"value": {"result": false, "message": "Server address \"news.example.com:563\" is not valid."}}`, false, `Server address "news.example.com:563" is not valid.`},
+		"api error":  {`{"status": false, "error": "Timed out"}`, false, "Timed out"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if q.Get("mode") == "get_config" {
+					fmt.Fprint(w, config)
+					return
+				}
+				if q.Get("mode") != "config" || q.Get("name") != "test_server" || q.Get("server") != "news" ||
+					q.Get("host") != "news.example.com" || q.Get("port") != "563" || q.Get("ssl") != "1" {
+					t.Errorf("unexpected query %q", r.URL.RawQuery)
+				}
+				fmt.Fprint(w, tc.body)
+			})
+
+			reachable, message, err := c.TestServer(context.Background(), "news")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if reachable != tc.reachable || message != tc.message {
+				t.Errorf("expected (%t, %q), got (%t, %q)", tc.reachable, tc.message, reachable, message)
+			}
+		})
+	}
+}
+
+func TestClientTestServerNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"servers": []}}`)
+	})
+
+	if _, _, err := c.TestServer(context.Background(), "news"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteServerParams(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.DeleteServer(context.Background(), "news.example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// del_config reads keyword; a name parameter would be ignored and the
+	// delete would silently do nothing.
+	query.Del("apikey")
+	query.Del("output")
+	want := url.Values{"mode": {"del_config"}, "section": {"servers"}, "keyword": {"news.example.com"}}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("expected params %v, got %v", want, query)
+	}
+}
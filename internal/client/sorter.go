@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Sort types a sorter applies to, as stored in its sort_type list.
+const (
+	SortTypeAll     = 0
+	SortTypeSeries  = 1
+	SortTypeDate    = 2
+	SortTypeMovie   = 3
+	SortTypeOther   = 4
+	SortTypeUnknown = 5
+)
+
+// SortTypes maps sort type names to the numbers SABnzbd uses for them.
+var SortTypes = map[string]int{
+	"all":     SortTypeAll,
+	"series":  SortTypeSeries,
+	"date":    SortTypeDate,
+	"movie":   SortTypeMovie,
+	"other":   SortTypeOther,
+	"unknown": SortTypeUnknown,
+}
+
+// SortTypeNames returns the known sort type names, sorted.
+func SortTypeNames() []string {
+	names := make([]string, 0, len(SortTypes))
+	for name := range SortTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseSortTypes converts sort type names into SABnzbd's sort type numbers.
+func ParseSortTypes(names []string) ([]int, error) {
+	types := make([]int, 0, len(names))
+	for _, name := range names {
+		t, ok := SortTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort type %q, expected one of %v", name, SortTypeNames())
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// FormatSortTypes converts SABnzbd's sort type numbers into their names.
+func FormatSortTypes(types []int) ([]string, error) {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		name, ok := sortTypeName(t)
+		if !ok {
+			return nil, fmt.Errorf("unknown sort type %d", t)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// sortTypeName returns the name of sort type t.
+func sortTypeName(t int) (string, bool) {
+	for name, v := range SortTypes {
+		if v == t {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// GetSorters retrieves all sorters, in the order SABnzbd lists them.
+func (c *Client) GetSorters(ctx context.Context) ([]Sorter, error) {
+	config, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sorters := []Sorter{}
+	return append(sorters, config.Sorters...), nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortTypesRoundTrip(t *testing.T) {
+	names := []string{"series", "movie", "date"}
+
+	types, err := ParseSortTypes(names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{SortTypeSeries, SortTypeMovie, SortTypeDate}; !reflect.DeepEqual(types, want) {
+		t.Errorf("expected %v, got %v", want, types)
+	}
+
+	got, err := FormatSortTypes(types)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, names) {
+		t.Errorf("expected %v, got %v", names, got)
+	}
+}
+
+func TestSortTypesInvalid(t *testing.T) {
+	if _, err := ParseSortTypes([]string{"series", "tv"}); err == nil {
+		t.Error("expected an error for an unknown sort type name")
+	}
+	if _, err := FormatSortTypes([]int{SortTypeMovie, 9}); err == nil {
+		t.Error("expected an error for an unknown sort type number")
+	}
+}
@@ -5,22 +5,101 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Status represents SABnzbd status information.
 type Status struct {
 	Version       string         `json:"version"`
 	Paused        bool           `json:"paused"`
+	PausedAll     bool           `json:"paused_all"`
+	PauseInt      string         `json:"pause_int"`
 	Speedlimit    string         `json:"speedlimit"`
 	SpeedlimitAbs string         `json:"speedlimit_abs"`
 	HaveWarnings  string         `json:"have_warnings"`
 	Diskspace1    string         `json:"diskspace1"`
 	Diskspace2    string         `json:"diskspace2"`
+	ConfigFn      string         `json:"configfn"`
+	DownloadDir   string         `json:"downloaddir"`
+	CompleteDir   string         `json:"completedir"`
+	Uptime        string         `json:"uptime"`
+	KBPerSec      Float          `json:"kbpersec"`
+	MBLeft        Float          `json:"mbleft"`
+	TimeLeft      string         `json:"timeleft"`
 	Servers       []ServerStatus `json:"servers"`
 }
 
+// Reasons the queue is paused, as returned by Status.PauseReason.
+const (
+	PauseReasonNone   = "none"
+	PauseReasonManual = "manual"
+	PauseReasonTimed  = "timed"
+)
+
+// PauseTimeLeft returns the time until a timed pause ends, as reported in
+// pause_int (e.g. "12:30"), or "" when no timed pause is running.
+func (s *Status) PauseTimeLeft() string {
+	left := strings.TrimSpace(s.PauseInt)
+	if left == "0" {
+		return ""
+	}
+
+	return left
+}
+
+// PauseReason reports why the queue is paused. A pause for a set time, from
+// the web interface or the API, ends by itself and is PauseReasonTimed. Any
+// other pause lasts until the queue is resumed and is PauseReasonManual;
+// SABnzbd does not report whether the scheduler or a user paused it.
+func (s *Status) PauseReason() string {
+	switch {
+	case !s.Paused:
+		return PauseReasonNone
+	case s.PauseTimeLeft() != "":
+		return PauseReasonTimed
+	default:
+		return PauseReasonManual
+	}
+}
+
+// Float is a number that SABnzbd may encode either as a JSON number or as a
+// numeric string, depending on the version and endpoint.
+type Float float64
+
+// UnmarshalJSON accepts both the number and the string forms. An empty
+// string decodes as zero.
+func (f *Float) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*f = 0
+			return nil
+		}
+
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("expected number or numeric string: %w", err)
+		}
+		*f = Float(n)
+		return nil
+	}
+
+	var n float64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("expected number or numeric string: %w", err)
+	}
+	*f = Float(n)
+
+	return nil
+}
+
 // ServerStatus represents the status of a news server.
 type ServerStatus struct {
 	ServerName       string `json:"servername"`
@@ -64,7 +143,63 @@ func (c *Client) GetVersion(ctx context.Context) (string, error) {
 	return resp.Version, nil
 }
 
-// GetScripts retrieves all available scripts.
+// VersionAtLeast reports whether SABnzbd version is minimum or newer. Only
+// the leading major.minor.patch numbers are compared, so a suffix such as
+// Beta1 or RC2 counts as the release it precedes.
+func VersionAtLeast(version, minimum string) (bool, error) {
+	have, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	want, err := parseVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range have {
+		if have[i] != want[i] {
+			return have[i] > want[i], nil
+		}
+	}
+
+	return true, nil
+}
+
+// parseVersion extracts the major, minor and patch numbers of a version such
+// as "3.7.2" or "4.0.0Beta1". Missing minor and patch numbers are 0.
+func parseVersion(version string) ([3]int, error) {
+	var parts [3]int
+
+	rest := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	for i := range parts {
+		end := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			if i == 0 {
+				return parts, fmt.Errorf("invalid SABnzbd version %q", version)
+			}
+			break
+		}
+
+		parts[i], _ = strconv.Atoi(rest[:end])
+		if !strings.HasPrefix(rest[end:], ".") {
+			break
+		}
+		rest = rest[end+1:]
+	}
+
+	return parts, nil
+}
+
+// NoScript is the script name SABnzbd uses for "no script".
+const NoScript = "None"
+
+// GetScripts retrieves all available scripts, sorted by name with NoScript
+// first. SABnzbd lists every script in its scripts folder here and does not
+// distinguish post-processing, pre-queue and notification scripts; any of them
+// can be selected for any of those uses.
 func (c *Client) GetScripts(ctx context.Context) ([]string, error) {
 	params := url.Values{}
 	params.Set("mode", "get_scripts")
@@ -76,5 +211,86 @@ func (c *Client) GetScripts(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("getting scripts: %w", err)
 	}
 
+	sort.SliceStable(resp.Scripts, func(i, j int) bool {
+		a, b := resp.Scripts[i], resp.Scripts[j]
+		if a == NoScript || b == NoScript {
+			return a == NoScript && b != NoScript
+		}
+		return a < b
+	})
+
 	return resp.Scripts, nil
 }
+
+// ParseWarningsCount converts the have_warnings value of the status response
+// into a number. SABnzbd reports it as a numeric string; an empty value means
+// there are no warnings.
+func ParseWarningsCount(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid warnings count %q", s)
+	}
+
+	return n, nil
+}
+
+// sizeUnits maps size suffixes to their multipliers. SABnzbd uses binary
+// (1024-based) units throughout.
+var sizeUnits = map[string]float64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+	"t":  1 << 40,
+	"tb": 1 << 40,
+}
+
+// ParseSize converts a human-readable size such as "123.4 GB", "500M" or
+// "1.2T" into bytes. A bare number is interpreted as bytes.
+func ParseSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("invalid size %q: empty value", s)
+	}
+
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := value, ""
+	if i >= 0 {
+		number, unit = value[:i], strings.TrimSpace(value[i:])
+	}
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, unit)
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+
+	return int64(math.Round(n * multiplier)), nil
+}
+
+// ParseDiskspace converts a diskspace value from the status response into
+// bytes. SABnzbd reports these as gigabytes, either as a bare number or with
+// an explicit unit.
+func ParseDiskspace(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		value += "G"
+	}
+
+	return ParseSize(value)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	cases := map[string]int64{
+		"0":        0,
+		"512":      512,
+		"512 B":    512,
+		"1K":       1024,
+		"1.5 KB":   1536,
+		"500M":     500 << 20,
+		"500 MB":   500 << 20,
+		"10G":      10 << 30,
+		"123.4 GB": 132499741082,
+		"1.2T":     1319413953331,
+		"2 tb":     2 << 40,
+	}
+
+	for input, want := range cases {
+		got, err := ParseSize(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d, got %d", input, want, got)
+		}
+	}
+
+	for _, input := range []string{"", "GB", "12 PB", "1..2G", "abc"} {
+		if _, err := ParseSize(input); err == nil {
+			t.Errorf("%q: expected error, got nil", input)
+		}
+	}
+}
+
+func TestParseWarningsCount(t *testing.T) {
+	cases := map[string]int64{
+		"":    0,
+		"0":   0,
+		"3":   3,
+		" 12": 12,
+	}
+
+	for input, want := range cases {
+		got, err := ParseWarningsCount(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d, got %d", input, want, got)
+		}
+	}
+
+	for _, input := range []string{"many", "-1", "1.5"} {
+		if _, err := ParseWarningsCount(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestParseDiskspace(t *testing.T) {
+	cases := map[string]int64{
+		"123.4":    132499741082,
+		"123.4 GB": 132499741082,
+		"1.5 TB":   1649267441664,
+		"750 MB":   750 << 20,
+	}
+
+	for input, want := range cases {
+		got, err := ParseDiskspace(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %d, got %d", input, want, got)
+		}
+	}
+}
+
+func TestFloatUnmarshal(t *testing.T) {
+	cases := map[string]Float{
+		`1234.5`:    1234.5,
+		`"1234.5"`:  1234.5,
+		`0`:         0,
+		`"0.00"`:    0,
+		`""`:        0,
+		`" 12.25 "`: 12.25,
+	}
+
+	for input, want := range cases {
+		var got Float
+		if err := json.Unmarshal([]byte(input), &got); err != nil {
+			t.Errorf("%s: unexpected error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: expected %v, got %v", input, want, got)
+		}
+	}
+
+	for _, input := range []string{`"fast"`, `true`, `[1]`} {
+		var got Float
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("%s: expected error, got nil", input)
+		}
+	}
+}
+
+func TestGetStatusRates(t *testing.T) {
+	payloads := map[string]string{
+		"strings": `{"status": {"uptime": "2d", "kbpersec": "2048.50", "mbleft": "1500.25", "timeleft": "0:12:30"}}`,
+		"numbers": `{"status": {"uptime": "2d", "kbpersec": 2048.5, "mbleft": 1500.25, "timeleft": "0:12:30"}}`,
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(payload))
+			})
+
+			status, err := c.GetStatus(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if status.Uptime != "2d" || status.TimeLeft != "0:12:30" {
+				t.Errorf("unexpected uptime/timeleft: %q, %q", status.Uptime, status.TimeLeft)
+			}
+			if status.KBPerSec != 2048.5 || status.MBLeft != 1500.25 {
+				t.Errorf("unexpected kbpersec/mbleft: %v, %v", status.KBPerSec, status.MBLeft)
+			}
+		})
+	}
+}
+
+func TestGetStatusPauseReason(t *testing.T) {
+	cases := map[string]struct {
+		payload  string
+		reason   string
+		timeLeft string
+	}{
+		"running": {`{"status": {"paused": false, "pause_int": "0"}}`, PauseReasonNone, ""},
+		"manual":  {`{"status": {"paused": true, "pause_int": "0"}}`, PauseReasonManual, ""},
+		"timed":   {`{"status": {"paused": true, "pause_int": "12:30"}}`, PauseReasonTimed, "12:30"},
+		"older":   {`{"status": {"paused": true}}`, PauseReasonManual, ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.payload))
+			})
+
+			status, err := c.GetStatus(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := status.PauseReason(); got != tc.reason {
+				t.Errorf("expected pause reason %q, got %q", tc.reason, got)
+			}
+			if got := status.PauseTimeLeft(); got != tc.timeLeft {
+				t.Errorf("expected pause time left %q, got %q", tc.timeLeft, got)
+			}
+		})
+	}
+}
+
+func TestGetScriptsSorted(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"scripts": ["notify.py", "None", "cleanup.sh", "Unrar.py"]}`))
+	})
+
+	scripts, err := c.GetScripts(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{"None", "Unrar.py", "cleanup.sh", "notify.py"}
+	if !reflect.DeepEqual(scripts, want) {
+		t.Errorf("expected %v, got %v", want, scripts)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Actions SABnzbd takes on a job containing a file with an unwanted
+// extension.
+const (
+	UnwantedExtensionsOff   = 0
+	UnwantedExtensionsPause = 1
+	UnwantedExtensionsAbort = 2
+)
+
+// UnwantedExtensionActions names the unwanted extension actions, indexed by
+// the value SABnzbd stores.
+var UnwantedExtensionActions = []string{"off", "pause", "abort"}
+
+// SwitchesInput represents the input for updating the download and
+// post-processing switches of the misc section.
+type SwitchesInput struct {
+	DeobfuscateFinalFilenames  bool
+	DirectUnpack               bool
+	UnwantedExtensions         []string
+	ActionOnUnwantedExtensions int
+}
+
+// Switches represents the download and post-processing switches from
+// SABnzbd's misc section.
+type Switches struct {
+	DeobfuscateFinalFilenames  IntBool
+	DirectUnpack               IntBool
+	UnwantedExtensions         []string
+	ActionOnUnwantedExtensions int
+}
+
+// DefaultSwitches holds the switches of a freshly installed SABnzbd.
+var DefaultSwitches = Switches{
+	DeobfuscateFinalFilenames: 1,
+	UnwantedExtensions:        []string{},
+}
+
+// SetSwitches updates the switches.
+func (c *Client) SetSwitches(ctx context.Context, input *SwitchesInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("deobfuscate_final_filenames", boolToInt(input.DeobfuscateFinalFilenames))
+	params.Set("direct_unpack", boolToInt(input.DirectUnpack))
+	// SABnzbd parses list settings from a comma-separated string.
+	params.Set("unwanted_extensions", strings.Join(input.UnwantedExtensions, ","))
+	params.Set("action_on_unwanted_extensions", strconv.Itoa(input.ActionOnUnwantedExtensions))
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting switches config: %w", err)
+	}
+
+	return nil
+}
+
+// GetSwitches retrieves the switches.
+func (c *Client) GetSwitches(ctx context.Context) (*Switches, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switches := &Switches{}
+
+	if switches.DeobfuscateFinalFilenames, err = miscIntBool(misc, "deobfuscate_final_filenames"); err != nil {
+		return nil, err
+	}
+	if switches.DirectUnpack, err = miscIntBool(misc, "direct_unpack"); err != nil {
+		return nil, err
+	}
+	if switches.UnwantedExtensions, err = miscList(misc, "unwanted_extensions"); err != nil {
+		return nil, err
+	}
+	if switches.ActionOnUnwantedExtensions, err = miscInt(misc, "action_on_unwanted_extensions"); err != nil {
+		return nil, err
+	}
+
+	return switches, nil
+}
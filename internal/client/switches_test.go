@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetSwitches(t *testing.T) {
+	payloads := map[string]string{
+		"numbers": `{"config": {"misc": {"deobfuscate_final_filenames": 1, "direct_unpack": 0, "unwanted_extensions": ["exe", "com"], "action_on_unwanted_extensions": 2}}}`,
+		"strings": `{"config": {"misc": {"deobfuscate_final_filenames": "1", "direct_unpack": "0", "unwanted_extensions": "exe, com", "action_on_unwanted_extensions": "2"}}}`,
+		"bools":   `{"config": {"misc": {"deobfuscate_final_filenames": true, "direct_unpack": false, "unwanted_extensions": ["exe", "com"], "action_on_unwanted_extensions": 2}}}`,
+	}
+
+	for name, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		got, err := c.GetSwitches(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		want := &Switches{DeobfuscateFinalFilenames: 1, UnwantedExtensions: []string{"exe", "com"}, ActionOnUnwantedExtensions: UnwantedExtensionsAbort}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func TestGetSwitchesEmptyList(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {"unwanted_extensions": ""}}}`)
+	})
+
+	got, err := c.GetSwitches(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.UnwantedExtensions == nil || len(got.UnwantedExtensions) != 0 {
+		t.Errorf("expected an empty list, got %#v", got.UnwantedExtensions)
+	}
+}
+
+func TestSetSwitches(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	input := &SwitchesInput{DeobfuscateFinalFilenames: true, UnwantedExtensions: []string{"exe", "com"}, ActionOnUnwantedExtensions: UnwantedExtensionsPause}
+	if err := c.SetSwitches(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":                       "misc",
+		"deobfuscate_final_filenames":   "1",
+		"direct_unpack":                 "0",
+		"unwanted_extensions":           "exe,com",
+		"action_on_unwanted_extensions": "1",
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
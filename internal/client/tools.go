@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Keys of the external tool paths in the misc section. An empty path makes
+// SABnzbd look the tool up itself.
+const (
+	Par2PathKey     = "par2_cmdline"
+	UnrarPathKey    = "unrar_cmdline"
+	SevenZipPathKey = "7zip_cmdline"
+)
+
+// ToolsInput represents the input for updating the external tool paths.
+type ToolsInput struct {
+	Par2Path     string
+	UnrarPath    string
+	SevenZipPath string
+}
+
+// Tools represents the external tool paths from SABnzbd's misc section.
+type Tools struct {
+	Par2Path     string
+	UnrarPath    string
+	SevenZipPath string
+
+	// Unsupported lists the keys SABnzbd did not return. SABnzbd ignores
+	// settings it does not know, so a path set under one of them has no
+	// effect.
+	Unsupported map[string]bool
+}
+
+// SetTools updates the external tool paths.
+func (c *Client) SetTools(ctx context.Context, input *ToolsInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set(Par2PathKey, input.Par2Path)
+	params.Set(UnrarPathKey, input.UnrarPath)
+	params.Set(SevenZipPathKey, input.SevenZipPath)
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting tools config: %w", err)
+	}
+
+	return nil
+}
+
+// GetTools retrieves the external tool paths.
+func (c *Client) GetTools(ctx context.Context) (*Tools, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := &Tools{Unsupported: map[string]bool{}}
+
+	for key, field := range map[string]*string{
+		Par2PathKey:     &tools.Par2Path,
+		UnrarPathKey:    &tools.UnrarPath,
+		SevenZipPathKey: &tools.SevenZipPath,
+	} {
+		v, ok := misc[key]
+		if !ok {
+			tools.Unsupported[key] = true
+			continue
+		}
+		if s, ok := v.(string); ok {
+			*field = s
+		}
+	}
+
+	return tools, nil
+}
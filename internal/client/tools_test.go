@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetTools(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {"par2_cmdline": "/usr/bin/par2", "unrar_cmdline": ""}}}`)
+	})
+
+	got, err := c.GetTools(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := &Tools{Par2Path: "/usr/bin/par2", Unsupported: map[string]bool{SevenZipPathKey: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestSetTools(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	if err := c.SetTools(context.Background(), &ToolsInput{UnrarPath: "/opt/bin/unrar"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":       "misc",
+		"par2_cmdline":  "",
+		"unrar_cmdline": "/opt/bin/unrar",
+		"7zip_cmdline":  "",
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
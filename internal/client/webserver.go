@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DefaultLanguage is the language SABnzbd uses when none is set.
+const DefaultLanguage = "en"
+
+// Languages are the codes of the languages SABnzbd ships translations for.
+var Languages = []string{
+	"cs", "da", "de", "en", "es", "fi", "fr", "he", "it", "nb",
+	"nl", "pl", "pt_BR", "ro", "ru", "sr", "sv", "tr", "zh_CN",
+}
+
+// WebServerInput represents the input for updating the web interface
+// settings. An HTTPSPort of 0 serves HTTPS on Port.
+type WebServerInput struct {
+	Host        string
+	Port        int
+	EnableHTTPS bool
+	HTTPSPort   int
+	Username    string
+	Password    string
+	Language    string
+}
+
+// WebServer represents the web interface settings from SABnzbd's misc
+// section. SABnzbd masks Password when it is set.
+type WebServer struct {
+	Host        string
+	Port        int
+	EnableHTTPS IntBool
+	HTTPSPort   int
+	Username    string
+	Password    string
+	Language    string
+}
+
+// SetWebServer updates the web interface settings. SABnzbd applies them when
+// it restarts, after which the API may only be reachable at the new address.
+func (c *Client) SetWebServer(ctx context.Context, input *WebServerInput) error {
+	params := url.Values{}
+	params.Set("mode", "set_config")
+	params.Set("section", "misc")
+	params.Set("host", input.Host)
+	params.Set("port", strconv.Itoa(input.Port))
+	params.Set("enable_https", boolToInt(input.EnableHTTPS))
+	// SABnzbd stores an empty https_port to serve HTTPS on port.
+	httpsPort := ""
+	if input.HTTPSPort != 0 {
+		httpsPort = strconv.Itoa(input.HTTPSPort)
+	}
+	params.Set("https_port", httpsPort)
+	params.Set("username", input.Username)
+	params.Set("password", input.Password)
+	params.Set("language", input.Language)
+
+	var resp map[string]interface{}
+	if err := c.doRequest(ctx, params, &resp); err != nil {
+		return fmt.Errorf("setting web server config: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebServer retrieves the web interface settings.
+func (c *Client) GetWebServer(ctx context.Context) (*WebServer, error) {
+	misc, err := c.GetMiscConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webServer := &WebServer{Language: DefaultLanguage}
+
+	if v, ok := misc["host"].(string); ok {
+		webServer.Host = v
+	}
+	if webServer.Port, err = miscInt(misc, "port"); err != nil {
+		return nil, err
+	}
+	if webServer.EnableHTTPS, err = miscIntBool(misc, "enable_https"); err != nil {
+		return nil, err
+	}
+	if webServer.HTTPSPort, err = miscInt(misc, "https_port"); err != nil {
+		return nil, err
+	}
+	if v, ok := misc["username"].(string); ok {
+		webServer.Username = v
+	}
+	if v, ok := misc["password"].(string); ok {
+		webServer.Password = v
+	}
+	if v, ok := misc["language"].(string); ok && v != "" {
+		webServer.Language = v
+	}
+
+	return webServer, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetWebServer(t *testing.T) {
+	payloads := map[string]string{
+		"string ports": `{"config": {"misc": {"host": "0.0.0.0", "port": "8080", "enable_https": 1, "https_port": "9090", "username": "admin", "password": "*****", "language": "de"}}}`,
+		"number ports": `{"config": {"misc": {"host": "0.0.0.0", "port": 8080, "enable_https": 1, "https_port": 9090, "username": "admin", "password": "*****", "language": "de"}}}`,
+	}
+
+	for name, payload := range payloads {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, payload)
+		})
+
+		got, err := c.GetWebServer(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		want := &WebServer{Host: "0.0.0.0", Port: 8080, EnableHTTPS: 1, HTTPSPort: 9090, Username: "admin", Password: "*****", Language: "de"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func TestGetWebServerSharedHTTPSPort(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"config": {"misc": {"host": "::", "port": "8080", "https_port": ""}}}`)
+	})
+
+	got, err := c.GetWebServer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.HTTPSPort != 0 {
+		t.Errorf("expected https_port 0 for an empty value, got %d", got.HTTPSPort)
+	}
+	if got.Language != DefaultLanguage {
+		t.Errorf("expected language %q for a missing value, got %q", DefaultLanguage, got.Language)
+	}
+}
+
+func TestSetWebServer(t *testing.T) {
+	var query map[string][]string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"status": true}`)
+	})
+
+	input := &WebServerInput{Host: "0.0.0.0", Port: 8080, EnableHTTPS: true, Username: "admin", Password: "secret", Language: "nl"}
+	if err := c.SetWebServer(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"section":      "misc",
+		"host":         "0.0.0.0",
+		"port":         "8080",
+		"enable_https": "1",
+		"https_port":   "",
+		"username":     "admin",
+		"password":     "secret",
+		"language":     "nl",
+	}
+	for key, value := range want {
+		if got, ok := query[key]; !ok || got[0] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ArrCategoryResource{}
+var _ resource.ResourceWithImportState = &ArrCategoryResource{}
+var _ resource.ResourceWithValidateConfig = &ArrCategoryResource{}
+var _ resource.ResourceWithModifyPlan = &ArrCategoryResource{}
+
+// arrCategoryPP is the post-processing an *arr category defaults to:
+// repair, unpack and delete, so the app imports unpacked files only.
+const arrCategoryPP = "3"
+
+func NewArrCategoryResource() resource.Resource {
+	return &ArrCategoryResource{}
+}
+
+// ArrCategoryResource defines the resource implementation.
+type ArrCategoryResource struct {
+	client *client.Client
+}
+
+// ArrCategoryResourceModel describes the resource data model.
+type ArrCategoryResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Dir         types.String `tfsdk:"dir"`
+	Script      types.String `tfsdk:"script"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	PP          types.String `tfsdk:"pp"`
+	DirAbsolute types.String `tfsdk:"dir_absolute"`
+}
+
+func (r *ArrCategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_arr_category"
+}
+
+func (r *ArrCategoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a category for an *arr application such as Sonarr, Radarr or Lidarr. " +
+			"The application sends each job with the category `name` and imports the finished download from " +
+			"`dir_absolute`, so the folder is required and post-processing defaults to repair, unpack and delete. " +
+			"Unlike `sabnzbd_category`, a `script` that is not in SABnzbd's scripts folder is an error. " +
+			"Do not manage the same category with `sabnzbd_category` as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The category name, as set in the application's download client settings " +
+					"(e.g. `tv` for Sonarr or `movies` for Radarr).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The folder completed downloads of this category are moved to, relative to " +
+					"SABnzbd's complete folder or absolute. The application must be able to read it.",
+				Required: true,
+			},
+			"script": schema.StringAttribute{
+				MarkdownDescription: "The post-processing script to run for downloads in this category, e.g. one " +
+					"that notifies the application. Use `None` for no script, or `Default` to use the global default. " +
+					"Defaults to `None`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.NoScript),
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The default priority for downloads in this category. " +
+					"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force. Defaults to `-100`.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(client.DefaultPriority),
+			},
+			"pp": schema.StringAttribute{
+				MarkdownDescription: "Post-processing options. Values: ``=Default, `0`=None, `1`=+Repair, " +
+					"`2`=+Repair/Unpack, `3`=+Repair/Unpack/Delete. Defaults to `3`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(arrCategoryPP),
+			},
+			"dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute folder completed downloads in this category are moved to, " +
+					"for the application's remote path mapping.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *ArrCategoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ArrCategoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ArrCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.ValueString() == client.DefaultCategoryName {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid Category Name",
+			"The default category cannot be an *arr category; use sabnzbd_default_category to manage it.",
+		)
+	}
+
+	if !data.Dir.IsNull() && !data.Dir.IsUnknown() && data.Dir.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dir"),
+			"Missing Category Folder",
+			"The application needs to know where completed downloads are, so dir must not be empty.",
+		)
+	}
+}
+
+func (r *ArrCategoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy, and no API to ask before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var script types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("script"), &script)...)
+	if resp.Diagnostics.HasError() || script.IsUnknown() {
+		return
+	}
+
+	diags, err := r.checkScript(ctx, script.ValueString())
+	if err != nil {
+		// Don't block planning when SABnzbd is unreachable; apply will report it.
+		tflog.Debug(ctx, "unable to list scripts while planning arr category", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ArrCategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ArrCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.scriptExists(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	// Place the category after the existing ones, as sabnzbd_category does.
+	input := arrCategoryInputFromModel(&data)
+
+	if err := r.client.AddCategoryLast(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "create arr category", err)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created arr category resource", map[string]interface{}{"name": data.Name.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ArrCategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ArrCategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ArrCategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ArrCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.scriptExists(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	// A nil order keeps the category where it is.
+	if err := r.client.SetCategory(ctx, arrCategoryInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "update arr category", err)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated arr category resource", map[string]interface{}{"name": data.Name.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ArrCategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ArrCategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteCategory(ctx, data.Name.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "delete arr category", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted arr category resource", map[string]interface{}{"name": data.Name.ValueString()})
+}
+
+func (r *ArrCategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// checkScript returns an error diagnostic when script is not one SABnzbd
+// lists. None and Default need no script file.
+func (r *ArrCategoryResource) checkScript(ctx context.Context, script string) (diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+
+	if script == client.NoScript || script == "Default" {
+		return diags, nil
+	}
+
+	scripts, err := r.client.GetScripts(ctx)
+	if err != nil {
+		return diags, err
+	}
+
+	if !slices.Contains(scripts, script) {
+		diags.Append(scriptNotFoundError(path.Root("script"), script, scripts))
+	}
+
+	return diags, nil
+}
+
+// scriptExists checks the script of data before it is written, reporting
+// into diags, and returns whether the write can go ahead.
+func (r *ArrCategoryResource) scriptExists(ctx context.Context, data *ArrCategoryResourceModel, diags *diag.Diagnostics) bool {
+	scriptDiags, err := r.checkScript(ctx, data.Script.ValueString())
+	if err != nil {
+		addClientError(diags, "list scripts", err)
+		return false
+	}
+	diags.Append(scriptDiags...)
+
+	return !diags.HasError()
+}
+
+// read copies the category SABnzbd stores for data's name into data.
+func (r *ArrCategoryResource) read(ctx context.Context, data *ArrCategoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	category, err := r.client.GetCategory(ctx, data.Name.ValueString())
+	if err != nil {
+		addClientError(&diags, "read arr category", err)
+		return diags
+	}
+
+	data.Dir = types.StringValue(category.Dir)
+	data.Script = types.StringValue(category.Script)
+	data.Priority = types.Int64Value(int64(category.Priority))
+	data.PP = types.StringValue(category.PP)
+
+	dirAbsolute, err := r.client.GetCategoryCompleteDir(ctx, category.Dir)
+	if err != nil {
+		addClientError(&diags, "read category folder", err)
+		return diags
+	}
+	data.DirAbsolute = types.StringValue(dirAbsolute)
+
+	return diags
+}
+
+// arrCategoryInputFromModel converts the resource model into a client input.
+func arrCategoryInputFromModel(data *ArrCategoryResourceModel) *client.CategoryInput {
+	return &client.CategoryInput{
+		Name:     data.Name.ValueString(),
+		Dir:      data.Dir.ValueString(),
+		Script:   data.Script.ValueString(),
+		Priority: int(data.Priority.ValueInt64()),
+		PP:       data.PP.ValueString(),
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testArrCategoryModel returns an arr category model for a Sonarr category.
+func testArrCategoryModel(script string) ArrCategoryResourceModel {
+	return ArrCategoryResourceModel{
+		Name:        types.StringValue("tv"),
+		Dir:         types.StringValue("/data/complete/tv"),
+		Script:      types.StringValue(script),
+		Priority:    types.Int64Value(client.DefaultPriority),
+		PP:          types.StringValue(arrCategoryPP),
+		DirAbsolute: types.StringUnknown(),
+	}
+}
+
+func TestArrCategoryResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.scripts = []string{"None", "notify_sonarr.py"}
+	f.categories = []client.Category{{Name: "movies", Order: 3}}
+
+	r := &ArrCategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testArrCategoryModel("notify_sonarr.py")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	got := f.categories[1]
+	if got.Name != "tv" || got.Dir != "/data/complete/tv" || got.Script != "notify_sonarr.py" || got.PP != "3" {
+		t.Errorf("unexpected stored category: %+v", got)
+	}
+	if got.Order != 4 || got.Priority != client.DefaultPriority {
+		t.Errorf("expected order 4 and priority -100, got %d and %d", got.Order, got.Priority)
+	}
+
+	var created ArrCategoryResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.DirAbsolute.ValueString() != "/data/complete/tv" {
+		t.Errorf("expected dir_absolute /data/complete/tv, got %s", created.DirAbsolute)
+	}
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if len(f.categories) != 1 {
+		t.Errorf("expected the category to be deleted, got %+v", f.categories)
+	}
+}
+
+func TestArrCategoryResourceMissingScript(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.scripts = []string{"None"}
+
+	r := &ArrCategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testArrCategoryModel("notify_sonarr.py")
+
+	planResp := resource.ModifyPlanResponse{Plan: newPlan(t, s, &plan)}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: newPlan(t, s, &plan)}, &planResp)
+	if !planResp.Diagnostics.HasError() {
+		t.Error("expected a plan error for a missing script")
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if !createResp.Diagnostics.HasError() || createResp.Diagnostics[0].Summary() != "Script Not Found" {
+		t.Fatalf("expected a Script Not Found error, got %v", createResp.Diagnostics)
+	}
+	if len(f.categories) != 0 {
+		t.Errorf("expected nothing to be written, got %+v", f.categories)
+	}
+}
+
+func TestArrCategoryResourceValidateConfig(t *testing.T) {
+	r := &ArrCategoryResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]ArrCategoryResourceModel{
+		"default category": {Name: types.StringValue("*"), Dir: types.StringValue("/data/complete")},
+		"empty dir":        {Name: types.StringValue("tv"), Dir: types.StringValue("")},
+	}
+
+	for name, model := range cases {
+		model.Script = types.StringNull()
+		model.Priority = types.Int64Null()
+		model.PP = types.StringNull()
+		model.DirAbsolute = types.StringNull()
+
+		resp := resource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &model)}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
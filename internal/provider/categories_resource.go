@@ -0,0 +1,388 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CategoriesResource{}
+var _ resource.ResourceWithImportState = &CategoriesResource{}
+
+// categoriesID is the ID of the categories singleton.
+const categoriesID = "categories"
+
+func NewCategoriesResource() resource.Resource {
+	return &CategoriesResource{}
+}
+
+// CategoriesResource defines the resource implementation.
+type CategoriesResource struct {
+	client *client.Client
+}
+
+// CategoriesResourceModel describes the resource data model.
+type CategoriesResourceModel struct {
+	ID            types.String                   `tfsdk:"id"`
+	ResetOnCreate types.Bool                     `tfsdk:"reset_on_create"`
+	Categories    map[string]CategoriesItemModel `tfsdk:"categories"`
+}
+
+// CategoriesItemModel describes a single category in the set.
+type CategoriesItemModel struct {
+	Dir      types.String `tfsdk:"dir"`
+	Script   types.String `tfsdk:"script"`
+	Priority types.Int64  `tfsdk:"priority"`
+	PP       types.String `tfsdk:"pp"`
+	Order    types.Int64  `tfsdk:"order"`
+}
+
+func (r *CategoriesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_categories"
+}
+
+func (r *CategoriesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete set of download categories in SABnzbd. Categories that are missing are " +
+			"created, changed ones are updated, and any category not listed is deleted, including categories that " +
+			"existed before this resource was created. The default category (`*`) is never deleted: list it to manage " +
+			"its settings. Note: This is a singleton resource - only one instance should exist, and it should not be " +
+			"combined with `sabnzbd_category` resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Always `categories`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"reset_on_create": schema.BoolAttribute{
+				MarkdownDescription: "Delete every category except the default one before the listed categories are " +
+					"created, so that no setting this resource does not manage carries over from an existing category. " +
+					"This is destructive and only takes effect when the resource is created. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"categories": schema.MapNestedAttribute{
+				MarkdownDescription: "The categories to configure, keyed by category name.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dir": schema.StringAttribute{
+							MarkdownDescription: "The relative or absolute path for completed downloads in this category. " +
+								"Leave empty to use the default complete folder.",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"script": schema.StringAttribute{
+							MarkdownDescription: "The post-processing script to run for downloads in this category. " +
+								"Use `None` for no script, or `Default` to use the global default. " +
+								"SABnzbd has no per-category pre-queue script; the pre-queue script is a global setting.",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString("None"),
+						},
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "The default priority for downloads in this category. " +
+								"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force.",
+							Optional: true,
+							Computed: true,
+							Default:  int64default.StaticInt64(-100),
+						},
+						"pp": schema.StringAttribute{
+							MarkdownDescription: "Post-processing options. Values: ``=Default, `0`=None, `1`=+Repair, " +
+								"`2`=+Repair/Unpack, `3`=+Repair/Unpack/Delete.",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(""),
+						},
+						"order": schema.Int64Attribute{
+							MarkdownDescription: "The display order of this category in the UI. If not set, an existing " +
+								"category keeps its order, and new categories are placed after the existing ones in " +
+								"alphabetical order.",
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *CategoriesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *CategoriesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CategoriesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only an explicit opt-in clears the existing categories.
+	if data.ResetOnCreate.ValueBool() {
+		tflog.Debug(ctx, "deleting existing categories before creating the set")
+		if err := r.client.DeleteConfigSection(ctx, "categories"); err != nil {
+			addClientError(&resp.Diagnostics, "reset categories", err)
+			return
+		}
+	}
+
+	if err := r.reconcile(ctx, &data); err != nil {
+		addClientError(&resp.Diagnostics, "create categories", err)
+		return
+	}
+
+	data.ID = types.StringValue(categoriesID)
+	tflog.Trace(ctx, "created categories resource", map[string]interface{}{"categories": len(data.Categories)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoriesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CategoriesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read categories", err)
+		return
+	}
+
+	// An imported set has no reset_on_create, which only matters on create.
+	if data.ResetOnCreate.IsNull() {
+		data.ResetOnCreate = types.BoolValue(false)
+	}
+
+	// Every category is managed, except that the default one is only tracked
+	// when it is listed.
+	_, trackDefault := data.Categories[client.DefaultCategoryName]
+
+	data.ID = types.StringValue(categoriesID)
+	data.Categories = make(map[string]CategoriesItemModel, len(config.Categories))
+	for _, category := range config.Categories {
+		if category.Name == client.DefaultCategoryName && !trackDefault {
+			continue
+		}
+		data.Categories[category.Name] = categoriesItemFromCategory(&category)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoriesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CategoriesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcile(ctx, &data); err != nil {
+		addClientError(&resp.Diagnostics, "update categories", err)
+		return
+	}
+
+	data.ID = types.StringValue(categoriesID)
+	tflog.Trace(ctx, "updated categories resource", map[string]interface{}{"categories": len(data.Categories)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CategoriesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CategoriesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, name := range sortedCategoryNames(data.Categories) {
+		// SABnzbd cannot delete the default category.
+		if name == client.DefaultCategoryName {
+			continue
+		}
+
+		if err := r.client.DeleteCategory(ctx, name); err != nil {
+			addClientError(&resp.Diagnostics, "delete category", err)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "deleted categories resource")
+}
+
+func (r *CategoriesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != categoriesID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The categories set is imported with the ID %q, got %q.", categoriesID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// reconcile makes SABnzbd's categories match data and fills in the resolved
+// orders.
+func (r *CategoriesResource) reconcile(ctx context.Context, data *CategoriesResourceModel) error {
+	config, err := r.client.GetConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := diffCategories(config.Categories, data.Categories)
+
+	for _, input := range changes.set {
+		tflog.Debug(ctx, "setting category", map[string]interface{}{"name": input.Name})
+		if err := r.client.SetCategory(ctx, input); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range changes.remove {
+		tflog.Debug(ctx, "deleting category", map[string]interface{}{"name": name})
+		if err := r.client.DeleteCategory(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	for name, item := range data.Categories {
+		item.Order = types.Int64Value(int64(changes.orders[name]))
+		data.Categories[name] = item
+	}
+
+	return nil
+}
+
+// categoryChanges are the writes needed to make SABnzbd's categories match a
+// desired set.
+type categoryChanges struct {
+	// set holds the desired categories that are missing or differ, by name.
+	set []*client.CategoryInput
+
+	// remove holds the names of the categories that are not desired.
+	remove []string
+
+	// orders holds the resolved order of every desired category.
+	orders map[string]int
+}
+
+// diffCategories compares the current categories with the desired ones. A
+// desired category without a known order keeps its current order, or, when
+// it is new, is placed after the existing categories in name order. The
+// default category is never moved or removed.
+func diffCategories(current []client.Category, desired map[string]CategoriesItemModel) categoryChanges {
+	existing := make(map[string]client.Category, len(current))
+	for _, category := range current {
+		existing[category.Name] = category
+	}
+
+	changes := categoryChanges{orders: make(map[string]int, len(desired))}
+	next := client.NextCategoryOrder(current)
+
+	for _, name := range sortedCategoryNames(desired) {
+		item := desired[name]
+		category, found := existing[name]
+
+		order := category.Order
+		switch {
+		case !item.Order.IsUnknown() && !item.Order.IsNull():
+			order = int(item.Order.ValueInt64())
+		case !found && name != client.DefaultCategoryName:
+			order = next
+			next++
+		}
+		changes.orders[name] = order
+
+		input := &client.CategoryInput{
+			Name:     name,
+			Dir:      item.Dir.ValueString(),
+			Script:   item.Script.ValueString(),
+			Priority: int(item.Priority.ValueInt64()),
+			PP:       item.PP.ValueString(),
+			Order:    &order,
+		}
+
+		if found && category.Dir == input.Dir && category.Script == input.Script &&
+			category.Priority == input.Priority && category.PP == input.PP && category.Order == order {
+			continue
+		}
+		changes.set = append(changes.set, input)
+	}
+
+	for _, category := range current {
+		if _, ok := desired[category.Name]; !ok && category.Name != client.DefaultCategoryName {
+			changes.remove = append(changes.remove, category.Name)
+		}
+	}
+	sort.Strings(changes.remove)
+
+	return changes
+}
+
+// categoriesItemFromCategory converts a category into its model in the set.
+func categoriesItemFromCategory(category *client.Category) CategoriesItemModel {
+	return CategoriesItemModel{
+		Dir:      types.StringValue(category.Dir),
+		Script:   types.StringValue(category.Script),
+		Priority: types.Int64Value(int64(category.Priority)),
+		PP:       types.StringValue(category.PP),
+		Order:    types.Int64Value(int64(category.Order)),
+	}
+}
+
+// sortedCategoryNames returns the keys of categories in sorted order.
+func sortedCategoryNames(categories map[string]CategoriesItemModel) []string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCategoriesItem returns a category in the set with the given dir and
+// order.
+func testCategoriesItem(dir string, order types.Int64) CategoriesItemModel {
+	return CategoriesItemModel{
+		Dir:      types.StringValue(dir),
+		Script:   types.StringValue("None"),
+		Priority: types.Int64Value(-100),
+		PP:       types.StringValue(""),
+		Order:    order,
+	}
+}
+
+func TestDiffCategories(t *testing.T) {
+	current := []client.Category{
+		{Name: client.DefaultCategoryName, Script: "None", PP: "3"},
+		{Name: "movies", Dir: "Movies", Script: "None", Priority: -100, Order: 1},
+		{Name: "tv", Dir: "TV", Script: "None", Priority: -100, Order: 2},
+		{Name: "software", Script: "None", Priority: -100, Order: 3},
+	}
+	desired := map[string]CategoriesItemModel{
+		"movies": testCategoriesItem("Movies", types.Int64Unknown()), // unchanged
+		"tv":     testCategoriesItem("Shows", types.Int64Unknown()),  // changed dir
+		"music":  testCategoriesItem("Music", types.Int64Unknown()),  // new
+		"books":  testCategoriesItem("Books", types.Int64Value(9)),   // new, explicit order
+		"audio":  testCategoriesItem("Audio", types.Int64Unknown()),  // new
+	}
+
+	changes := diffCategories(current, desired)
+
+	var set []string
+	for _, input := range changes.set {
+		set = append(set, input.Name)
+	}
+	if want := []string{"audio", "books", "music", "tv"}; !reflect.DeepEqual(set, want) {
+		t.Errorf("expected to set %v, got %v", want, set)
+	}
+
+	if want := []string{"software"}; !reflect.DeepEqual(changes.remove, want) {
+		t.Errorf("expected to remove %v, got %v", want, changes.remove)
+	}
+
+	// New categories without an order follow the existing ones by name.
+	wantOrders := map[string]int{"audio": 4, "books": 9, "movies": 1, "music": 5, "tv": 2}
+	if !reflect.DeepEqual(changes.orders, wantOrders) {
+		t.Errorf("expected orders %v, got %v", wantOrders, changes.orders)
+	}
+}
+
+func TestCategoriesResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.categories = []client.Category{
+		{Name: client.DefaultCategoryName, Script: "None", PP: "3"},
+		{Name: "unmanaged", Script: "None", Priority: -100, Order: 1},
+	}
+
+	r := &CategoriesResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoriesResourceModel{
+		ID:            types.StringUnknown(),
+		ResetOnCreate: types.BoolValue(false),
+		Categories: map[string]CategoriesItemModel{
+			"movies": testCategoriesItem("Movies", types.Int64Unknown()),
+			"tv":     testCategoriesItem("TV", types.Int64Unknown()),
+		},
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var names []string
+	for _, category := range f.categories {
+		names = append(names, category.Name)
+	}
+	if want := []string{client.DefaultCategoryName, "movies", "tv"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected categories %v after create, got %v", want, names)
+	}
+
+	var created CategoriesResourceModel
+	createResp.State.Get(ctx, &created)
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read CategoriesResourceModel
+	readResp.State.Get(ctx, &read)
+	if !reflect.DeepEqual(read, created) {
+		t.Errorf("state drifted after read:\ncreated %+v\nread    %+v", created, read)
+	}
+
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &resource.DeleteResponse{})
+	if len(f.categories) != 1 || f.categories[0].Name != client.DefaultCategoryName {
+		t.Errorf("expected only the default category after delete, got %+v", f.categories)
+	}
+}
+
+func TestCategoriesResourceResetOnCreate(t *testing.T) {
+	ctx := context.Background()
+
+	for _, reset := range []bool{false, true} {
+		f, c := newFakeSabnzbd(t)
+		f.categories = []client.Category{
+			{Name: client.DefaultCategoryName, Script: "None", PP: "3"},
+			{Name: "movies", Script: "None", Extra: map[string]string{"newzbin": "Movies"}},
+		}
+
+		r := &CategoriesResource{client: c}
+		s := resourceSchema(t, r)
+
+		plan := CategoriesResourceModel{
+			ID:            types.StringUnknown(),
+			ResetOnCreate: types.BoolValue(reset),
+			Categories: map[string]CategoriesItemModel{
+				"movies": testCategoriesItem("Movies", types.Int64Unknown()),
+			},
+		}
+
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("reset %t: unexpected create diagnostics: %v", reset, createResp.Diagnostics)
+		}
+
+		// Settings the resource does not manage only survive without a reset.
+		movies, ok := client.FindCategory(f.categories, "movies")
+		if !ok {
+			t.Fatalf("reset %t: expected movies to exist, got %+v", reset, f.categories)
+		}
+		if kept := movies.Extra["newzbin"] == "Movies"; kept == reset {
+			t.Errorf("reset %t: unexpected extra settings %v", reset, movies.Extra)
+		}
+		if _, ok := client.FindCategory(f.categories, client.DefaultCategoryName); !ok {
+			t.Errorf("reset %t: expected the default category to be kept", reset)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CategoryDataSource{}
+
+func NewCategoryDataSource() datasource.DataSource {
+	return &CategoryDataSource{}
+}
+
+// CategoryDataSource defines the data source implementation.
+type CategoryDataSource struct {
+	client *client.Client
+}
+
+// CategoryDataSourceModel describes the data source data model.
+type CategoryDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Dir         types.String `tfsdk:"dir"`
+	Script      types.String `tfsdk:"script"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	PP          types.String `tfsdk:"pp"`
+	Order       types.Int64  `tfsdk:"order"`
+	DirAbsolute types.String `tfsdk:"dir_absolute"`
+}
+
+func (d *CategoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category"
+}
+
+func (d *CategoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a download category from SABnzbd by name. " +
+			"Useful for referencing the default category or a category that is managed outside of Terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the category to look up. Use `*` for the default category.",
+				Required:            true,
+			},
+			"dir": schema.StringAttribute{
+				MarkdownDescription: "The relative or absolute path for completed downloads in this category. " +
+					"Empty when the default complete folder is used.",
+				Computed: true,
+			},
+			"script": schema.StringAttribute{
+				MarkdownDescription: "The post-processing script run for downloads in this category.",
+				Computed:            true,
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The default priority for downloads in this category. " +
+					"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force.",
+				Computed: true,
+			},
+			"pp": schema.StringAttribute{
+				MarkdownDescription: "Post-processing options. Values: ``=Default, `0`=None, `1`=+Repair, " +
+					"`2`=+Repair/Unpack, `3`=+Repair/Unpack/Delete.",
+				Computed: true,
+			},
+			"order": schema.Int64Attribute{
+				MarkdownDescription: "The display order of this category in the UI.",
+				Computed:            true,
+			},
+			"dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute folder completed downloads in this category are moved to. " +
+					"A relative or empty `dir` is resolved against SABnzbd's global complete folder.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *CategoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *CategoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CategoryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	category, err := d.client.GetCategory(ctx, data.Name.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		detail := fmt.Sprintf("No category named %q is configured in SABnzbd.", data.Name.ValueString())
+		if data.Name.ValueString() != client.DefaultCategoryName {
+			detail += fmt.Sprintf(" The default category is named %q.", client.DefaultCategoryName)
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Category Not Found", detail)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read category", err)
+		return
+	}
+
+	data.Dir = types.StringValue(category.Dir)
+	data.Script = types.StringValue(category.Script)
+	data.Priority = types.Int64Value(int64(category.Priority))
+	data.PP = types.StringValue(category.PP)
+	data.Order = types.Int64Value(int64(category.Order))
+
+	dirAbsolute, err := d.client.GetCategoryCompleteDir(ctx, category.Dir)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read category folder", err)
+		return
+	}
+	data.DirAbsolute = types.StringValue(dirAbsolute)
+
+	tflog.Trace(ctx, "read category data source", map[string]interface{}{"name": data.Name.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func readCategoryDataSource(t *testing.T, d *CategoryDataSource, name string) (CategoryDataSourceModel, datasource.ReadResponse) {
+	t.Helper()
+
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &CategoryDataSourceModel{
+		Name:        types.StringValue(name),
+		Dir:         types.StringNull(),
+		Script:      types.StringNull(),
+		Priority:    types.Int64Null(),
+		PP:          types.StringNull(),
+		Order:       types.Int64Null(),
+		DirAbsolute: types.StringNull(),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+
+	var data CategoryDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.State.Get(context.Background(), &data)
+	}
+
+	return data, resp
+}
+
+func TestCategoryDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.categories = []client.Category{
+		{Name: client.DefaultCategoryName, Script: "None", Priority: 0, PP: "3"},
+		{Name: "movies", Dir: "Movies", Script: "notify.py", Priority: 1, Order: 1},
+	}
+
+	f.misc["complete_dir"] = "/data/complete"
+
+	d := &CategoryDataSource{client: c}
+
+	data, resp := readCategoryDataSource(t, d, "movies")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if data.Dir.ValueString() != "Movies" || data.Script.ValueString() != "notify.py" ||
+		data.Priority.ValueInt64() != 1 || data.Order.ValueInt64() != 1 {
+		t.Errorf("unexpected category: %+v", data)
+	}
+	if data.DirAbsolute.ValueString() != "/data/complete/Movies" {
+		t.Errorf("expected dir_absolute /data/complete/Movies, got %q", data.DirAbsolute.ValueString())
+	}
+
+	data, resp = readCategoryDataSource(t, d, client.DefaultCategoryName)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics for default category: %v", resp.Diagnostics)
+	}
+	if data.PP.ValueString() != "3" {
+		t.Errorf("expected default category pp %q, got %q", "3", data.PP.ValueString())
+	}
+}
+
+func TestCategoryDataSourceNotFound(t *testing.T) {
+	_, c := newFakeSabnzbd(t)
+	d := &CategoryDataSource{client: c}
+
+	_, resp := readCategoryDataSource(t, d, "missing")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a missing category")
+	}
+
+	diag := resp.Diagnostics.Errors()[0]
+	if diag.Summary() != "Category Not Found" {
+		t.Errorf("expected summary %q, got %q", "Category Not Found", diag.Summary())
+	}
+	if withPath, ok := diag.(interface{ Path() path.Path }); !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Errorf("expected the error to be attached to name")
+	}
+}
@@ -6,12 +6,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -22,24 +26,40 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CategoryResource{}
 var _ resource.ResourceWithImportState = &CategoryResource{}
+var _ resource.ResourceWithModifyPlan = &CategoryResource{}
+var _ resource.ResourceWithValidateConfig = &CategoryResource{}
 
 func NewCategoryResource() resource.Resource {
 	return &CategoryResource{}
 }
 
+// Built-in defaults for category settings, used unless the provider
+// configures its own.
+const (
+	defaultCategoryScript   = "None"
+	defaultCategoryPriority = client.DefaultPriority
+)
+
 // CategoryResource defines the resource implementation.
 type CategoryResource struct {
 	client *client.Client
+
+	// defaultScript and defaultPriority are the provider's defaults for
+	// unset attributes, or null to use the built-in ones.
+	defaultScript   types.String
+	defaultPriority types.Int64
 }
 
 // CategoryResourceModel describes the resource data model.
 type CategoryResourceModel struct {
-	Name     types.String `tfsdk:"name"`
-	Dir      types.String `tfsdk:"dir"`
-	Script   types.String `tfsdk:"script"`
-	Priority types.Int64  `tfsdk:"priority"`
-	PP       types.String `tfsdk:"pp"`
-	Order    types.Int64  `tfsdk:"order"`
+	Name        types.String `tfsdk:"name"`
+	Dir         types.String `tfsdk:"dir"`
+	Script      types.String `tfsdk:"script"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	PP          types.String `tfsdk:"pp"`
+	Order       types.Int64  `tfsdk:"order"`
+	Extra       types.Map    `tfsdk:"extra"`
+	DirAbsolute types.String `tfsdk:"dir_absolute"`
 }
 
 func (r *CategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,8 +73,11 @@ func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The unique name of the category. Use `*` for the default category.",
-				Required:            true,
+				MarkdownDescription: "The unique name of the category. SABnzbd stores names in lowercase, so names " +
+					"that only differ in case refer to the same category. Use `*` for the default category; " +
+					"since it cannot be deleted, destroying it only removes it from state. " +
+					"Prefer `sabnzbd_default_category` to manage the default category.",
+				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -68,17 +91,18 @@ func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"script": schema.StringAttribute{
 				MarkdownDescription: "The post-processing script to run for downloads in this category. " +
-					"Use `None` for no script, or `Default` to use the global default.",
+					"Use `None` for no script, or `Default` to use the global default. " +
+					"SABnzbd has no per-category pre-queue script; the pre-queue script is a global setting. " +
+					"Defaults to the provider's `default_script`, or `None` if that is not set.",
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString("None"),
 			},
 			"priority": schema.Int64Attribute{
 				MarkdownDescription: "The default priority for downloads in this category. " +
-					"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force.",
+					"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force. " +
+					"Defaults to the provider's `default_priority`, or `-100` if that is not set.",
 				Optional: true,
 				Computed: true,
-				Default:  int64default.StaticInt64(-100),
 			},
 			"pp": schema.StringAttribute{
 				MarkdownDescription: "Post-processing options. Values: ``=Default, `0`=None, `1`=+Repair, " +
@@ -88,10 +112,28 @@ func (r *CategoryResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:  stringdefault.StaticString(""),
 			},
 			"order": schema.Int64Attribute{
-				MarkdownDescription: "The display order of this category in the UI.",
-				Optional:            true,
-				Computed:            true,
-				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "The display order of this category in the UI. " +
+					"If not set, a new category is placed after the existing ones: it gets one more than the highest " +
+					"order in use (or `0` if there are no categories), and that value is kept afterwards. Categories " +
+					"created in the same apply get distinct orders, but one added elsewhere at the same moment may " +
+					"share it; set `order` when the exact position matters.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"extra": schema.MapAttribute{
+				MarkdownDescription: "Additional category settings to send to SABnzbd by key, for settings this resource " +
+					"does not have an attribute for (e.g. `newzbin`). Only the keys listed here are read back. " +
+					"Removing a key sets it to an empty value in SABnzbd.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute folder completed downloads in this category are moved to. " +
+					"A relative or empty `dir` is resolved against SABnzbd's global complete folder.",
+				Computed: true,
 			},
 		},
 	}
@@ -102,16 +144,159 @@ func (r *CategoryResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.defaultScript = data.defaultScript
+	r.defaultPriority = data.defaultPriority
+}
+
+func (r *CategoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var extra types.Map
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra"), &extra)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for key := range extra.Elements() {
+		if client.IsCategoryField(key) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra").AtMapKey(key),
+				"Reserved Category Setting",
+				fmt.Sprintf("The %q setting has its own attribute and cannot be set through extra.", key),
+			)
+		}
+	}
+}
+
+func (r *CategoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.planDefaults(ctx, req, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// No API to ask before the provider is configured.
+	if r.client == nil {
+		return
+	}
+
+	// Only a new category can clobber another; an existing one keeps its
+	// name since changing it forces replacement.
+	if req.State.Raw.IsNull() {
+		r.planNameCollision(ctx, resp)
+	}
+
+	var script types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("script"), &script)...)
+	if resp.Diagnostics.HasError() || script.IsNull() || script.IsUnknown() {
+		return
+	}
+
+	if script.ValueString() == "None" || script.ValueString() == "Default" {
+		return
+	}
+
+	scripts, err := r.client.GetScripts(ctx)
+	if err != nil {
+		// Don't block planning when SABnzbd is unreachable; apply will report it.
+		tflog.Debug(ctx, "unable to list scripts while planning category", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if !slices.Contains(scripts, script.ValueString()) {
+		resp.Diagnostics.Append(scriptNotFoundWarning(path.Root("script"), script.ValueString(), scripts))
+	}
+}
+
+// planDefaults plans the default script and priority for those attributes
+// the configuration leaves unset. They have no schema default since the
+// provider's defaults are only known once it is configured.
+func (r *CategoryResource) planDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var script types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("script"), &script)...)
+	if script.IsNull() {
+		value := types.StringValue(defaultCategoryScript)
+		if !r.defaultScript.IsNull() {
+			value = r.defaultScript
+		}
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("script"), value)...)
+	}
+
+	var priority types.Int64
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("priority"), &priority)...)
+	if priority.IsNull() {
+		value := types.Int64Value(defaultCategoryPriority)
+		if !r.defaultPriority.IsNull() {
+			value = r.defaultPriority
+		}
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("priority"), value)...)
+	}
+
+	return diags
+}
+
+// planNameCollision warns when SABnzbd would store the planned category under
+// the name of another one. SABnzbd lowercases category names, so a category
+// that only differs in case from an existing one overwrites it.
+func (r *CategoryResource) planNameCollision(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	var name types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() {
+		return
+	}
+
+	config, err := r.client.GetConfig(ctx)
+	if err != nil {
+		// Don't block planning when SABnzbd is unreachable; apply will report it.
+		tflog.Debug(ctx, "unable to list categories while planning category", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if existing, ok := caseCollidingCategory(name.ValueString(), config.Categories); ok {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("name"),
+			"Category Name Collision",
+			fmt.Sprintf("SABnzbd already has a category named %q and does not tell category names apart by case, "+
+				"so creating %q overwrites it. Use %q to manage the existing category.", existing, name.ValueString(), existing),
 		)
 		return
 	}
 
-	r.client = c
+	if lower := strings.ToLower(name.ValueString()); lower != name.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("name"),
+			"Category Name Is Lowercased",
+			fmt.Sprintf("SABnzbd stores category names in lowercase, so %q is saved as %q and overwrites any other "+
+				"category of that name, including one declared in a different case. Use %q instead.", name.ValueString(), lower, lower),
+		)
+	}
+}
+
+// caseCollidingCategory returns the name of the category in categories that
+// matches name when case is ignored but is spelled differently.
+func caseCollidingCategory(name string, categories []client.Category) (string, bool) {
+	for _, cat := range categories {
+		if cat.Name != name && strings.EqualFold(cat.Name, name) {
+			return cat.Name, true
+		}
+	}
+
+	return "", false
 }
 
 func (r *CategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -122,20 +307,30 @@ func (r *CategoryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	input := &client.CategoryInput{
-		Name:     data.Name.ValueString(),
-		Dir:      data.Dir.ValueString(),
-		Script:   data.Script.ValueString(),
-		Priority: int(data.Priority.ValueInt64()),
-		PP:       data.PP.ValueString(),
-		Order:    int(data.Order.ValueInt64()),
+	input := categoryInputFromModel(&data)
+
+	// Place the category after the existing ones when no order was
+	// configured, rather than leaving every category at order 0. The default
+	// category is not listed with the others, so it keeps its order.
+	var err error
+	if data.Order.IsUnknown() && data.Name.ValueString() != client.DefaultCategoryName {
+		err = r.client.AddCategoryLast(ctx, input)
+		if err == nil {
+			data.Order = types.Int64Value(int64(*input.Order))
+		}
+	} else {
+		err = r.client.SetCategory(ctx, input)
 	}
-
-	if err := r.client.SetCategory(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create category, got error: %s", err))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create category", err)
 		return
 	}
 
+	// The category exists either way, so save the state even when reading
+	// it back fails. This also adopts the order of the default category when
+	// none was configured.
+	resp.Diagnostics.Append(r.readBackCategory(ctx, &data)...)
+
 	tflog.Trace(ctx, "created category resource", map[string]interface{}{"name": data.Name.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -151,15 +346,18 @@ func (r *CategoryResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	category, err := r.client.GetCategory(ctx, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read category, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read category", err)
 		return
 	}
 
-	data.Dir = types.StringValue(category.Dir)
-	data.Script = types.StringValue(category.Script)
-	data.Priority = types.Int64Value(int64(category.Priority))
-	data.PP = types.StringValue(category.PP)
-	data.Order = types.Int64Value(int64(category.Order))
+	setCategoryModel(&data, category)
+
+	dirAbsolute, err := r.client.GetCategoryCompleteDir(ctx, category.Dir)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read category folder", err)
+		return
+	}
+	data.DirAbsolute = types.StringValue(dirAbsolute)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -172,20 +370,30 @@ func (r *CategoryResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	input := &client.CategoryInput{
-		Name:     data.Name.ValueString(),
-		Dir:      data.Dir.ValueString(),
-		Script:   data.Script.ValueString(),
-		Priority: int(data.Priority.ValueInt64()),
-		PP:       data.PP.ValueString(),
-		Order:    int(data.Order.ValueInt64()),
+	var state CategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := categoryInputFromModel(&data)
+
+	// Clear the extra settings that are no longer configured.
+	for key := range state.Extra.Elements() {
+		if _, ok := input.Extra[key]; !ok {
+			input.Extra[key] = ""
+		}
 	}
 
 	if err := r.client.SetCategory(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update category, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update category", err)
 		return
 	}
 
+	// As on create, save the state even when reading it back fails.
+	resp.Diagnostics.Append(r.readBackCategory(ctx, &data)...)
+
 	tflog.Trace(ctx, "updated category resource", map[string]interface{}{"name": data.Name.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -199,8 +407,22 @@ func (r *CategoryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	if err := r.client.DeleteCategory(ctx, data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete category, got error: %s", err))
+	// SABnzbd cannot delete the default category, so it is only removed from
+	// state and keeps its current settings.
+	if data.Name.ValueString() == client.DefaultCategoryName {
+		tflog.Trace(ctx, "deleted default category resource from state")
+		return
+	}
+
+	// del_config needs the name as SABnzbd stored it, which may differ in
+	// case from the configured one.
+	name := data.Name.ValueString()
+	if category, err := r.client.GetCategory(ctx, name); err == nil {
+		name = category.Name
+	}
+
+	if err := r.client.DeleteCategory(ctx, name); err != nil {
+		addClientError(&resp.Diagnostics, "delete category", err)
 		return
 	}
 
@@ -210,3 +432,97 @@ func (r *CategoryResource) Delete(ctx context.Context, req resource.DeleteReques
 func (r *CategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// readBackCategory reconciles data with the settings SABnzbd stored for the
+// category after a write (see adoptStoredValue) and resolves dir_absolute.
+// Computed attributes that cannot be read are set to null, since state cannot
+// hold unknown values.
+func (r *CategoryResource) readBackCategory(ctx context.Context, data *CategoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defer func() {
+		if data.Order.IsUnknown() {
+			data.Order = types.Int64Null()
+		}
+		if data.DirAbsolute.IsUnknown() {
+			data.DirAbsolute = types.StringNull()
+		}
+	}()
+
+	category, err := r.client.GetCategory(ctx, data.Name.ValueString())
+	if err != nil {
+		addClientError(&diags, "read category", err)
+		return diags
+	}
+
+	stored := CategoryResourceModel{Extra: data.Extra}
+	setCategoryModel(&stored, category)
+
+	adoptStoredValue(&diags, path.Root("dir"), &data.Dir, stored.Dir)
+	adoptStoredValue(&diags, path.Root("script"), &data.Script, stored.Script)
+	adoptStoredValue(&diags, path.Root("priority"), &data.Priority, stored.Priority)
+	adoptStoredValue(&diags, path.Root("pp"), &data.PP, stored.PP)
+	adoptStoredValue(&diags, path.Root("order"), &data.Order, stored.Order)
+	adoptStoredValue(&diags, path.Root("extra"), &data.Extra, stored.Extra)
+
+	dirAbsolute, err := r.client.GetCategoryCompleteDir(ctx, category.Dir)
+	if err != nil {
+		addClientError(&diags, "read category folder", err)
+		return diags
+	}
+	data.DirAbsolute = types.StringValue(dirAbsolute)
+
+	return diags
+}
+
+// setCategoryModel copies the settings SABnzbd stores for category into data.
+func setCategoryModel(data *CategoryResourceModel, category *client.Category) {
+	data.Dir = types.StringValue(category.Dir)
+	data.Script = types.StringValue(category.Script)
+	data.Priority = types.Int64Value(int64(category.Priority))
+	data.PP = types.StringValue(category.PP)
+	data.Order = types.Int64Value(int64(category.Order))
+	data.Extra = trackedCategoryExtra(data.Extra, category.Extra)
+}
+
+// categoryInputFromModel converts the resource model into a client input.
+func categoryInputFromModel(data *CategoryResourceModel) *client.CategoryInput {
+	input := &client.CategoryInput{
+		Name:     data.Name.ValueString(),
+		Dir:      data.Dir.ValueString(),
+		Script:   data.Script.ValueString(),
+		Priority: int(data.Priority.ValueInt64()),
+		PP:       data.PP.ValueString(),
+		Extra:    map[string]string{},
+	}
+
+	for key, value := range data.Extra.Elements() {
+		if v, ok := value.(types.String); ok && !v.IsUnknown() && !v.IsNull() {
+			input.Extra[key] = v.ValueString()
+		}
+	}
+
+	if !data.Order.IsUnknown() && !data.Order.IsNull() {
+		order := int(data.Order.ValueInt64())
+		input.Order = &order
+	}
+
+	return input
+}
+
+// trackedCategoryExtra returns the values SABnzbd reports for the keys of
+// tracked, so only the extra settings that are configured are read back. A
+// key SABnzbd no longer has is dropped so that it shows up as a change.
+func trackedCategoryExtra(tracked types.Map, current map[string]string) types.Map {
+	if tracked.IsNull() || tracked.IsUnknown() {
+		return tracked
+	}
+
+	values := make(map[string]attr.Value, len(tracked.Elements()))
+	for key := range tracked.Elements() {
+		if value, ok := current[key]; ok {
+			values[key] = types.StringValue(value)
+		}
+	}
+
+	return types.MapValueMust(types.StringType, values)
+}
@@ -0,0 +1,547 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCategoryResourceOrderStable(t *testing.T) {
+	ctx := context.Background()
+	_, c := newFakeSabnzbd(t)
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	orders := map[int64]bool{}
+	for i, name := range []string{"movies", "tv", "software"} {
+		plan := CategoryResourceModel{
+			Name:        types.StringValue(name),
+			Dir:         types.StringValue(""),
+			Script:      types.StringValue("None"),
+			Priority:    types.Int64Value(-100),
+			PP:          types.StringValue(""),
+			Order:       types.Int64Unknown(),
+			Extra:       types.MapNull(types.StringType),
+			DirAbsolute: types.StringUnknown(),
+		}
+
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected create diagnostics: %v", name, createResp.Diagnostics)
+		}
+
+		var created CategoryResourceModel
+		createResp.State.Get(ctx, &created)
+		if created.Order.IsUnknown() || created.Order.IsNull() {
+			t.Fatalf("%s: expected order to be known after create", name)
+		}
+		// Each new category goes after the previous ones.
+		if created.Order.ValueInt64() != int64(i) {
+			t.Errorf("%s: expected order %d, got %d", name, i, created.Order.ValueInt64())
+		}
+		if orders[created.Order.ValueInt64()] {
+			t.Errorf("%s: order %d assigned twice", name, created.Order.ValueInt64())
+		}
+		orders[created.Order.ValueInt64()] = true
+
+		readResp := resource.ReadResponse{State: createResp.State}
+		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected read diagnostics: %v", name, readResp.Diagnostics)
+		}
+
+		var read CategoryResourceModel
+		readResp.State.Get(ctx, &read)
+		if !reflect.DeepEqual(read, created) {
+			t.Errorf("%s: state drifted after read: created %+v, read %+v", name, created, read)
+		}
+	}
+}
+
+func TestCategoryResourceModifyPlanScript(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.scripts = []string{"None", "notify.py"}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	tests := map[string]bool{
+		"notify.py":  false,
+		"None":       false,
+		"Default":    false,
+		"missing.py": true,
+	}
+
+	for script, wantWarning := range tests {
+		model := CategoryResourceModel{
+			Name:        types.StringValue("movies"),
+			Dir:         types.StringValue(""),
+			Script:      types.StringValue(script),
+			Priority:    types.Int64Value(-100),
+			PP:          types.StringValue(""),
+			Order:       types.Int64Unknown(),
+			Extra:       types.MapNull(types.StringType),
+			DirAbsolute: types.StringUnknown(),
+		}
+		plan := newPlan(t, s, &model)
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: newConfig(t, s, &model), State: newState(t, s, nil)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error diagnostics: %v", script, resp.Diagnostics)
+		}
+		if got := resp.Diagnostics.WarningsCount() > 0; got != wantWarning {
+			t.Errorf("%s: expected warning %t, got %t", script, wantWarning, got)
+		}
+	}
+}
+
+func TestCategoryResourceModifyPlanUnreachable(t *testing.T) {
+	r := &CategoryResource{client: client.NewClient("http://127.0.0.1:1", "test-key")}
+	s := resourceSchema(t, r)
+
+	model := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringValue(""),
+		Script:      types.StringValue("missing.py"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Unknown(),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringUnknown(),
+	}
+	plan := newPlan(t, s, &model)
+
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan, Config: newConfig(t, s, &model), State: newState(t, s, nil)}, &resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics when SABnzbd is unreachable, got %v", resp.Diagnostics)
+	}
+}
+
+func TestCategoryResourceDefaultCategory(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.categories = []client.Category{
+		{Name: client.DefaultCategoryName, Script: "None", PP: "3"},
+		{Name: "movies", Order: 4},
+	}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoryResourceModel{
+		Name:        types.StringValue(client.DefaultCategoryName),
+		Dir:         types.StringValue(""),
+		Script:      types.StringValue("None"),
+		Priority:    types.Int64Value(0),
+		PP:          types.StringValue("2"),
+		Order:       types.Int64Unknown(),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringUnknown(),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// The default category keeps its order instead of being moved last.
+	var created CategoryResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Order.ValueInt64() != 0 {
+		t.Errorf("expected the default category to keep order 0, got %d", created.Order.ValueInt64())
+	}
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if len(f.categories) != 2 {
+		t.Errorf("expected the default category to be kept on destroy, got %+v", f.categories)
+	}
+}
+
+func TestCategoryResourceExtra(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringValue(""),
+		Script:      types.StringValue("None"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Value(1),
+		Extra:       types.MapValueMust(types.StringType, map[string]attr.Value{"newzbin": types.StringValue("Movies")}),
+		DirAbsolute: types.StringUnknown(),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if got := f.categories[0].Extra["newzbin"]; got != "Movies" {
+		t.Errorf("expected newzbin to be sent, got %q", got)
+	}
+
+	// Settings that are not tracked in extra are not read back.
+	f.categories[0].Extra["indexer_tag"] = "hd"
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read CategoryResourceModel
+	readResp.State.Get(ctx, &read)
+	if !read.Extra.Equal(plan.Extra) {
+		t.Errorf("expected extra %s after read, got %s", plan.Extra, read.Extra)
+	}
+
+	// Removing a key clears it in SABnzbd.
+	plan.Extra = types.MapNull(types.StringType)
+	plan.DirAbsolute = read.DirAbsolute
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &plan), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if got := f.categories[0].Extra["newzbin"]; got != "" {
+		t.Errorf("expected newzbin to be cleared, got %q", got)
+	}
+}
+
+func TestCategoryResourceValidateExtra(t *testing.T) {
+	r := &CategoryResource{}
+	s := resourceSchema(t, r)
+
+	config := newConfig(t, s, &CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringNull(),
+		Script:      types.StringNull(),
+		Priority:    types.Int64Null(),
+		PP:          types.StringNull(),
+		Order:       types.Int64Null(),
+		Extra:       types.MapValueMust(types.StringType, map[string]attr.Value{"dir": types.StringValue("Movies")}),
+		DirAbsolute: types.StringNull(),
+	})
+
+	resp := resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error setting dir through extra")
+	}
+}
+
+func TestCategoryResourceClearDir(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc["complete_dir"] = "/data/complete"
+	f.categories = []client.Category{{Name: "movies", Dir: "Movies", Script: "None", Priority: -100, Order: 1}}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	state := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringValue("Movies"),
+		Script:      types.StringValue("None"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Value(1),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringValue("/data/complete/Movies"),
+	}
+	plan := state
+	plan.Dir = types.StringValue("")
+	plan.DirAbsolute = types.StringUnknown()
+
+	updateResp := resource.UpdateResponse{State: newState(t, s, &state)}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &plan), State: newState(t, s, &state)}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if f.categories[0].Dir != "" {
+		t.Errorf("expected the dir override to be cleared, got %q", f.categories[0].Dir)
+	}
+
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read CategoryResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Dir.ValueString() != "" || read.DirAbsolute.ValueString() != "/data/complete" {
+		t.Errorf("expected an empty dir using the complete folder, got dir %s, dir_absolute %s", read.Dir, read.DirAbsolute)
+	}
+}
+
+func TestCategoryResourceScriptRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.scripts = []string{"None", "notify.py"}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringValue(""),
+		Script:      types.StringValue("notify.py"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Value(1),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringUnknown(),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// script is the only script setting a category has.
+	if got := f.categories[0]; got.Script != "notify.py" || len(got.Extra) != 0 {
+		t.Errorf("expected only script to be set, got %+v", got)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read CategoryResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Script.ValueString() != "notify.py" {
+		t.Errorf("expected script notify.py after read, got %s", read.Script)
+	}
+	// -100 is the Default sentinel and must not be read back as Normal.
+	if f.categories[0].Priority != client.DefaultPriority || read.Priority.ValueInt64() != client.DefaultPriority {
+		t.Errorf("expected priority -100 to round-trip, stored %d and read %s", f.categories[0].Priority, read.Priority)
+	}
+}
+
+func TestCategoryResourceProviderDefaults(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &CategoryResource{})
+
+	config := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringNull(),
+		Script:      types.StringNull(),
+		Priority:    types.Int64Null(),
+		PP:          types.StringNull(),
+		Order:       types.Int64Null(),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringNull(),
+	}
+	plan := config
+	plan.Script = types.StringUnknown()
+	plan.Priority = types.Int64Unknown()
+	plan.DirAbsolute = types.StringUnknown()
+
+	cases := []struct {
+		name         string
+		resource     *CategoryResource
+		config       func(*CategoryResourceModel)
+		wantScript   string
+		wantPriority int64
+	}{
+		{"built-in defaults", &CategoryResource{}, func(*CategoryResourceModel) {}, "None", -100},
+		{
+			"provider defaults",
+			&CategoryResource{defaultScript: types.StringValue("notify.py"), defaultPriority: types.Int64Value(1)},
+			func(*CategoryResourceModel) {},
+			"notify.py", 1,
+		},
+		{
+			"resource values win",
+			&CategoryResource{defaultScript: types.StringValue("notify.py"), defaultPriority: types.Int64Value(1)},
+			func(m *CategoryResourceModel) {
+				m.Script = types.StringValue("Default")
+				m.Priority = types.Int64Value(0)
+			},
+			"Default", 0,
+		},
+	}
+
+	for _, tc := range cases {
+		config, plan := config, plan
+		tc.config(&config)
+		tc.config(&plan)
+
+		resp := resource.ModifyPlanResponse{Plan: newPlan(t, s, &plan)}
+		tc.resource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Plan:   newPlan(t, s, &plan),
+			Config: newConfig(t, s, &config),
+			State:  newState(t, s, nil),
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", tc.name, resp.Diagnostics)
+		}
+
+		var got CategoryResourceModel
+		resp.Plan.Get(ctx, &got)
+		if got.Script.ValueString() != tc.wantScript || got.Priority.ValueInt64() != tc.wantPriority {
+			t.Errorf("%s: expected script %q and priority %d, got %s and %s",
+				tc.name, tc.wantScript, tc.wantPriority, got.Script, got.Priority)
+		}
+	}
+}
+
+func TestCategoryResourceKeepsPlannedValues(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.normalize = func(section, key, value string) string {
+		if section == "categories" && key == "dir" {
+			return strings.TrimRight(value, "/")
+		}
+		return value
+	}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoryResourceModel{
+		Name:        types.StringValue("movies"),
+		Dir:         types.StringValue("movies/"),
+		Script:      types.StringValue("None"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Value(1),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringUnknown(),
+	}
+
+	// Terraform rejects an apply result that differs from a known planned
+	// value, so the normalized dir is warned about instead of stored.
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if createResp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a warning about the normalized dir, got %v", createResp.Diagnostics)
+	}
+
+	var created CategoryResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Dir.ValueString() != "movies/" || created.DirAbsolute.ValueString() != "/config/Downloads/complete/movies" {
+		t.Errorf("expected the planned dir and the resolved stored one, got %+v", created)
+	}
+}
+
+func TestCategoryResourceMixedCaseName(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := CategoryResourceModel{
+		Name:        types.StringValue("Movies"),
+		Dir:         types.StringValue(""),
+		Script:      types.StringValue("None"),
+		Priority:    types.Int64Value(-100),
+		PP:          types.StringValue(""),
+		Order:       types.Int64Unknown(),
+		Extra:       types.MapNull(types.StringType),
+		DirAbsolute: types.StringUnknown(),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// SABnzbd stores the category as movies, which is still found under the
+	// configured name.
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read CategoryResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Name.ValueString() != "Movies" || read.Order.IsNull() {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if len(f.categories) != 0 {
+		t.Errorf("expected the category to be deleted, got %+v", f.categories)
+	}
+}
+
+func TestCategoryResourceModifyPlanNameCollision(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.categories = []client.Category{{Name: "movies", Order: 1}}
+
+	r := &CategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	tests := map[string]string{
+		"movies": "",
+		"tv":     "",
+		"Movies": "Category Name Collision",
+		"TV":     "Category Name Is Lowercased",
+	}
+
+	for name, wantWarning := range tests {
+		model := CategoryResourceModel{
+			Name:        types.StringValue(name),
+			Dir:         types.StringValue(""),
+			Script:      types.StringValue("None"),
+			Priority:    types.Int64Value(-100),
+			PP:          types.StringValue(""),
+			Order:       types.Int64Unknown(),
+			Extra:       types.MapNull(types.StringType),
+			DirAbsolute: types.StringUnknown(),
+		}
+		plan := newPlan(t, s, &model)
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: newConfig(t, s, &model), State: newState(t, s, nil)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error diagnostics: %v", name, resp.Diagnostics)
+		}
+
+		var got string
+		if warnings := resp.Diagnostics.Warnings(); len(warnings) > 0 {
+			got = warnings[0].Summary()
+		}
+		if got != wantWarning {
+			t.Errorf("%s: expected warning %q, got %q", name, wantWarning, got)
+		}
+	}
+}
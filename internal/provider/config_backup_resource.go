@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigBackupResource{}
+
+func NewConfigBackupResource() resource.Resource {
+	return &ConfigBackupResource{}
+}
+
+// ConfigBackupResource defines the resource implementation.
+type ConfigBackupResource struct {
+	client *client.Client
+}
+
+// ConfigBackupResourceModel describes the resource data model.
+type ConfigBackupResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Path             types.String `tfsdk:"path"`
+	IncludeSecrets   types.Bool   `tfsdk:"include_secrets"`
+	ServerBackup     types.Bool   `tfsdk:"server_backup"`
+	Triggers         types.Map    `tfsdk:"triggers"`
+	SHA256           types.String `tfsdk:"sha256"`
+	ServerBackupPath types.String `tfsdk:"server_backup_path"`
+}
+
+func (r *ConfigBackupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_backup"
+}
+
+func (r *ConfigBackupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Writes the complete SABnzbd configuration as JSON to a file on the machine running Terraform, " +
+			"as a disaster-recovery snapshot. The export is the same as `sabnzbd_config_export`'s. " +
+			"The snapshot is written when the resource is created and again whenever an attribute changes, " +
+			"and is written again if the file is removed. Destroying the resource keeps the file.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The path the snapshot was written to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The file to write the snapshot to. Missing parent directories are created, " +
+					"and an existing file is overwritten.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Include passwords and API keys in the snapshot instead of replacing them with `" +
+					client.RedactedValue + "`. The file is only readable by its owner either way. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_backup": schema.BoolAttribute{
+				MarkdownDescription: "Also have SABnzbd write its own backup of the configuration and database " +
+					"to the backup folder on the SABnzbd host, which SABnzbd can restore from. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause a new snapshot to be written when they change, " +
+					"such as the IDs of the resources it should capture.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 checksum of the snapshot, hex-encoded.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_backup_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the backup SABnzbd wrote on its host, or null if `server_backup` is not set.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConfigBackupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ConfigBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigBackupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoded, err := r.client.ExportConfig(ctx, data.IncludeSecrets.ValueBool())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "export config", err)
+		return
+	}
+
+	file := data.Path.ValueString()
+	if err := writeConfigBackup(file, encoded); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Write Config Backup",
+			fmt.Sprintf("Unable to write the config backup to %s: %s.", file, err))
+		return
+	}
+
+	data.ServerBackupPath = types.StringNull()
+	if data.ServerBackup.ValueBool() {
+		backupPath, err := r.client.CreateBackup(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "create SABnzbd backup", err)
+			return
+		}
+		data.ServerBackupPath = types.StringValue(backupPath)
+	}
+
+	sum := sha256.Sum256(encoded)
+	data.ID = types.StringValue(file)
+	data.SHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+	tflog.Trace(ctx, "created config backup resource", map[string]interface{}{"path": file})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigBackupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A snapshot is not refreshed from SABnzbd, but a removed file is
+	// written again on the next apply.
+	if _, err := os.Stat(data.Path.ValueString()); errors.Is(err, fs.ErrNotExist) {
+		tflog.Debug(ctx, "config backup file is gone, removing from state", map[string]interface{}{"path": data.Path.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConfigBackupResourceModel
+
+	// All configurable attributes force replacement.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Snapshots are kept for disaster recovery, so the file stays and the
+	// resource is only removed from state.
+	tflog.Trace(ctx, "deleted config backup resource from state")
+}
+
+// writeConfigBackup writes a config export to file, creating missing parent
+// directories. The export can hold credentials, so the file is only readable
+// by its owner.
+func writeConfigBackup(file string, encoded []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(file, encoded, 0o600); err != nil {
+		return err
+	}
+
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(file, 0o600)
+}
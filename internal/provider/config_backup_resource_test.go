@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConfigBackupResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "news.example.com", Password: "secret", Enable: 1}}
+
+	r := &ConfigBackupResource{client: c}
+	s := resourceSchema(t, r)
+
+	file := filepath.Join(t.TempDir(), "backups", "sabnzbd.json")
+	plan := ConfigBackupResourceModel{
+		ID:               types.StringUnknown(),
+		Path:             types.StringValue(file),
+		IncludeSecrets:   types.BoolValue(false),
+		ServerBackup:     types.BoolValue(true),
+		Triggers:         types.MapNull(types.StringType),
+		SHA256:           types.StringUnknown(),
+		ServerBackupPath: types.StringUnknown(),
+	}
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	written, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("expected the snapshot to be written: %s", err)
+	}
+	if !strings.Contains(string(written), `"news.example.com"`) || strings.Contains(string(written), `"secret"`) {
+		t.Errorf("expected a redacted export with the server, got %s", written)
+	}
+	if info, err := os.Stat(file); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("expected mode 0600, got %s", info.Mode().Perm())
+	}
+	if f.backupRequests != 1 {
+		t.Errorf("expected one create_backup request, got %d", f.backupRequests)
+	}
+
+	var created ConfigBackupResourceModel
+	createResp.State.Get(ctx, &created)
+	sum := sha256.Sum256(written)
+	if created.ID.ValueString() != file || created.SHA256.ValueString() != hex.EncodeToString(sum[:]) ||
+		created.ServerBackupPath.ValueString() != "/config/backup/sabnzbd_backup.zip" {
+		t.Errorf("unexpected state after create: %+v", created)
+	}
+
+	// A removed snapshot drops the resource from state so it is written again.
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected the resource to be removed from state")
+	}
+}
+
+func TestConfigBackupResourceWithoutServerBackup(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "news.example.com", Password: "secret", Enable: 1}}
+
+	r := &ConfigBackupResource{client: c}
+	s := resourceSchema(t, r)
+
+	file := filepath.Join(t.TempDir(), "sabnzbd.json")
+	plan := ConfigBackupResourceModel{
+		ID:               types.StringUnknown(),
+		Path:             types.StringValue(file),
+		IncludeSecrets:   types.BoolValue(true),
+		ServerBackup:     types.BoolValue(false),
+		Triggers:         types.MapNull(types.StringType),
+		SHA256:           types.StringUnknown(),
+		ServerBackupPath: types.StringUnknown(),
+	}
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if written, _ := os.ReadFile(file); !strings.Contains(string(written), `"secret"`) {
+		t.Errorf("expected the password with include_secrets, got %s", written)
+	}
+	if f.backupRequests != 0 {
+		t.Errorf("expected no create_backup request, got %d", f.backupRequests)
+	}
+
+	var created ConfigBackupResourceModel
+	createResp.State.Get(ctx, &created)
+	if !created.ServerBackupPath.IsNull() {
+		t.Errorf("expected a null server_backup_path, got %s", created.ServerBackupPath)
+	}
+}
@@ -58,9 +58,11 @@ func (d *ConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				ElementType:         types.StringType,
 			},
 			"scripts": schema.ListAttribute{
-				MarkdownDescription: "List of available script names.",
-				Computed:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "List of available script names, sorted, with `None` first. SABnzbd does not " +
+					"distinguish post-processing, pre-queue and notification scripts, so this lists every script " +
+					"in its scripts folder.",
+				Computed:    true,
+				ElementType: types.StringType,
 			},
 		},
 	}
@@ -94,7 +96,7 @@ func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Get version.
 	version, err := d.client.GetVersion(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read version, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read version", err)
 		return
 	}
 	data.Version = types.StringValue(version)
@@ -102,7 +104,7 @@ func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Get categories.
 	categories, err := d.client.GetCategories(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read categories, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read categories", err)
 		return
 	}
 	categoryValues := make([]types.String, len(categories))
@@ -119,7 +121,7 @@ func (d *ConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Get scripts.
 	scripts, err := d.client.GetScripts(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scripts, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read scripts", err)
 		return
 	}
 	scriptValues := make([]types.String, len(scripts))
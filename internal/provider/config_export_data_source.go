@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConfigExportDataSource{}
+
+func NewConfigExportDataSource() datasource.DataSource {
+	return &ConfigExportDataSource{}
+}
+
+// ConfigExportDataSource defines the data source implementation.
+type ConfigExportDataSource struct {
+	client *client.Client
+}
+
+// ConfigExportDataSourceModel describes the data source data model.
+type ConfigExportDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	IncludeSecrets types.Bool   `tfsdk:"include_secrets"`
+	JSON           types.String `tfsdk:"json"`
+}
+
+func (d *ConfigExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_export"
+}
+
+func (d *ConfigExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the complete SABnzbd configuration as JSON, for backups and migrations. " +
+			"Credentials such as server passwords and the API keys are redacted unless `include_secrets` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"include_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Include passwords and API keys in `json` instead of replacing them with `" +
+					client.RedactedValue + "`. Defaults to `false`.",
+				Optional: true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The configuration returned by SABnzbd's `get_config`, as indented JSON with sorted keys.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (d *ConfigExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *ConfigExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConfigExportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoded, err := d.client.ExportConfig(ctx, data.IncludeSecrets.ValueBool())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "export config", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-config-export")
+	data.JSON = types.StringValue(string(encoded))
+
+	tflog.Trace(ctx, "read config export data source", map[string]interface{}{"include_secrets": data.IncludeSecrets.ValueBool()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConfigExportDataSourceRedacts(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "news.example.com", Password: "secret", Enable: 1}}
+
+	d := &ConfigExportDataSource{client: c}
+	s := dataSourceSchema(t, d)
+
+	for _, includeSecrets := range []types.Bool{types.BoolNull(), types.BoolValue(true)} {
+		config := newDataSourceConfig(t, s, &ConfigExportDataSourceModel{
+			ID:             types.StringNull(),
+			IncludeSecrets: includeSecrets,
+			JSON:           types.StringNull(),
+		})
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var got ConfigExportDataSourceModel
+		resp.State.Get(context.Background(), &got)
+
+		exported := got.JSON.ValueString()
+		if !strings.Contains(exported, `"news.example.com"`) {
+			t.Errorf("include_secrets=%s: expected the server in the export, got %s", includeSecrets, exported)
+		}
+		if leaked := strings.Contains(exported, `"secret"`); leaked != includeSecrets.ValueBool() {
+			t.Errorf("include_secrets=%s: password present=%t in export %s", includeSecrets, leaked, exported)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DefaultCategoryResource{}
+var _ resource.ResourceWithImportState = &DefaultCategoryResource{}
+
+func NewDefaultCategoryResource() resource.Resource {
+	return &DefaultCategoryResource{}
+}
+
+// DefaultCategoryResource defines the resource implementation.
+type DefaultCategoryResource struct {
+	client *client.Client
+}
+
+// DefaultCategoryResourceModel describes the resource data model.
+type DefaultCategoryResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Script   types.String `tfsdk:"script"`
+	Priority types.Int64  `tfsdk:"priority"`
+	PP       types.String `tfsdk:"pp"`
+}
+
+func (r *DefaultCategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_category"
+}
+
+func (r *DefaultCategoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the settings of SABnzbd's default category (`*`), which apply to jobs without a category. " +
+			"The default category always exists and cannot be deleted: creating this resource configures it, " +
+			"settings that are not set keep their current value, and destroying the resource only removes it from state. " +
+			"Note: This is a singleton resource - only one instance should exist, and it should not be combined with a " +
+			"`sabnzbd_category` named `*`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Always `*`, the name of the default category.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"script": schema.StringAttribute{
+				MarkdownDescription: "The post-processing script to run for downloads without a category. " +
+					"Use `None` for no script.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The default priority for downloads without a category. " +
+					"Values: -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"pp": schema.StringAttribute{
+				MarkdownDescription: "Post-processing options for downloads without a category. Values: `0`=None, " +
+					"`1`=+Repair, `2`=+Repair/Unpack, `3`=+Repair/Unpack/Delete.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DefaultCategoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *DefaultCategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DefaultCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data); err != nil {
+		addClientError(&resp.Diagnostics, "create default category", err)
+		return
+	}
+
+	tflog.Trace(ctx, "created default category resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultCategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DefaultCategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	category, err := r.client.GetDefaultCategory(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read default category", err)
+		return
+	}
+
+	setDefaultCategoryModel(&data, category)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultCategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DefaultCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data); err != nil {
+		addClientError(&resp.Diagnostics, "update default category", err)
+		return
+	}
+
+	tflog.Trace(ctx, "updated default category resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultCategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The default category cannot be deleted, so it is only removed from state
+	// and keeps its current settings.
+	tflog.Trace(ctx, "deleted default category resource from state")
+}
+
+func (r *DefaultCategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != client.DefaultCategoryName {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The default category is imported with the ID %q, got %q.", client.DefaultCategoryName, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply writes the configured settings to the default category, keeping the
+// current value of any setting that is unknown in data, and updates data with
+// the result.
+func (r *DefaultCategoryResource) apply(ctx context.Context, data *DefaultCategoryResourceModel) error {
+	current, err := r.client.GetDefaultCategory(ctx)
+	if err != nil {
+		return err
+	}
+
+	input := defaultCategoryInput(data, current)
+	if err := r.client.SetCategory(ctx, input); err != nil {
+		return err
+	}
+
+	setDefaultCategoryModel(data, &client.Category{
+		Name:     input.Name,
+		Script:   input.Script,
+		Priority: input.Priority,
+		PP:       input.PP,
+	})
+
+	return nil
+}
+
+// defaultCategoryInput builds the input for the default category from data,
+// falling back to current for settings that are not known. The folder and
+// order are always kept, as they do not apply to the default category.
+func defaultCategoryInput(data *DefaultCategoryResourceModel, current *client.Category) *client.CategoryInput {
+	input := &client.CategoryInput{
+		Name:     client.DefaultCategoryName,
+		Dir:      current.Dir,
+		Script:   current.Script,
+		Priority: current.Priority,
+		PP:       current.PP,
+	}
+
+	if !data.Script.IsUnknown() && !data.Script.IsNull() {
+		input.Script = data.Script.ValueString()
+	}
+	if !data.Priority.IsUnknown() && !data.Priority.IsNull() {
+		input.Priority = int(data.Priority.ValueInt64())
+	}
+	if !data.PP.IsUnknown() && !data.PP.IsNull() {
+		input.PP = data.PP.ValueString()
+	}
+
+	return input
+}
+
+// setDefaultCategoryModel copies the settings of category into data.
+func setDefaultCategoryModel(data *DefaultCategoryResourceModel, category *client.Category) {
+	data.ID = types.StringValue(client.DefaultCategoryName)
+	data.Script = types.StringValue(category.Script)
+	data.Priority = types.Int64Value(int64(category.Priority))
+	data.PP = types.StringValue(category.PP)
+}
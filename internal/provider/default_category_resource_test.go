@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultCategoryResourceBootstrap(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &DefaultCategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	// A fresh instance does not list the default category yet.
+	plan := DefaultCategoryResourceModel{
+		ID:       types.StringUnknown(),
+		Script:   types.StringUnknown(),
+		Priority: types.Int64Unknown(),
+		PP:       types.StringValue("2"),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var got DefaultCategoryResourceModel
+	createResp.State.Get(ctx, &got)
+
+	want := DefaultCategoryResourceModel{
+		ID:       types.StringValue(client.DefaultCategoryName),
+		Script:   types.StringValue(client.DefaultCategory.Script),
+		Priority: types.Int64Value(int64(client.DefaultCategory.Priority)),
+		PP:       types.StringValue("2"),
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if len(f.categories) != 1 || f.categories[0].Name != client.DefaultCategoryName || f.categories[0].PP != "2" {
+		t.Errorf("expected the default category to be written, got %+v", f.categories)
+	}
+
+	// Destroying only forgets the default category.
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &resource.DeleteResponse{})
+	if len(f.categories) != 1 {
+		t.Errorf("expected the default category to be kept on destroy, got %+v", f.categories)
+	}
+}
+
+func TestDefaultCategoryResourceKeepsUnsetSettings(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.categories = []client.Category{
+		{Name: client.DefaultCategoryName, Script: "notify.py", Priority: 1, PP: "3"},
+	}
+
+	r := &DefaultCategoryResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := DefaultCategoryResourceModel{
+		ID:       types.StringUnknown(),
+		Script:   types.StringUnknown(),
+		Priority: types.Int64Value(-1),
+		PP:       types.StringUnknown(),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	want := client.Category{Name: client.DefaultCategoryName, Script: "notify.py", Priority: -1, PP: "3"}
+	if !reflect.DeepEqual(f.categories[0], want) {
+		t.Errorf("expected %+v, got %+v", want, f.categories[0])
+	}
+}
+
+func TestDefaultCategoryResourceImportID(t *testing.T) {
+	r := &DefaultCategoryResource{}
+	s := resourceSchema(t, r)
+
+	resp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "movies"}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error importing a category other than the default one")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// addClientError appends an error diagnostic for a failed client call.
+// Authentication and access failures get dedicated summaries so users know to
+// check their API key and SABnzbd's API settings rather than debugging the
+// request itself. Changes withheld by validate_only list the request instead.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	var authErr *client.AuthError
+	if errors.As(err, &authErr) {
+		diags.AddError("SABnzbd Authentication Error", fmt.Sprintf("Unable to %s: %s", action, err))
+		return
+	}
+
+	var accessErr *client.AccessError
+	if errors.As(err, &accessErr) {
+		diags.AddError("SABnzbd API Access Denied", fmt.Sprintf("Unable to %s: %s", action, err))
+		return
+	}
+
+	var validateOnlyErr *client.ValidateOnlyError
+	if errors.As(err, &validateOnlyErr) {
+		diags.AddError("SABnzbd Change Not Applied",
+			fmt.Sprintf("Did not %s because the provider sets validate_only. The %s request would have sent: %s",
+				action, validateOnlyErr.Mode, validateOnlyErr.Params.Encode()))
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+}
+
+// adoptStoredValue reconciles a planned attribute with the value SABnzbd
+// stored for it after a write. An unknown planned value takes the stored one.
+// A known value is kept, since Terraform rejects an apply result that differs
+// from the plan, and a stored value that differs from it is reported as a
+// warning so the configuration can be changed to match.
+func adoptStoredValue[T attr.Value](diags *diag.Diagnostics, attribute path.Path, planned *T, stored T) {
+	if (*planned).IsUnknown() {
+		*planned = stored
+		return
+	}
+
+	if !(*planned).Equal(stored) {
+		diags.AddAttributeWarning(
+			attribute,
+			"Value Changed by SABnzbd",
+			fmt.Sprintf("SABnzbd stored %s instead of the configured %s. The next plan will show the difference "+
+				"as a change; set the attribute to the stored value to avoid it.", stored, *planned),
+		)
+	}
+}
+
+// scriptNotFoundWarning warns that script, set at attribute, is not among the
+// scripts SABnzbd lists.
+func scriptNotFoundWarning(attribute path.Path, script string, scripts []string) diag.Diagnostic {
+	return diag.NewAttributeWarningDiagnostic(
+		attribute,
+		"Script Not Found",
+		fmt.Sprintf("The script %q is not in SABnzbd's scripts folder. SABnzbd will accept the setting, "+
+			"but the script will not run until it is added. %s", script, availableScripts(scripts)),
+	)
+}
+
+// scriptNotFoundError is scriptNotFoundWarning for resources that require the
+// script to exist.
+func scriptNotFoundError(attribute path.Path, script string, scripts []string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		attribute,
+		"Script Not Found",
+		fmt.Sprintf("The script %q is not in SABnzbd's scripts folder. Add it to the scripts folder first. %s",
+			script, availableScripts(scripts)),
+	)
+}
+
+// availableScripts lists scripts for a diagnostic detail.
+func availableScripts(scripts []string) string {
+	if len(scripts) == 0 {
+		return "No scripts are available."
+	}
+
+	return fmt.Sprintf("Available scripts: %s.", strings.Join(scripts, ", "))
+}
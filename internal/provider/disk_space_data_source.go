@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DiskSpaceDataSource{}
+
+func NewDiskSpaceDataSource() datasource.DataSource {
+	return &DiskSpaceDataSource{}
+}
+
+// DiskSpaceDataSource defines the data source implementation.
+type DiskSpaceDataSource struct {
+	client *client.Client
+}
+
+// DiskSpaceDataSourceModel describes the data source data model.
+type DiskSpaceDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Diskspace1      types.String `tfsdk:"diskspace1"`
+	Diskspace2      types.String `tfsdk:"diskspace2"`
+	Diskspace1Bytes types.Int64  `tfsdk:"diskspace1_bytes"`
+	Diskspace2Bytes types.Int64  `tfsdk:"diskspace2_bytes"`
+}
+
+func (d *DiskSpaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disk_space"
+}
+
+func (d *DiskSpaceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the free disk space reported by SABnzbd for its download and complete folders, " +
+			"both as reported and parsed into bytes for numeric comparisons.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"diskspace1": schema.StringAttribute{
+				MarkdownDescription: "Free space on the temporary download folder, as reported by SABnzbd.",
+				Computed:            true,
+			},
+			"diskspace2": schema.StringAttribute{
+				MarkdownDescription: "Free space on the completed download folder, as reported by SABnzbd.",
+				Computed:            true,
+			},
+			"diskspace1_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Free space on the temporary download folder in bytes.",
+				Computed:            true,
+			},
+			"diskspace2_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Free space on the completed download folder in bytes.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DiskSpaceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *DiskSpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DiskSpaceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := d.client.GetStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read status", err)
+		return
+	}
+
+	diskspace1, err := client.ParseDiskspace(status.Diskspace1)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse diskspace1, got error: %s", err))
+		return
+	}
+
+	diskspace2, err := client.ParseDiskspace(status.Diskspace2)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse diskspace2, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-disk-space")
+	data.Diskspace1 = types.StringValue(status.Diskspace1)
+	data.Diskspace2 = types.StringValue(status.Diskspace2)
+	data.Diskspace1Bytes = types.Int64Value(diskspace1)
+	data.Diskspace2Bytes = types.Int64Value(diskspace2)
+
+	tflog.Trace(ctx, "read disk space data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
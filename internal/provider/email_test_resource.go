@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EmailTestResource{}
+
+// emailTestID is the ID of the email test resource.
+const emailTestID = "email-test"
+
+func NewEmailTestResource() resource.Resource {
+	return &EmailTestResource{}
+}
+
+// EmailTestResource defines the resource implementation.
+type EmailTestResource struct {
+	client *client.Client
+}
+
+// EmailTestResourceModel describes the resource data model.
+type EmailTestResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	FailOnError types.Bool   `tfsdk:"fail_on_error"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Sent        types.Bool   `tfsdk:"sent"`
+	Message     types.String `tfsdk:"message"`
+}
+
+func (r *EmailTestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_test"
+}
+
+func (r *EmailTestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Has SABnzbd send a test email with its current email notification settings, to check them " +
+			"before relying on notifications. The email is sent when the resource is created and again whenever " +
+			"`triggers` change. A failed send is reported as a warning, or as an error with `fail_on_error`. " +
+			"Destroying the resource does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The email test identifier. Always `email-test`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				MarkdownDescription: "Fail the apply when SABnzbd cannot send the email, instead of warning. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause another test email to be sent when they change, " +
+					"such as the email settings.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"sent": schema.BoolAttribute{
+				MarkdownDescription: "Whether SABnzbd sent the test email.",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The message SABnzbd reported for the test, e.g. why the email could not be sent. " +
+					"May be empty when it gives no reason.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *EmailTestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *EmailTestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EmailTestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sent, message, err := r.client.TestEmail(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "send test email", err)
+		return
+	}
+
+	if !sent {
+		resp.Diagnostics.Append(emailTestFailure(data.FailOnError.ValueBool(), message))
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.ID = types.StringValue(emailTestID)
+	data.Sent = types.BoolValue(sent)
+	data.Message = types.StringValue(message)
+	tflog.Trace(ctx, "sent test email", map[string]interface{}{"sent": sent})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EmailTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data EmailTestResourceModel
+
+	// Sending the email leaves nothing behind to refresh; keep the state as
+	// created.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EmailTestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EmailTestResourceModel
+
+	// All configurable attributes force replacement.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EmailTestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// There is nothing to undo in SABnzbd.
+	tflog.Trace(ctx, "deleted email test resource")
+}
+
+// emailTestFailure reports a test email SABnzbd could not send, as an error
+// when failOnError is set and as a warning otherwise.
+func emailTestFailure(failOnError bool, message string) diag.Diagnostic {
+	detail := "SABnzbd could not send the test email. Check the email server, account and recipient settings."
+	if message != "" {
+		detail = fmt.Sprintf("SABnzbd could not send the test email: %s.", message)
+	}
+
+	if failOnError {
+		return diag.NewErrorDiagnostic("Test Email Not Sent", detail)
+	}
+
+	return diag.NewWarningDiagnostic("Test Email Not Sent", detail)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailTestResourceCreate(t *testing.T) {
+	cases := map[string]struct {
+		emailError   string
+		failOnError  bool
+		wantError    bool
+		wantWarnings int
+	}{
+		"sent":              {},
+		"failed":            {emailError: "Failed to connect to mail server", wantWarnings: 1},
+		"failed and strict": {emailError: "Failed to connect to mail server", failOnError: true, wantError: true},
+	}
+
+	for name, tc := range cases {
+		ctx := context.Background()
+		f, c := newFakeSabnzbd(t)
+		f.emailTestError = tc.emailError
+
+		r := &EmailTestResource{client: c}
+		s := resourceSchema(t, r)
+
+		plan := EmailTestResourceModel{
+			ID:          types.StringUnknown(),
+			FailOnError: types.BoolValue(tc.failOnError),
+			Triggers:    types.MapNull(types.StringType),
+			Sent:        types.BoolUnknown(),
+			Message:     types.StringUnknown(),
+		}
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+
+		if createResp.Diagnostics.HasError() != tc.wantError || createResp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: unexpected diagnostics: %v", name, createResp.Diagnostics)
+		}
+		for _, d := range createResp.Diagnostics {
+			if !strings.Contains(d.Detail(), tc.emailError) {
+				t.Errorf("%s: expected SABnzbd's message in %q", name, d.Detail())
+			}
+		}
+		if tc.wantError {
+			continue
+		}
+
+		var created EmailTestResourceModel
+		createResp.State.Get(ctx, &created)
+		if created.ID.ValueString() != emailTestID || created.Sent.ValueBool() != (tc.emailError == "") {
+			t.Errorf("%s: unexpected state: %+v", name, created)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ExternalAccessResource{}
+var _ resource.ResourceWithImportState = &ExternalAccessResource{}
+var _ resource.ResourceWithModifyPlan = &ExternalAccessResource{}
+var _ resource.ResourceWithValidateConfig = &ExternalAccessResource{}
+
+// externalAccessID is the ID of the external access singleton.
+const externalAccessID = "external_access"
+
+// hostnamePattern matches a DNS hostname made of letters, digits and inner
+// hyphens.
+var hostnamePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+func NewExternalAccessResource() resource.Resource {
+	return &ExternalAccessResource{}
+}
+
+// ExternalAccessResource defines the resource implementation.
+type ExternalAccessResource struct {
+	client *client.Client
+}
+
+// ExternalAccessResourceModel describes the resource data model.
+type ExternalAccessResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	HostWhitelist types.List   `tfsdk:"host_whitelist"`
+	LocalRanges   types.List   `tfsdk:"local_ranges"`
+	InetExposure  types.String `tfsdk:"inet_exposure"`
+}
+
+func (r *ExternalAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_access"
+}
+
+func (r *ExternalAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages which clients may reach SABnzbd: the hostnames it answers to, the networks it " +
+			"treats as local and what clients outside them may do. This is a singleton resource; only one should " +
+			"exist per SABnzbd instance.\n\n" +
+			"~> **Warning:** The provider is one of these clients. A change that stops SABnzbd from accepting the " +
+			"provider's `url` or address locks it out, and only a change made outside Terraform can undo it. " +
+			"The plan warns when a change might do so.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The external access configuration identifier. Always `external_access`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_whitelist": schema.ListAttribute{
+				MarkdownDescription: "Hostnames SABnzbd answers to, e.g. `sabnzbd.example.com`. Requests made to an IP " +
+					"address or `localhost` are always answered. Empty answers every hostname. Defaults to empty.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, nil)),
+			},
+			"local_ranges": schema.ListAttribute{
+				MarkdownDescription: "Networks SABnzbd treats as local, as CIDR blocks or address prefixes, e.g. " +
+					"`192.168.1.0/24` or `10.0.`. Empty treats private networks as local. Defaults to empty.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, nil)),
+			},
+			"inet_exposure": schema.StringAttribute{
+				MarkdownDescription: "What clients outside `local_ranges` may do. Values: `" +
+					strings.Join(client.InetExposureLevels, "`, `") + "`. Defaults to `none`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.InetExposureLevels[client.InetExposureNone]),
+			},
+		},
+	}
+}
+
+func (r *ExternalAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ExternalAccessResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exposure := data.InetExposure
+	if !exposure.IsNull() && !exposure.IsUnknown() && !slices.Contains(client.InetExposureLevels, exposure.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("inet_exposure"),
+			"Invalid External Access Level",
+			fmt.Sprintf("%q is not a known access level. Valid levels are: %s.",
+				exposure.ValueString(), strings.Join(client.InetExposureLevels, ", ")),
+		)
+	}
+
+	if !data.HostWhitelist.IsNull() && !data.HostWhitelist.IsUnknown() {
+		var hosts []types.String
+		resp.Diagnostics.Append(data.HostWhitelist.ElementsAs(ctx, &hosts, false)...)
+		for _, host := range hosts {
+			if host.IsUnknown() {
+				continue
+			}
+			if v := host.ValueString(); !validWhitelistHost(v) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("host_whitelist"),
+					"Invalid Host Whitelist Entry",
+					fmt.Sprintf("Entries must be a hostname or IP address without a scheme or port, got %q.", v),
+				)
+			}
+		}
+	}
+
+	if !data.LocalRanges.IsNull() && !data.LocalRanges.IsUnknown() {
+		var ranges []types.String
+		resp.Diagnostics.Append(data.LocalRanges.ElementsAs(ctx, &ranges, false)...)
+		for _, localRange := range ranges {
+			if localRange.IsUnknown() {
+				continue
+			}
+			// SABnzbd splits the list on commas.
+			if v := localRange.ValueString(); strings.TrimSpace(v) == "" || strings.Contains(v, ",") {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("local_ranges"),
+					"Invalid Local Range",
+					fmt.Sprintf("Ranges must be non-empty and without commas, got %q.", v),
+				)
+			}
+		}
+	}
+}
+
+// validWhitelistHost reports whether host is an IP address or a hostname.
+func validWhitelistHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	return len(host) <= 253 && hostnamePattern.MatchString(host)
+}
+
+func (r *ExternalAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy, and no url to check before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ExternalAccessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.HostWhitelist.IsUnknown() || plan.LocalRanges.IsUnknown() || plan.InetExposure.IsUnknown() {
+		return
+	}
+
+	// Only warn about changes, not about settings already in place.
+	if !req.State.Raw.IsNull() {
+		var state ExternalAccessResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.HostWhitelist.Equal(state.HostWhitelist) && plan.LocalRanges.Equal(state.LocalRanges) &&
+			plan.InetExposure.Equal(state.InetExposure) {
+			return
+		}
+	}
+
+	input, diags := externalAccessInputFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	baseURL := r.client.BaseURL()
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		tflog.Debug(ctx, "unable to parse provider url while planning", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	host := u.Hostname()
+	ip := net.ParseIP(host)
+
+	// SABnzbd rejects requests made to a hostname it was not told about.
+	if ip == nil && !strings.EqualFold(host, "localhost") && len(input.HostWhitelist) > 0 &&
+		!slices.ContainsFunc(input.HostWhitelist, func(h string) bool { return strings.EqualFold(h, host) }) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("host_whitelist"),
+			"Provider Will Be Locked Out",
+			fmt.Sprintf("The provider reaches SABnzbd at %s, but %q is not in host_whitelist. SABnzbd will refuse "+
+				"the provider's requests after this change, and only a change made outside Terraform can undo it. "+
+				"Add %q to host_whitelist or point the provider url at an IP address.", baseURL, host, host),
+		)
+	}
+
+	// Changing settings needs full API access, which SABnzbd only grants to
+	// clients outside local_ranges from the full_api level up.
+	if input.InetExposure < client.InetExposureFullAPI && ip != nil && !localAddress(ip, input.LocalRanges) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("inet_exposure"),
+			"Provider May Be Locked Out",
+			fmt.Sprintf("The provider reaches SABnzbd at %s, which is outside local_ranges. If SABnzbd sees the "+
+				"provider's requests coming from outside local_ranges too, inet_exposure = %q stops the provider "+
+				"from changing settings after this change, and only a change made outside Terraform can undo it.",
+				baseURL, plan.InetExposure.ValueString()),
+		)
+	}
+}
+
+// localAddress reports whether SABnzbd treats ip as local: a loopback
+// address, an address in ranges, or a private address when ranges is empty.
+func localAddress(ip net.IP, ranges []string) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	if len(ranges) == 0 {
+		return ip.IsPrivate() || ip.IsLinkLocalUnicast()
+	}
+
+	for _, localRange := range ranges {
+		if _, network, err := net.ParseCIDR(localRange); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(ip.String(), localRange) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ExternalAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ExternalAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ExternalAccessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := externalAccessInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetExternalAccess(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "create external access configuration", err)
+		return
+	}
+
+	access, err := r.client.GetExternalAccess(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read external access configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setExternalAccessModel(&data, access)...)
+
+	data.ID = types.StringValue(externalAccessID)
+	tflog.Trace(ctx, "created external access resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ExternalAccessResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	access, err := r.client.GetExternalAccess(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read external access configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setExternalAccessModel(&data, access)...)
+
+	data.ID = types.StringValue(externalAccessID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ExternalAccessResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := externalAccessInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetExternalAccess(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "update external access configuration", err)
+		return
+	}
+
+	access, err := r.client.GetExternalAccess(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read external access configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setExternalAccessModel(&data, access)...)
+
+	data.ID = types.StringValue(externalAccessID)
+	tflog.Trace(ctx, "updated external access resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Resetting external access could lock the provider out, so the settings
+	// are kept and the resource is only removed from state.
+	tflog.Trace(ctx, "deleted external access resource from state")
+}
+
+func (r *ExternalAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), externalAccessID)...)
+}
+
+// externalAccessInputFromModel converts the resource model into a client
+// input.
+func externalAccessInputFromModel(ctx context.Context, data *ExternalAccessResourceModel) (*client.ExternalAccessInput, diag.Diagnostics) {
+	var hosts, ranges []string
+	diags := data.HostWhitelist.ElementsAs(ctx, &hosts, false)
+	diags.Append(data.LocalRanges.ElementsAs(ctx, &ranges, false)...)
+
+	return &client.ExternalAccessInput{
+		HostWhitelist: hosts,
+		LocalRanges:   ranges,
+		InetExposure:  slices.Index(client.InetExposureLevels, data.InetExposure.ValueString()),
+	}, diags
+}
+
+// setExternalAccessModel copies the external access settings SABnzbd stores
+// into data. An access level the provider does not know is reported rather
+// than stored.
+func setExternalAccessModel(data *ExternalAccessResourceModel, access *client.ExternalAccess) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hosts, d := types.ListValueFrom(context.Background(), types.StringType, access.HostWhitelist)
+	diags.Append(d...)
+	data.HostWhitelist = hosts
+
+	ranges, d := types.ListValueFrom(context.Background(), types.StringType, access.LocalRanges)
+	diags.Append(d...)
+	data.LocalRanges = ranges
+
+	exposure := access.InetExposure
+	if exposure < 0 || exposure >= len(client.InetExposureLevels) {
+		diags.AddAttributeError(path.Root("inet_exposure"), "Unknown External Access Level",
+			fmt.Sprintf("SABnzbd reports inet_exposure = %d, which this provider does not support.", exposure))
+		return diags
+	}
+	data.InetExposure = types.StringValue(client.InetExposureLevels[exposure])
+
+	return diags
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testExternalAccessModel returns an external access model with the given
+// access level and host whitelist.
+func testExternalAccessModel(exposure string, hosts ...string) ExternalAccessResourceModel {
+	values := make([]attr.Value, len(hosts))
+	for i, host := range hosts {
+		values[i] = types.StringValue(host)
+	}
+
+	return ExternalAccessResourceModel{
+		ID:            types.StringUnknown(),
+		HostWhitelist: types.ListValueMust(types.StringType, values),
+		LocalRanges:   types.ListValueMust(types.StringType, nil),
+		InetExposure:  types.StringValue(exposure),
+	}
+}
+
+func TestExternalAccessResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ExternalAccessResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testExternalAccessModel("full_api", "sabnzbd.lan", "nas")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	want := map[string]interface{}{
+		"host_whitelist": "sabnzbd.lan,nas",
+		"local_ranges":   "",
+		"inet_exposure":  "3",
+	}
+	for key, value := range want {
+		if f.misc[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, f.misc[key])
+		}
+	}
+
+	var created ExternalAccessResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != externalAccessID || created.InetExposure.ValueString() != "full_api" || len(created.HostWhitelist.Elements()) != 2 {
+		t.Errorf("unexpected state after create: %+v", created)
+	}
+}
+
+func TestExternalAccessResourceValidateConfig(t *testing.T) {
+	r := &ExternalAccessResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		config  ExternalAccessResourceModel
+		wantErr bool
+	}{
+		"hostnames":     {config: testExternalAccessModel("none", "sabnzbd.lan", "nas")},
+		"ip addresses":  {config: testExternalAccessModel("none", "192.168.1.10", "::1")},
+		"unknown level": {config: testExternalAccessModel("everything"), wantErr: true},
+		"scheme":        {config: testExternalAccessModel("none", "http://sabnzbd.lan"), wantErr: true},
+		"port":          {config: testExternalAccessModel("none", "sabnzbd.lan:8080"), wantErr: true},
+		"leading dash":  {config: testExternalAccessModel("none", "-sabnzbd"), wantErr: true},
+		"empty":         {config: testExternalAccessModel("none", ""), wantErr: true},
+	}
+
+	for name, tc := range cases {
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &tc.config)}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestExternalAccessResourceModifyPlanWarnsLockout(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		url          string
+		plan         ExternalAccessResourceModel
+		wantWarnings int
+	}{
+		"loopback":             {"http://127.0.0.1:8080", testExternalAccessModel("none", "sabnzbd.lan"), 0},
+		"whitelisted hostname": {"http://sabnzbd.lan:8080", testExternalAccessModel("none", "SABnzbd.lan"), 0},
+		"missing hostname":     {"http://sabnzbd.lan:8080", testExternalAccessModel("none", "nas"), 1},
+		"empty whitelist":      {"http://sabnzbd.lan:8080", testExternalAccessModel("none"), 0},
+		"private address":      {"http://192.168.1.10:8080", testExternalAccessModel("none"), 0},
+		"public address":       {"http://203.0.113.10:8080", testExternalAccessModel("none"), 1},
+		"public full api":      {"http://203.0.113.10:8080", testExternalAccessModel("full_api"), 0},
+	}
+
+	for name, tc := range cases {
+		r := &ExternalAccessResource{client: client.NewClient(tc.url, "test-key")}
+		s := resourceSchema(t, r)
+
+		req := resource.ModifyPlanRequest{
+			Plan:   newPlan(t, s, &tc.plan),
+			State:  newState(t, s, nil),
+			Config: newConfig(t, s, &tc.plan),
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.wantWarnings, resp.Diagnostics)
+		}
+	}
+}
+
+func TestLocalAddress(t *testing.T) {
+	cases := []struct {
+		ip     string
+		ranges []string
+		want   bool
+	}{
+		{"127.0.0.1", []string{"10.0.0.0/8"}, true},
+		{"192.168.1.10", nil, true},
+		{"203.0.113.10", nil, false},
+		{"192.168.1.10", []string{"10.0.0.0/8"}, false},
+		{"10.1.2.3", []string{"10.0.0.0/8"}, true},
+		{"10.1.2.3", []string{"10.1."}, true},
+	}
+
+	for _, tc := range cases {
+		if got := localAddress(net.ParseIP(tc.ip), tc.ranges); got != tc.want {
+			t.Errorf("localAddress(%s, %v): expected %t, got %t", tc.ip, tc.ranges, tc.want, got)
+		}
+	}
+}
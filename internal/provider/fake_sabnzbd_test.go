@@ -0,0 +1,426 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeSabnzbd is an in-memory stand-in for the SABnzbd API, implementing just
+// enough of get_config/set_config/del_config/get_scripts/addurl/queue/history/test_server/test_email/create_backup/rss_now to exercise resources in tests.
+type fakeSabnzbd struct {
+	mu         sync.Mutex
+	misc       map[string]interface{}
+	categories []client.Category
+	servers    []client.Server
+	rss        []client.RSSFeed
+	sorters    []client.Sorter
+	status     map[string]interface{}
+	history    []client.HistorySlot
+	queue      client.Queue
+	scripts    []string
+
+	// historyRequests counts mode=history calls.
+	historyRequests int
+
+	// rssNowRequests counts mode=rss_now calls.
+	rssNowRequests int
+
+	// backupRequests counts config create_backup calls.
+	backupRequests int
+
+	// plainTextAddURL makes addurl answer with a plain-text "ok" and no
+	// nzo_id, like older SABnzbd versions.
+	plainTextAddURL bool
+
+	// addURLSkipsQueue makes addurl accept a URL without queueing it, the
+	// way a job whose fetch fails at once goes straight to history.
+	addURLSkipsQueue bool
+
+	// emailTestError, when set, is the message test_email fails with.
+	emailTestError string
+
+	// serverTestErrors maps host names to the message test_server fails
+	// with; other hosts connect.
+	serverTestErrors map[string]string
+
+	// serverConnectionsLimit, when set, caps the connections stored for a
+	// server the way SABnzbd clamps out-of-range values.
+	serverConnectionsLimit int
+
+	// normalize, when set, rewrites each set_config value before it is
+	// stored, the way SABnzbd normalizes some settings on save.
+	normalize func(section, key, value string) string
+
+	// unknownMisc lists misc keys set_config ignores, the way SABnzbd
+	// drops settings it does not know.
+	unknownMisc map[string]bool
+
+	// userAgent is the User-Agent of the last request.
+	userAgent string
+
+	// url is the address the fake is served at.
+	url string
+}
+
+// newFakeSabnzbd starts a fake SABnzbd server and returns it along with a
+// client pointed at it.
+func newFakeSabnzbd(t *testing.T) (*fakeSabnzbd, *client.Client) {
+	t.Helper()
+
+	f := &fakeSabnzbd{
+		misc: map[string]interface{}{},
+		status: map[string]interface{}{
+			"version":  "4.3.2",
+			"configfn": "/config/sabnzbd.ini",
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(srv.Close)
+	f.url = srv.URL
+
+	return f, client.NewClient(srv.URL, "test-key")
+}
+
+func (f *fakeSabnzbd) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.userAgent = r.UserAgent()
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch q.Get("mode") {
+	case "status":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": f.status})
+	case "version":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": f.status["version"]})
+	case "get_config":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"config": map[string]interface{}{
+				"misc":       f.misc,
+				"categories": f.categories,
+				"servers":    f.servers,
+				"rss":        f.rss,
+				"sorters":    f.sorters,
+			},
+		})
+	case "set_config":
+		if f.normalize != nil {
+			for key, values := range q {
+				switch key {
+				case "mode", "section", "apikey", "output", "name", "keyword":
+					continue
+				}
+				q[key] = []string{f.normalize(q.Get("section"), key, values[0])}
+			}
+		}
+		switch q.Get("section") {
+		case "categories":
+			f.setCategory(q)
+		case "servers":
+			f.setServer(q)
+		case "misc":
+			for key, values := range q {
+				if key == "schedlines" {
+					f.setSchedlines(values)
+					continue
+				}
+				if key != "mode" && key != "section" && key != "apikey" && key != "output" && !f.unknownMisc[key] {
+					f.misc[key] = values[0]
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+	case "config":
+		if q.Get("name") == "create_backup" {
+			f.backupRequests++
+			result := map[string]interface{}{"result": true, "message": "/config/backup/sabnzbd_backup.zip"}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": result})
+			return
+		}
+		if q.Get("name") == "test_email" {
+			if f.emailTestError != "" {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "error": f.emailTestError})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+			return
+		}
+		if q.Get("name") != "test_server" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "error": "Not implemented"})
+			return
+		}
+		result := map[string]interface{}{"result": true, "message": "Connection Successful!"}
+		if message, ok := f.serverTestErrors[q.Get("host")]; ok {
+			result = map[string]interface{}{"result": false, "message": message}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": result})
+	case "rss_now":
+		f.rssNowRequests++
+		_, _ = w.Write([]byte("ok\n"))
+	case "get_scripts":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"scripts": f.scripts})
+	case "addurl":
+		nzoID := "SABnzbd_nzo_" + strconv.Itoa(len(f.queue.Slots)+1)
+		if !f.addURLSkipsQueue {
+			f.queue.Slots = append(f.queue.Slots, client.QueueSlot{NzoID: nzoID, Filename: q.Get("name"), Category: q.Get("cat")})
+			f.queue.NoOfSlots = len(f.queue.Slots)
+		}
+		if f.plainTextAddURL {
+			_, _ = w.Write([]byte("ok\n"))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": true, "nzo_ids": []string{nzoID}})
+	case "queue":
+		if q.Get("name") == "delete" {
+			slots := f.queue.Slots[:0]
+			for _, slot := range f.queue.Slots {
+				if slot.NzoID != q.Get("value") {
+					slots = append(slots, slot)
+				}
+			}
+			f.queue.Slots = slots
+			f.queue.NoOfSlots = len(slots)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"queue": f.queue})
+	case "history":
+		f.historyRequests++
+		start, _ := strconv.Atoi(q.Get("start"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		start = min(start, len(f.history))
+		end := min(start+limit, len(f.history))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"history": map[string]interface{}{
+				"noofslots": len(f.history),
+				"slots":     f.history[start:end],
+			},
+		})
+	case "del_config":
+		switch q.Get("section") {
+		case "categories":
+			for i, cat := range f.categories {
+				if cat.Name == q.Get("keyword") {
+					f.categories = append(f.categories[:i], f.categories[i+1:]...)
+					break
+				}
+			}
+		case "servers":
+			for i, server := range f.servers {
+				if server.Name == q.Get("keyword") {
+					f.servers = append(f.servers[:i], f.servers[i+1:]...)
+					break
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": true})
+	default:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": false, "error": "Not implemented"})
+	}
+}
+
+// setCategory upserts a category. Like SABnzbd, a new category without an
+// explicit order gets order 0.
+func (f *fakeSabnzbd) setCategory(q map[string][]string) {
+	get := func(key string) string {
+		if v, ok := q[key]; ok && len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	// SABnzbd stores category names in lowercase.
+	name := strings.ToLower(get("name"))
+	index := -1
+	for i, cat := range f.categories {
+		if cat.Name == name {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		f.categories = append(f.categories, client.Category{Name: name})
+		index = len(f.categories) - 1
+	}
+
+	cat := &f.categories[index]
+	cat.Dir = get("dir")
+	cat.Script = get("script")
+	cat.PP = get("pp")
+	if v, err := strconv.Atoi(get("priority")); err == nil {
+		cat.Priority = v
+	}
+	if v, err := strconv.Atoi(get("order")); err == nil {
+		cat.Order = v
+	}
+	for key, values := range q {
+		switch key {
+		case "mode", "section", "apikey", "output":
+			continue
+		}
+		if !client.IsCategoryField(key) {
+			if cat.Extra == nil {
+				cat.Extra = map[string]string{}
+			}
+			cat.Extra[key] = values[0]
+		}
+	}
+}
+
+// setSchedlines stores the schedlines sent as repeated set_config parameters,
+// skipping empty ones the way SABnzbd's scheduler does.
+func (f *fakeSabnzbd) setSchedlines(values []string) {
+	lines := []interface{}{}
+	for _, line := range values {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	f.misc["schedlines"] = lines
+}
+
+// setServer upserts a server from set_config parameters.
+func (f *fakeSabnzbd) setServer(q map[string][]string) {
+	get := func(key string) string {
+		if v, ok := q[key]; ok && len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	atoi := func(key string) int {
+		v, _ := strconv.Atoi(get(key))
+		return v
+	}
+	flag := func(key string) client.IntBool {
+		return client.IntBool(atoi(key))
+	}
+
+	server := client.Server{
+		Name:        get("name"),
+		Host:        get("host"),
+		Port:        atoi("port"),
+		Username:    get("username"),
+		Password:    get("password"),
+		Connections: atoi("connections"),
+		SSL:         flag("ssl"),
+		SSLVerify:   atoi("ssl_verify"),
+		SSLCiphers:  get("ssl_ciphers"),
+		Enable:      flag("enable"),
+		Optional:    flag("optional"),
+		Retention:   atoi("retention"),
+		Timeout:     atoi("timeout"),
+		Priority:    atoi("priority"),
+		Required:    flag("required"),
+		Notes:       get("notes"),
+		SendGroup:   flag("send_group"),
+		ExpireDate:  get("expire_date"),
+		Quota:       get("quota"),
+	}
+	if f.serverConnectionsLimit > 0 {
+		server.Connections = min(server.Connections, f.serverConnectionsLimit)
+	}
+
+	for i := range f.servers {
+		if f.servers[i].Name == server.Name {
+			f.servers[i] = server
+			return
+		}
+	}
+	f.servers = append(f.servers, server)
+}
+
+// resourceSchema returns the schema of r.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// newPlan builds a plan for s populated from model.
+func newPlan(t *testing.T, s schema.Schema, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if diags := plan.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("unexpected plan diagnostics: %v", diags)
+	}
+
+	return plan
+}
+
+// newConfig builds a resource config for s populated from model.
+func newConfig(t *testing.T, s schema.Schema, model interface{}) tfsdk.Config {
+	t.Helper()
+
+	// tfsdk.Config has no setter, so build the value through a State.
+	state := newState(t, s, model)
+
+	return tfsdk.Config{Schema: s, Raw: state.Raw}
+}
+
+// newState builds a state for s populated from model, or an empty state if
+// model is nil.
+func newState(t *testing.T, s schema.Schema, model interface{}) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if model != nil {
+		if diags := state.Set(context.Background(), model); diags.HasError() {
+			t.Fatalf("unexpected state diagnostics: %v", diags)
+		}
+	}
+
+	return state
+}
+
+// dataSourceSchema returns the schema of d.
+func dataSourceSchema(t *testing.T, d datasource.DataSource) dschema.Schema {
+	t.Helper()
+
+	var resp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// newDataSourceConfig builds a data source config for s populated from model.
+func newDataSourceConfig(t *testing.T, s dschema.Schema, model interface{}) tfsdk.Config {
+	t.Helper()
+
+	// tfsdk.Config has no setter, so build the value through a State.
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("unexpected config diagnostics: %v", diags)
+	}
+
+	return tfsdk.Config{Schema: s, Raw: state.Raw}
+}
@@ -6,8 +6,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +26,11 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &FoldersResource{}
 var _ resource.ResourceWithImportState = &FoldersResource{}
+var _ resource.ResourceWithValidateConfig = &FoldersResource{}
+var _ resource.ResourceWithModifyPlan = &FoldersResource{}
+
+// foldersID is the ID of the folders singleton.
+const foldersID = "folders"
 
 func NewFoldersResource() resource.Resource {
 	return &FoldersResource{}
@@ -51,6 +59,50 @@ type FoldersResourceModel struct {
 	AdminDir            types.String `tfsdk:"admin_dir"`
 	BackupDir           types.String `tfsdk:"backup_dir"`
 	LogDir              types.String `tfsdk:"log_dir"`
+	DownloadDirAbsolute types.String `tfsdk:"download_dir_absolute"`
+	CompleteDirAbsolute types.String `tfsdk:"complete_dir_absolute"`
+	WatchedDirAbsolute  types.String `tfsdk:"watched_dir_absolute"`
+	ScriptsDirAbsolute  types.String `tfsdk:"scripts_dir_absolute"`
+	Effective           types.Object `tfsdk:"effective"`
+	Timeouts            types.Object `tfsdk:"timeouts"`
+}
+
+// FoldersEffectiveModel describes the folder settings as stored by SABnzbd.
+type FoldersEffectiveModel struct {
+	DownloadDir         types.String `tfsdk:"download_dir"`
+	DownloadFree        types.String `tfsdk:"download_free"`
+	CompleteDir         types.String `tfsdk:"complete_dir"`
+	CompleteFree        types.String `tfsdk:"complete_free"`
+	AutoResume          types.Bool   `tfsdk:"auto_resume"`
+	Permissions         types.String `tfsdk:"permissions"`
+	WatchedDir          types.String `tfsdk:"watched_dir"`
+	WatchedDirScanSpeed types.Int64  `tfsdk:"watched_dir_scan_speed"`
+	ScriptsDir          types.String `tfsdk:"scripts_dir"`
+	EmailTemplatesDir   types.String `tfsdk:"email_templates_dir"`
+	PasswordFile        types.String `tfsdk:"password_file"`
+	NzbBackupDir        types.String `tfsdk:"nzb_backup_dir"`
+	AdminDir            types.String `tfsdk:"admin_dir"`
+	BackupDir           types.String `tfsdk:"backup_dir"`
+	LogDir              types.String `tfsdk:"log_dir"`
+}
+
+// foldersEffectiveAttrTypes are the attribute types of the effective object.
+var foldersEffectiveAttrTypes = map[string]attr.Type{
+	"download_dir":           types.StringType,
+	"download_free":          types.StringType,
+	"complete_dir":           types.StringType,
+	"complete_free":          types.StringType,
+	"auto_resume":            types.BoolType,
+	"permissions":            types.StringType,
+	"watched_dir":            types.StringType,
+	"watched_dir_scan_speed": types.Int64Type,
+	"scripts_dir":            types.StringType,
+	"email_templates_dir":    types.StringType,
+	"password_file":          types.StringType,
+	"nzb_backup_dir":         types.StringType,
+	"admin_dir":              types.StringType,
+	"backup_dir":             types.StringType,
+	"log_dir":                types.StringType,
 }
 
 func (r *FoldersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -113,7 +165,8 @@ func (r *FoldersResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"watched_dir": schema.StringAttribute{
 				MarkdownDescription: "Folder periodically scanned for new NZB files. " +
-					"Supports category sub-folders and filename prefixes for automatic categorization.",
+					"Supports category sub-folders and filename prefixes for automatic categorization. " +
+					"Keep it outside `download_dir` and `complete_dir`, or NZB files in downloads can be queued again.",
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString(""),
@@ -122,7 +175,7 @@ func (r *FoldersResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Seconds between filesystem scans of watched folder. Set to 0 to disable automatic scans.",
 				Optional:            true,
 				Computed:            true,
-				Default:             int64default.StaticInt64(5),
+				Default:             int64default.StaticInt64(int64(client.DefaultFolders.WatchedDirScanSpeed)),
 			},
 			"scripts_dir": schema.StringAttribute{
 				MarkdownDescription: "Folder where user scripts (post-processing and pre-queue) are stored.",
@@ -152,39 +205,223 @@ func (r *FoldersResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Folder for SABnzbd administrative files.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("admin"),
+				Default:             stringdefault.StaticString(client.DefaultFolders.AdminDir),
 			},
 			"backup_dir": schema.StringAttribute{
 				MarkdownDescription: "Folder for SABnzbd configuration backups.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("backup"),
+				Default:             stringdefault.StaticString(client.DefaultFolders.BackupDir),
 			},
 			"log_dir": schema.StringAttribute{
 				MarkdownDescription: "Folder for SABnzbd log files.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("logs"),
+				Default:             stringdefault.StaticString(client.DefaultFolders.LogDir),
 			},
+			"download_dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute path of `download_dir`, resolved against SABnzbd's base folder when relative.",
+				Computed:            true,
+			},
+			"complete_dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute path of `complete_dir`, resolved against SABnzbd's base folder when relative.",
+				Computed:            true,
+			},
+			"watched_dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute path of `watched_dir`, resolved against SABnzbd's base folder when relative.",
+				Computed:            true,
+			},
+			"scripts_dir_absolute": schema.StringAttribute{
+				MarkdownDescription: "The effective absolute path of `scripts_dir`, resolved against SABnzbd's base folder when relative.",
+				Computed:            true,
+			},
+			"effective": schema.SingleNestedAttribute{
+				MarkdownDescription: "The folder settings as SABnzbd stored them after the last apply or refresh. " +
+					"Empty values are not sent to SABnzbd, and SABnzbd may normalize paths, so these can differ from the configured values.",
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"download_dir": schema.StringAttribute{
+						MarkdownDescription: "Temporary download folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"download_free": schema.StringAttribute{
+						MarkdownDescription: "Minimum free space for the temporary download folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"complete_dir": schema.StringAttribute{
+						MarkdownDescription: "Completed download folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"complete_free": schema.StringAttribute{
+						MarkdownDescription: "Minimum free space for the completed download folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"auto_resume": schema.BoolAttribute{
+						MarkdownDescription: "Automatic resume setting as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"permissions": schema.StringAttribute{
+						MarkdownDescription: "Permissions for completed downloads as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"watched_dir": schema.StringAttribute{
+						MarkdownDescription: "Watched folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"watched_dir_scan_speed": schema.Int64Attribute{
+						MarkdownDescription: "Seconds between scans of the watched folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"scripts_dir": schema.StringAttribute{
+						MarkdownDescription: "Scripts folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"email_templates_dir": schema.StringAttribute{
+						MarkdownDescription: "Email templates folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"password_file": schema.StringAttribute{
+						MarkdownDescription: "Password file as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"nzb_backup_dir": schema.StringAttribute{
+						MarkdownDescription: "NZB backup folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"admin_dir": schema.StringAttribute{
+						MarkdownDescription: "Administrative files folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"backup_dir": schema.StringAttribute{
+						MarkdownDescription: "Configuration backups folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+					"log_dir": schema.StringAttribute{
+						MarkdownDescription: "Log files folder as stored by SABnzbd.",
+						Computed:            true,
+					},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
 		},
 	}
 }
 
+func (r *FoldersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FoldersResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DownloadDir.IsUnknown() || data.CompleteDir.IsUnknown() || data.WatchedDir.IsUnknown() {
+		return
+	}
+
+	// Some setups share the folder on purpose, so this only warns.
+	if sameFolder(data.DownloadDir.ValueString(), data.CompleteDir.ValueString()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("complete_dir"),
+			"Download and Complete Folders Are the Same",
+			fmt.Sprintf("download_dir and complete_dir are both %q. SABnzbd then mixes incomplete downloads with "+
+				"finished ones, which can confuse other applications watching the folder and post-processing "+
+				"cleanup. Use separate folders unless this is intended.", data.CompleteDir.ValueString()),
+		)
+	}
+
+	// SABnzbd treats the folders in the watched folder as category
+	// sub-folders, so overlapping it with a download folder can make
+	// SABnzbd pick up NZB files from its own downloads.
+	watchedDir := data.WatchedDir.ValueString()
+	for _, folder := range []struct{ attribute, dir string }{
+		{"download_dir", data.DownloadDir.ValueString()},
+		{"complete_dir", data.CompleteDir.ValueString()},
+	} {
+		if foldersOverlap(watchedDir, folder.dir) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("watched_dir"),
+				"Watched Folder Overlaps Download Folders",
+				fmt.Sprintf("watched_dir %q and %s %q are the same folder or one is inside the other. SABnzbd scans "+
+					"the watched folder and its category sub-folders for NZB files, so NZB files in downloads can be "+
+					"added to the queue again. Use a watched folder outside the download and complete folders.",
+					watchedDir, folder.attribute, folder.dir),
+			)
+		}
+	}
+}
+
+// sameFolder reports whether the download and complete folders are set to the
+// same non-empty path, ignoring a trailing separator.
+func sameFolder(downloadDir, completeDir string) bool {
+	if downloadDir == "" || completeDir == "" {
+		return false
+	}
+
+	return strings.TrimRight(downloadDir, `/\`) == strings.TrimRight(completeDir, `/\`)
+}
+
+// foldersOverlap reports whether two non-empty folders are the same or one is
+// inside the other, ignoring trailing separators. A relative folder never
+// matches an absolute one, since the base folder is not known when validating.
+func foldersOverlap(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	a = strings.TrimRight(strings.ReplaceAll(a, `\`, "/"), "/") + "/"
+	b = strings.TrimRight(strings.ReplaceAll(b, `\`, "/"), "/") + "/"
+
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+func (r *FoldersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var watchedDir types.String
+	var scanSpeed types.Int64
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("watched_dir"), &watchedDir)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("watched_dir_scan_speed"), &scanSpeed)...)
+	if resp.Diagnostics.HasError() || watchedDir.IsUnknown() || scanSpeed.IsUnknown() {
+		return
+	}
+
+	if watchedDirUnscanned(watchedDir.ValueString(), scanSpeed.ValueInt64()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("watched_dir_scan_speed"),
+			"Watched Folder Is Never Scanned",
+			fmt.Sprintf("watched_dir is set to %q, but watched_dir_scan_speed is 0, which disables scanning. "+
+				"NZB files dropped in the folder will not be picked up. Set watched_dir_scan_speed to the seconds "+
+				"between scans, e.g. 5.", watchedDir.ValueString()),
+		)
+	}
+}
+
+// watchedDirUnscanned reports whether a watched folder is set while scanning
+// it is disabled.
+func watchedDirUnscanned(watchedDir string, scanSpeed int64) bool {
+	return watchedDir != "" && scanSpeed == 0
+}
+
 func (r *FoldersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.client
 }
 
 func (r *FoldersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -195,6 +432,13 @@ func (r *FoldersResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutCreate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	input := &client.FoldersInput{
 		DownloadDir:         data.DownloadDir.ValueString(),
 		DownloadFree:        data.DownloadFree.ValueString(),
@@ -213,12 +457,32 @@ func (r *FoldersResource) Create(ctx context.Context, req resource.CreateRequest
 		LogDir:              data.LogDir.ValueString(),
 	}
 
+	// Creating the singleton overwrites whatever is configured today, so
+	// point users at import when the instance has already been set up.
+	current, err := r.client.GetFolders(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read folders configuration", err)
+		return
+	}
+	if overwritten := foldersOverwrites(current, input); len(overwritten) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Overwriting Existing Folder Configuration",
+			fmt.Sprintf("SABnzbd already has non-default values for %s, which are being replaced. "+
+				"To manage an existing configuration without overwriting it, import it instead: "+
+				"terraform import <resource address> folders", strings.Join(overwritten, ", ")),
+		)
+	}
+
 	if err := r.client.SetFolders(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create folders configuration, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create folders configuration", err)
 		return
 	}
 
-	data.ID = types.StringValue("folders")
+	// The folders are written either way, so save the state even when
+	// reading them back fails.
+	resp.Diagnostics.Append(r.readBackFolders(ctx, &data)...)
+
+	data.ID = types.StringValue(foldersID)
 	tflog.Trace(ctx, "created folders resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -232,28 +496,40 @@ func (r *FoldersResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutRead)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	folders, err := r.client.GetFolders(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read folders configuration, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read folders configuration", err)
 		return
 	}
 
-	data.ID = types.StringValue("folders")
-	data.DownloadDir = types.StringValue(folders.DownloadDir)
-	data.DownloadFree = types.StringValue(folders.DownloadFree)
-	data.CompleteDir = types.StringValue(folders.CompleteDir)
-	data.CompleteFree = types.StringValue(folders.CompleteFree)
-	data.AutoResume = types.BoolValue(folders.AutoResume == 1)
-	data.Permissions = types.StringValue(folders.Permissions)
-	data.WatchedDir = types.StringValue(folders.WatchedDir)
-	data.WatchedDirScanSpeed = types.Int64Value(int64(folders.WatchedDirScanSpeed))
-	data.ScriptsDir = types.StringValue(folders.ScriptsDir)
-	data.EmailTemplatesDir = types.StringValue(folders.EmailTemplatesDir)
-	data.PasswordFile = types.StringValue(folders.PasswordFile)
-	data.NzbBackupDir = types.StringValue(folders.NzbBackupDir)
-	data.AdminDir = types.StringValue(folders.AdminDir)
-	data.BackupDir = types.StringValue(folders.BackupDir)
-	data.LogDir = types.StringValue(folders.LogDir)
+	// A bad import may have stored another ID, which UseStateForUnknown would
+	// otherwise carry forward.
+	if id := data.ID.ValueString(); id != foldersID {
+		resp.Diagnostics.Append(foldersIDWarning(id))
+	}
+	data.ID = types.StringValue(foldersID)
+	setFoldersModel(&data, folders)
+
+	effective, diags := effectiveFoldersValue(ctx, folders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Effective = effective
+
+	baseDir, err := r.client.GetBaseDir(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read base folder", err)
+		return
+	}
+	setAbsoluteFolderPaths(&data, folders, baseDir)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -266,6 +542,13 @@ func (r *FoldersResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutUpdate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	input := &client.FoldersInput{
 		DownloadDir:         data.DownloadDir.ValueString(),
 		DownloadFree:        data.DownloadFree.ValueString(),
@@ -285,11 +568,15 @@ func (r *FoldersResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if err := r.client.SetFolders(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update folders configuration, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update folders configuration", err)
 		return
 	}
 
-	data.ID = types.StringValue("folders")
+	// The folders are written either way, so save the state even when
+	// reading them back fails.
+	resp.Diagnostics.Append(r.readBackFolders(ctx, &data)...)
+
+	data.ID = types.StringValue(foldersID)
 	tflog.Trace(ctx, "updated folders resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -302,5 +589,177 @@ func (r *FoldersResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *FoldersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != foldersID {
+		resp.Diagnostics.Append(foldersIDWarning(req.ID))
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), foldersID)...)
+}
+
+// foldersIDWarning warns that the folders singleton had an ID other than
+// foldersID, which is replaced.
+func foldersIDWarning(id string) diag.Diagnostic {
+	return diag.NewWarningDiagnostic(
+		"Unexpected Folders ID",
+		fmt.Sprintf("The folders configuration is a singleton with the ID %q, but the ID %q was found. "+
+			"The ID has been corrected to %q.", foldersID, id, foldersID),
+	)
+}
+
+// setFoldersModel copies the folder settings SABnzbd stores into data.
+func setFoldersModel(data *FoldersResourceModel, folders *client.Folders) {
+	data.DownloadDir = types.StringValue(folders.DownloadDir)
+	data.DownloadFree = types.StringValue(folders.DownloadFree)
+	data.CompleteDir = types.StringValue(folders.CompleteDir)
+	data.CompleteFree = types.StringValue(folders.CompleteFree)
+	data.AutoResume = types.BoolValue(folders.AutoResume == 1)
+	data.Permissions = types.StringValue(folders.Permissions)
+	data.WatchedDir = types.StringValue(folders.WatchedDir)
+	data.WatchedDirScanSpeed = types.Int64Value(int64(folders.WatchedDirScanSpeed))
+	data.ScriptsDir = types.StringValue(folders.ScriptsDir)
+	data.EmailTemplatesDir = types.StringValue(folders.EmailTemplatesDir)
+	data.PasswordFile = types.StringValue(folders.PasswordFile)
+	data.NzbBackupDir = types.StringValue(folders.NzbBackupDir)
+	data.AdminDir = types.StringValue(folders.AdminDir)
+	data.BackupDir = types.StringValue(folders.BackupDir)
+	data.LogDir = types.StringValue(folders.LogDir)
+}
+
+// readBackFolders reconciles data with the folder settings SABnzbd stored
+// after a write and fills in the computed attributes. Empty values are not
+// sent, so SABnzbd keeps its own for those; they stay empty here and effective
+// reports what is in use. Computed attributes that cannot be read are set to
+// null, since state cannot hold unknown values.
+func (r *FoldersResource) readBackFolders(ctx context.Context, data *FoldersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defer nullUnknownFolderPaths(data)
+
+	stored, err := r.client.GetFolders(ctx)
+	if err != nil {
+		addClientError(&diags, "read folders configuration", err)
+		return diags
+	}
+
+	sent := []struct {
+		attribute string
+		planned   *types.String
+		stored    string
+	}{
+		{"download_dir", &data.DownloadDir, stored.DownloadDir},
+		{"download_free", &data.DownloadFree, stored.DownloadFree},
+		{"complete_dir", &data.CompleteDir, stored.CompleteDir},
+		{"complete_free", &data.CompleteFree, stored.CompleteFree},
+		{"permissions", &data.Permissions, stored.Permissions},
+		{"watched_dir", &data.WatchedDir, stored.WatchedDir},
+		{"scripts_dir", &data.ScriptsDir, stored.ScriptsDir},
+		{"email_templates_dir", &data.EmailTemplatesDir, stored.EmailTemplatesDir},
+		{"password_file", &data.PasswordFile, stored.PasswordFile},
+		{"nzb_backup_dir", &data.NzbBackupDir, stored.NzbBackupDir},
+		{"admin_dir", &data.AdminDir, stored.AdminDir},
+		{"backup_dir", &data.BackupDir, stored.BackupDir},
+		{"log_dir", &data.LogDir, stored.LogDir},
+	}
+	for _, field := range sent {
+		if field.planned.ValueString() != "" {
+			adoptStoredValue(&diags, path.Root(field.attribute), field.planned, types.StringValue(field.stored))
+		}
+	}
+	adoptStoredValue(&diags, path.Root("auto_resume"), &data.AutoResume, types.BoolValue(stored.AutoResume == 1))
+	adoptStoredValue(&diags, path.Root("watched_dir_scan_speed"), &data.WatchedDirScanSpeed,
+		types.Int64Value(int64(stored.WatchedDirScanSpeed)))
+
+	effective, effectiveDiags := effectiveFoldersValue(ctx, stored)
+	diags.Append(effectiveDiags...)
+	if !effectiveDiags.HasError() {
+		data.Effective = effective
+	}
+
+	baseDir, err := r.client.GetBaseDir(ctx)
+	if err != nil {
+		addClientError(&diags, "read base folder", err)
+		return diags
+	}
+	setAbsoluteFolderPaths(data, stored, baseDir)
+
+	return diags
+}
+
+// nullUnknownFolderPaths sets the computed attributes that are still unknown
+// to null, e.g. after reading them failed.
+func nullUnknownFolderPaths(data *FoldersResourceModel) {
+	if data.Effective.IsUnknown() {
+		data.Effective = types.ObjectNull(foldersEffectiveAttrTypes)
+	}
+	for _, value := range []*types.String{
+		&data.DownloadDirAbsolute, &data.CompleteDirAbsolute, &data.WatchedDirAbsolute, &data.ScriptsDirAbsolute,
+	} {
+		if value.IsUnknown() {
+			*value = types.StringNull()
+		}
+	}
+}
+
+// setAbsoluteFolderPaths populates the computed absolute path attributes by
+// resolving the folders SABnzbd stored against baseDir. The stored folders
+// are used rather than the configured ones, which may be unset.
+func setAbsoluteFolderPaths(data *FoldersResourceModel, folders *client.Folders, baseDir string) {
+	data.DownloadDirAbsolute = types.StringValue(client.ResolvePath(baseDir, folders.DownloadDir))
+	data.CompleteDirAbsolute = types.StringValue(client.ResolvePath(baseDir, folders.CompleteDir))
+	data.WatchedDirAbsolute = types.StringValue(client.ResolvePath(baseDir, folders.WatchedDir))
+	data.ScriptsDirAbsolute = types.StringValue(client.ResolvePath(baseDir, folders.ScriptsDir))
+}
+
+// effectiveFoldersValue converts folders as stored by SABnzbd into the
+// effective object.
+func effectiveFoldersValue(ctx context.Context, folders *client.Folders) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, foldersEffectiveAttrTypes, FoldersEffectiveModel{
+		DownloadDir:         types.StringValue(folders.DownloadDir),
+		DownloadFree:        types.StringValue(folders.DownloadFree),
+		CompleteDir:         types.StringValue(folders.CompleteDir),
+		CompleteFree:        types.StringValue(folders.CompleteFree),
+		AutoResume:          types.BoolValue(folders.AutoResume == 1),
+		Permissions:         types.StringValue(folders.Permissions),
+		WatchedDir:          types.StringValue(folders.WatchedDir),
+		WatchedDirScanSpeed: types.Int64Value(int64(folders.WatchedDirScanSpeed)),
+		ScriptsDir:          types.StringValue(folders.ScriptsDir),
+		EmailTemplatesDir:   types.StringValue(folders.EmailTemplatesDir),
+		PasswordFile:        types.StringValue(folders.PasswordFile),
+		NzbBackupDir:        types.StringValue(folders.NzbBackupDir),
+		AdminDir:            types.StringValue(folders.AdminDir),
+		BackupDir:           types.StringValue(folders.BackupDir),
+		LogDir:              types.StringValue(folders.LogDir),
+	})
+}
+
+// foldersOverwrites returns the folder settings that currently hold
+// non-default values and would be replaced by input. Empty input values are
+// skipped by SetFolders and so never overwrite anything.
+func foldersOverwrites(current *client.Folders, input *client.FoldersInput) []string {
+	defaults := client.DefaultFolders
+	fields := []struct {
+		name, current, def, planned string
+	}{
+		{"download_dir", current.DownloadDir, defaults.DownloadDir, input.DownloadDir},
+		{"download_free", current.DownloadFree, defaults.DownloadFree, input.DownloadFree},
+		{"complete_dir", current.CompleteDir, defaults.CompleteDir, input.CompleteDir},
+		{"complete_free", current.CompleteFree, defaults.CompleteFree, input.CompleteFree},
+		{"permissions", current.Permissions, defaults.Permissions, input.Permissions},
+		{"watched_dir", current.WatchedDir, defaults.WatchedDir, input.WatchedDir},
+		{"scripts_dir", current.ScriptsDir, defaults.ScriptsDir, input.ScriptsDir},
+		{"email_templates_dir", current.EmailTemplatesDir, defaults.EmailTemplatesDir, input.EmailTemplatesDir},
+		{"password_file", current.PasswordFile, defaults.PasswordFile, input.PasswordFile},
+		{"nzb_backup_dir", current.NzbBackupDir, defaults.NzbBackupDir, input.NzbBackupDir},
+		{"admin_dir", current.AdminDir, defaults.AdminDir, input.AdminDir},
+		{"backup_dir", current.BackupDir, defaults.BackupDir, input.BackupDir},
+		{"log_dir", current.LogDir, defaults.LogDir, input.LogDir},
+	}
+
+	var overwritten []string
+	for _, f := range fields {
+		if f.planned != "" && f.current != f.def && f.current != f.planned {
+			overwritten = append(overwritten, f.name)
+		}
+	}
+
+	return overwritten
 }
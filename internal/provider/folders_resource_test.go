@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestFoldersOverwrites(t *testing.T) {
+	current := client.DefaultFolders
+	current.CompleteDir = "/data/complete"
+	current.WatchedDir = "/data/watch"
+	current.ScriptsDir = "/config/scripts"
+
+	input := &client.FoldersInput{
+		DownloadDir: "/data/incomplete", // default today, not an overwrite
+		CompleteDir: "/mnt/complete",    // replaces a configured value
+		WatchedDir:  "/data/watch",      // unchanged
+		ScriptsDir:  "",                 // skipped by SetFolders
+	}
+
+	got := foldersOverwrites(&current, input)
+	want := []string{"complete_dir"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := foldersOverwrites(&client.DefaultFolders, input); len(got) != 0 {
+		t.Errorf("expected no overwrites on a default instance, got %v", got)
+	}
+}
+
+func TestSameFolder(t *testing.T) {
+	cases := []struct {
+		download, complete string
+		want               bool
+	}{
+		{"/data/downloads", "/data/downloads", true},
+		{"/data/downloads/", "/data/downloads", true},
+		{`C:\Downloads\`, `C:\Downloads`, true},
+		{"Downloads", "Downloads", true},
+		{"/data/incomplete", "/data/complete", false},
+		{"", "", false},
+		{"", "/data/complete", false},
+	}
+
+	for _, tc := range cases {
+		if got := sameFolder(tc.download, tc.complete); got != tc.want {
+			t.Errorf("sameFolder(%q, %q): expected %t, got %t", tc.download, tc.complete, tc.want, got)
+		}
+	}
+}
+
+func TestFoldersOverlap(t *testing.T) {
+	cases := []struct {
+		watched, dir string
+		want         bool
+	}{
+		{"/data/complete/nzb", "/data/complete", true},
+		{"/data", "/data/complete/", true},
+		{"/data/watch/", "/data/watch", true},
+		{`C:\Downloads\watch`, `C:\Downloads`, true},
+		{"Downloads/watch", "Downloads", true},
+		{"/data/watch", "/data/watched", false},
+		{"/data/watch", "/data/complete", false},
+		{"watch", "/data/watch", false},
+		{"", "/data/complete", false},
+		{"/data/watch", "", false},
+	}
+
+	for _, tc := range cases {
+		if got := foldersOverlap(tc.watched, tc.dir); got != tc.want {
+			t.Errorf("foldersOverlap(%q, %q): expected %t, got %t", tc.watched, tc.dir, tc.want, got)
+		}
+	}
+}
+
+func TestFoldersResourceModifyPlanWarnsUnscannedWatchedDir(t *testing.T) {
+	ctx := context.Background()
+	r := &FoldersResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		watchedDir   string
+		scanSpeed    int64
+		wantWarnings int
+	}{
+		"scanned":        {"/data/watch", 5, 0},
+		"never scanned":  {"/data/watch", 0, 1},
+		"no folder":      {"", 0, 0},
+		"folder default": {"", 5, 0},
+	}
+
+	for name, tc := range cases {
+		plan := FoldersResourceModel{
+			ID:                  types.StringUnknown(),
+			DownloadDir:         types.StringValue(""),
+			DownloadFree:        types.StringValue(""),
+			CompleteDir:         types.StringValue(""),
+			CompleteFree:        types.StringValue(""),
+			AutoResume:          types.BoolValue(false),
+			Permissions:         types.StringValue(""),
+			WatchedDir:          types.StringValue(tc.watchedDir),
+			WatchedDirScanSpeed: types.Int64Value(tc.scanSpeed),
+			ScriptsDir:          types.StringValue(""),
+			EmailTemplatesDir:   types.StringValue(""),
+			PasswordFile:        types.StringValue(""),
+			NzbBackupDir:        types.StringValue(""),
+			AdminDir:            types.StringValue("admin"),
+			BackupDir:           types.StringValue("backup"),
+			LogDir:              types.StringValue("logs"),
+			DownloadDirAbsolute: types.StringUnknown(),
+			CompleteDirAbsolute: types.StringUnknown(),
+			WatchedDirAbsolute:  types.StringUnknown(),
+			ScriptsDirAbsolute:  types.StringUnknown(),
+			Effective:           types.ObjectUnknown(foldersEffectiveAttrTypes),
+			Timeouts:            types.ObjectNull(timeoutsAttrTypes),
+		}
+		req := resource.ModifyPlanRequest{
+			Plan:  newPlan(t, s, &plan),
+			State: newState(t, s, nil),
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.wantWarnings, resp.Diagnostics)
+		}
+	}
+}
+
+func TestFoldersResourceImport(t *testing.T) {
+	ctx := context.Background()
+	fake, c := newFakeSabnzbd(t)
+	fake.misc = map[string]interface{}{
+		"download_dir":   "Downloads/incomplete",
+		"download_free":  "10G",
+		"complete_dir":   "/data/complete",
+		"complete_free":  "",
+		"auto_resume":    float64(1),
+		"permissions":    "755",
+		"dirscan_dir":    "watch",
+		"dirscan_speed":  float64(10),
+		"script_dir":     "/config/scripts",
+		"email_dir":      "",
+		"password_file":  "",
+		"nzb_backup_dir": "",
+		"admin_dir":      "admin",
+		"backup_dir":     "",
+		"log_dir":        "logs",
+	}
+
+	r := &FoldersResource{client: c}
+	s := resourceSchema(t, r)
+
+	importResp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "folders"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import diagnostics: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var got FoldersResourceModel
+	readResp.State.Get(ctx, &got)
+
+	want := FoldersResourceModel{
+		ID:                  types.StringValue("folders"),
+		DownloadDir:         types.StringValue("Downloads/incomplete"),
+		DownloadFree:        types.StringValue("10G"),
+		CompleteDir:         types.StringValue("/data/complete"),
+		CompleteFree:        types.StringValue(""),
+		AutoResume:          types.BoolValue(true),
+		Permissions:         types.StringValue("755"),
+		WatchedDir:          types.StringValue("watch"),
+		WatchedDirScanSpeed: types.Int64Value(10),
+		ScriptsDir:          types.StringValue("/config/scripts"),
+		EmailTemplatesDir:   types.StringValue(""),
+		PasswordFile:        types.StringValue(""),
+		NzbBackupDir:        types.StringValue(""),
+		AdminDir:            types.StringValue("admin"),
+		BackupDir:           types.StringValue(""),
+		LogDir:              types.StringValue("logs"),
+		DownloadDirAbsolute: types.StringValue("/config/Downloads/incomplete"),
+		CompleteDirAbsolute: types.StringValue("/data/complete"),
+		WatchedDirAbsolute:  types.StringValue("/config/watch"),
+		ScriptsDirAbsolute:  types.StringValue("/config/scripts"),
+		Timeouts:            types.ObjectNull(timeoutsAttrTypes),
+	}
+	want.Effective, _ = effectiveFoldersValue(ctx, &client.Folders{
+		DownloadDir:         "Downloads/incomplete",
+		DownloadFree:        "10G",
+		CompleteDir:         "/data/complete",
+		AutoResume:          1,
+		Permissions:         "755",
+		WatchedDir:          "watch",
+		WatchedDirScanSpeed: 10,
+		ScriptsDir:          "/config/scripts",
+		AdminDir:            "admin",
+		LogDir:              "logs",
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imported state mismatch:\nexpected %+v\ngot      %+v", want, got)
+	}
+}
+
+func TestFoldersResourceCreateEffective(t *testing.T) {
+	ctx := context.Background()
+	fake, c := newFakeSabnzbd(t)
+	fake.misc = map[string]interface{}{
+		"complete_dir": "/data/complete",
+	}
+
+	r := &FoldersResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := FoldersResourceModel{
+		ID:                  types.StringUnknown(),
+		DownloadDir:         types.StringValue("incomplete"),
+		DownloadFree:        types.StringValue(""),
+		CompleteDir:         types.StringValue(""),
+		CompleteFree:        types.StringValue(""),
+		AutoResume:          types.BoolValue(false),
+		Permissions:         types.StringValue(""),
+		WatchedDir:          types.StringValue(""),
+		WatchedDirScanSpeed: types.Int64Value(5),
+		ScriptsDir:          types.StringValue(""),
+		EmailTemplatesDir:   types.StringValue(""),
+		PasswordFile:        types.StringValue(""),
+		NzbBackupDir:        types.StringValue(""),
+		AdminDir:            types.StringValue("admin"),
+		BackupDir:           types.StringValue("backup"),
+		LogDir:              types.StringValue("logs"),
+		DownloadDirAbsolute: types.StringUnknown(),
+		CompleteDirAbsolute: types.StringUnknown(),
+		WatchedDirAbsolute:  types.StringUnknown(),
+		ScriptsDirAbsolute:  types.StringUnknown(),
+		Effective:           types.ObjectUnknown(foldersEffectiveAttrTypes),
+		Timeouts:            types.ObjectNull(timeoutsAttrTypes),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	var got FoldersResourceModel
+	createResp.State.Get(ctx, &got)
+
+	var effective FoldersEffectiveModel
+	got.Effective.As(ctx, &effective, basetypes.ObjectAsOptions{})
+
+	// The empty complete_dir is not sent, so SABnzbd keeps its current value.
+	if got.CompleteDir.ValueString() != "" || effective.CompleteDir.ValueString() != "/data/complete" {
+		t.Errorf("expected effective complete_dir /data/complete for an empty configured value, got %q", effective.CompleteDir.ValueString())
+	}
+	if effective.DownloadDir.ValueString() != "incomplete" {
+		t.Errorf("expected effective download_dir incomplete, got %q", effective.DownloadDir.ValueString())
+	}
+}
+
+func TestFoldersResourceForcesID(t *testing.T) {
+	ctx := context.Background()
+	_, c := newFakeSabnzbd(t)
+
+	r := &FoldersResource{client: c}
+	s := resourceSchema(t, r)
+
+	importResp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "config"}, &importResp)
+	if importResp.Diagnostics.HasError() || importResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning importing a wrong ID, got %v", importResp.Diagnostics)
+	}
+
+	var id types.String
+	importResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != foldersID {
+		t.Errorf("expected imported ID %q, got %s", foldersID, id)
+	}
+
+	// A state that already holds a wrong ID is corrected on read.
+	importResp.State.SetAttribute(ctx, path.Root("id"), "config")
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning reading a wrong ID, got %v", readResp.Diagnostics)
+	}
+
+	readResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != foldersID {
+		t.Errorf("expected ID %q after read, got %s", foldersID, id)
+	}
+}
+
+func TestFoldersResourceKeepsPlannedValues(t *testing.T) {
+	ctx := context.Background()
+	fake, c := newFakeSabnzbd(t)
+	fake.misc = map[string]interface{}{
+		"complete_dir": "/data/complete",
+	}
+	fake.normalize = func(section, key, value string) string {
+		if section == "misc" && strings.HasSuffix(key, "_dir") {
+			return strings.TrimRight(value, "/")
+		}
+		return value
+	}
+
+	r := &FoldersResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := FoldersResourceModel{
+		ID:                  types.StringUnknown(),
+		DownloadDir:         types.StringValue("incomplete/"),
+		DownloadFree:        types.StringValue(""),
+		CompleteDir:         types.StringValue(""),
+		CompleteFree:        types.StringValue(""),
+		AutoResume:          types.BoolValue(false),
+		Permissions:         types.StringValue(""),
+		WatchedDir:          types.StringValue(""),
+		WatchedDirScanSpeed: types.Int64Value(5),
+		ScriptsDir:          types.StringValue(""),
+		EmailTemplatesDir:   types.StringValue(""),
+		PasswordFile:        types.StringValue(""),
+		NzbBackupDir:        types.StringValue(""),
+		AdminDir:            types.StringValue("admin/"),
+		BackupDir:           types.StringValue("backup"),
+		LogDir:              types.StringValue("logs"),
+		DownloadDirAbsolute: types.StringUnknown(),
+		CompleteDirAbsolute: types.StringUnknown(),
+		WatchedDirAbsolute:  types.StringUnknown(),
+		ScriptsDirAbsolute:  types.StringUnknown(),
+		Effective:           types.ObjectUnknown(foldersEffectiveAttrTypes),
+		Timeouts:            types.ObjectNull(timeoutsAttrTypes),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Terraform rejects an apply result that differs from a known planned
+	// value, so the normalized folders are warned about instead of stored.
+	var changed []string
+	for _, warning := range createResp.Diagnostics.Warnings() {
+		if withPath, ok := warning.(diag.DiagnosticWithPath); ok && warning.Summary() == "Value Changed by SABnzbd" {
+			changed = append(changed, withPath.Path().String())
+		}
+	}
+	if want := []string{"download_dir", "admin_dir"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("expected warnings about %v, got %v", want, createResp.Diagnostics)
+	}
+
+	var got FoldersResourceModel
+	createResp.State.Get(ctx, &got)
+	if got.DownloadDir.ValueString() != "incomplete/" || got.AdminDir.ValueString() != "admin/" {
+		t.Errorf("expected the planned download_dir and admin_dir, got %s and %s", got.DownloadDir, got.AdminDir)
+	}
+	if got.DownloadDirAbsolute.ValueString() == "" || got.Effective.IsUnknown() {
+		t.Errorf("expected the computed attributes to be known, got %+v", got)
+	}
+
+	// Empty values are not sent, so they stay empty rather than adopting
+	// the value SABnzbd keeps.
+	if got.CompleteDir.ValueString() != "" {
+		t.Errorf("expected complete_dir to stay empty, got %s", got.CompleteDir)
+	}
+
+	// The absolute paths come from what SABnzbd keeps, so they match what
+	// a refresh reads back.
+	if got.CompleteDirAbsolute.ValueString() != "/data/complete" {
+		t.Errorf("expected complete_dir_absolute from the stored folder, got %s", got.CompleteDirAbsolute)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FreeSpaceCheckDataSource{}
+
+func NewFreeSpaceCheckDataSource() datasource.DataSource {
+	return &FreeSpaceCheckDataSource{}
+}
+
+// FreeSpaceCheckDataSource defines the data source implementation.
+type FreeSpaceCheckDataSource struct {
+	client *client.Client
+}
+
+// FreeSpaceCheckDataSourceModel describes the data source data model.
+type FreeSpaceCheckDataSourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	DownloadFree           types.String `tfsdk:"download_free"`
+	DownloadFreeBytes      types.Int64  `tfsdk:"download_free_bytes"`
+	DownloadAvailableBytes types.Int64  `tfsdk:"download_available_bytes"`
+	DownloadBelowThreshold types.Bool   `tfsdk:"download_below_threshold"`
+	CompleteFree           types.String `tfsdk:"complete_free"`
+	CompleteFreeBytes      types.Int64  `tfsdk:"complete_free_bytes"`
+	CompleteAvailableBytes types.Int64  `tfsdk:"complete_available_bytes"`
+	CompleteBelowThreshold types.Bool   `tfsdk:"complete_below_threshold"`
+}
+
+func (d *FreeSpaceCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_free_space_check"
+}
+
+func (d *FreeSpaceCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares the minimum free space thresholds configured for SABnzbd's download and complete " +
+			"folders (`download_free` and `complete_free`) against the free space SABnzbd currently reports, " +
+			"to check whether SABnzbd is about to pause for lack of space.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"download_free": schema.StringAttribute{
+				MarkdownDescription: "The configured minimum free space for the temporary download folder, e.g. `10G`. " +
+					"Empty when no minimum is set.",
+				Computed: true,
+			},
+			"download_free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The configured minimum free space for the temporary download folder in bytes, or `0` when none is set.",
+				Computed:            true,
+			},
+			"download_available_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The free space SABnzbd reports for the temporary download folder in bytes.",
+				Computed:            true,
+			},
+			"download_below_threshold": schema.BoolAttribute{
+				MarkdownDescription: "Whether the temporary download folder has less free space than its configured minimum. " +
+					"Always `false` when no minimum is set.",
+				Computed: true,
+			},
+			"complete_free": schema.StringAttribute{
+				MarkdownDescription: "The configured minimum free space for the completed download folder, e.g. `10G`. " +
+					"Empty when no minimum is set.",
+				Computed: true,
+			},
+			"complete_free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The configured minimum free space for the completed download folder in bytes, or `0` when none is set.",
+				Computed:            true,
+			},
+			"complete_available_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The free space SABnzbd reports for the completed download folder in bytes.",
+				Computed:            true,
+			},
+			"complete_below_threshold": schema.BoolAttribute{
+				MarkdownDescription: "Whether the completed download folder has less free space than its configured minimum. " +
+					"Always `false` when no minimum is set.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *FreeSpaceCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *FreeSpaceCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FreeSpaceCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folders, err := d.client.GetFolders(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read folders", err)
+		return
+	}
+
+	status, err := d.client.GetStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read status", err)
+		return
+	}
+
+	downloadFree, err := client.ParseFreeThreshold(folders.DownloadFree)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse download_free, got error: %s", err))
+		return
+	}
+
+	completeFree, err := client.ParseFreeThreshold(folders.CompleteFree)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse complete_free, got error: %s", err))
+		return
+	}
+
+	downloadAvailable, err := client.ParseDiskspace(status.Diskspace1)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse diskspace1, got error: %s", err))
+		return
+	}
+
+	completeAvailable, err := client.ParseDiskspace(status.Diskspace2)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse diskspace2, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-free-space-check")
+	data.DownloadFree = types.StringValue(folders.DownloadFree)
+	data.DownloadFreeBytes = types.Int64Value(downloadFree)
+	data.DownloadAvailableBytes = types.Int64Value(downloadAvailable)
+	data.DownloadBelowThreshold = types.BoolValue(belowFreeThreshold(downloadAvailable, downloadFree))
+	data.CompleteFree = types.StringValue(folders.CompleteFree)
+	data.CompleteFreeBytes = types.Int64Value(completeFree)
+	data.CompleteAvailableBytes = types.Int64Value(completeAvailable)
+	data.CompleteBelowThreshold = types.BoolValue(belowFreeThreshold(completeAvailable, completeFree))
+
+	tflog.Trace(ctx, "read free space check data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// belowFreeThreshold reports whether available is under threshold. A
+// threshold of 0 means no minimum is set.
+func belowFreeThreshold(available, threshold int64) bool {
+	return threshold > 0 && available < threshold
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFreeSpaceCheckDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.misc["download_free"] = "10G"
+	f.misc["complete_free"] = ""
+	f.status["diskspace1"] = "4.50"
+	f.status["diskspace2"] = "0.25"
+
+	d := &FreeSpaceCheckDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &FreeSpaceCheckDataSourceModel{
+		ID:                     types.StringNull(),
+		DownloadFree:           types.StringNull(),
+		DownloadFreeBytes:      types.Int64Null(),
+		DownloadAvailableBytes: types.Int64Null(),
+		DownloadBelowThreshold: types.BoolNull(),
+		CompleteFree:           types.StringNull(),
+		CompleteFreeBytes:      types.Int64Null(),
+		CompleteAvailableBytes: types.Int64Null(),
+		CompleteBelowThreshold: types.BoolNull(),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got FreeSpaceCheckDataSourceModel
+	resp.State.Get(context.Background(), &got)
+
+	if got.DownloadFreeBytes.ValueInt64() != 10<<30 || got.DownloadAvailableBytes.ValueInt64() != 9<<29 {
+		t.Errorf("unexpected download threshold/available: %s, %s", got.DownloadFreeBytes, got.DownloadAvailableBytes)
+	}
+	if !got.DownloadBelowThreshold.ValueBool() {
+		t.Error("expected the download folder to be below its threshold")
+	}
+
+	// No complete_free is set, so even a nearly full disk is not flagged.
+	if got.CompleteFreeBytes.ValueInt64() != 0 || got.CompleteBelowThreshold.ValueBool() {
+		t.Errorf("expected no complete threshold, got %s bytes, below=%s", got.CompleteFreeBytes, got.CompleteBelowThreshold)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GlobalScriptsResource{}
+var _ resource.ResourceWithImportState = &GlobalScriptsResource{}
+var _ resource.ResourceWithModifyPlan = &GlobalScriptsResource{}
+
+// globalScriptsID is the ID of the global scripts singleton.
+const globalScriptsID = "global_scripts"
+
+func NewGlobalScriptsResource() resource.Resource {
+	return &GlobalScriptsResource{}
+}
+
+// GlobalScriptsResource defines the resource implementation.
+type GlobalScriptsResource struct {
+	client *client.Client
+}
+
+// GlobalScriptsResourceModel describes the resource data model.
+type GlobalScriptsResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	PreScript      types.String `tfsdk:"pre_script"`
+	EndQueueScript types.String `tfsdk:"end_queue_script"`
+}
+
+func (r *GlobalScriptsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_scripts"
+}
+
+func (r *GlobalScriptsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the scripts SABnzbd runs for every job, independent of categories. " +
+			"This is a singleton resource; only one should exist per SABnzbd instance. " +
+			"Post-processing scripts are set per category with `sabnzbd_category`. " +
+			"Destroying the resource sets both scripts to `None`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The global scripts configuration identifier. Always `global_scripts`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pre_script": schema.StringAttribute{
+				MarkdownDescription: "The pre-queue script, run before each job is added to the queue, " +
+					"which can change or reject the job. Use `None` to disable it. Defaults to `None`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.NoScript),
+			},
+			"end_queue_script": schema.StringAttribute{
+				MarkdownDescription: "The script run when the queue finishes. Use `None` to disable it. Defaults to `None`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.NoScript),
+			},
+		},
+	}
+}
+
+func (r *GlobalScriptsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy, and no API to ask before the provider is
+	// configured.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan GlobalScriptsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := map[string]types.String{
+		"pre_script":       plan.PreScript,
+		"end_queue_script": plan.EndQueueScript,
+	}
+
+	var scripts []string
+	for _, attribute := range []string{"pre_script", "end_queue_script"} {
+		script := planned[attribute]
+		if script.IsUnknown() || script.ValueString() == client.NoScript {
+			continue
+		}
+
+		if scripts == nil {
+			var err error
+			if scripts, err = r.client.GetScripts(ctx); err != nil {
+				// Don't block planning when SABnzbd is unreachable; apply will report it.
+				tflog.Debug(ctx, "unable to list scripts while planning global scripts", map[string]interface{}{"error": err.Error()})
+				return
+			}
+		}
+
+		if !slices.Contains(scripts, script.ValueString()) {
+			resp.Diagnostics.Append(scriptNotFoundWarning(path.Root(attribute), script.ValueString(), scripts))
+		}
+	}
+}
+
+func (r *GlobalScriptsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *GlobalScriptsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GlobalScriptsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetGlobalScripts(ctx, globalScriptsInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "create global scripts configuration", err)
+		return
+	}
+
+	scripts, err := r.client.GetGlobalScripts(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read global scripts configuration", err)
+		return
+	}
+	setGlobalScriptsModel(&data, scripts)
+
+	data.ID = types.StringValue(globalScriptsID)
+	tflog.Trace(ctx, "created global scripts resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GlobalScriptsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GlobalScriptsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scripts, err := r.client.GetGlobalScripts(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read global scripts configuration", err)
+		return
+	}
+	setGlobalScriptsModel(&data, scripts)
+
+	data.ID = types.StringValue(globalScriptsID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GlobalScriptsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GlobalScriptsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetGlobalScripts(ctx, globalScriptsInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "update global scripts configuration", err)
+		return
+	}
+
+	scripts, err := r.client.GetGlobalScripts(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read global scripts configuration", err)
+		return
+	}
+	setGlobalScriptsModel(&data, scripts)
+
+	data.ID = types.StringValue(globalScriptsID)
+	tflog.Trace(ctx, "updated global scripts resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GlobalScriptsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Scripts run for every job, so leaving them behind would keep changing
+	// jobs after the resource is gone.
+	if err := r.client.SetGlobalScripts(ctx, &client.GlobalScriptsInput{}); err != nil {
+		addClientError(&resp.Diagnostics, "delete global scripts configuration", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted global scripts resource")
+}
+
+func (r *GlobalScriptsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), globalScriptsID)...)
+}
+
+// globalScriptsInputFromModel converts the resource model into a client input.
+func globalScriptsInputFromModel(data *GlobalScriptsResourceModel) *client.GlobalScriptsInput {
+	return &client.GlobalScriptsInput{
+		PreScript:      data.PreScript.ValueString(),
+		EndQueueScript: data.EndQueueScript.ValueString(),
+	}
+}
+
+// setGlobalScriptsModel copies the scripts SABnzbd stores into data.
+func setGlobalScriptsModel(data *GlobalScriptsResourceModel, scripts *client.GlobalScripts) {
+	data.PreScript = types.StringValue(scripts.PreScript)
+	data.EndQueueScript = types.StringValue(scripts.EndQueueScript)
+}
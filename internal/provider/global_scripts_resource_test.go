@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGlobalScriptsResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &GlobalScriptsResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := GlobalScriptsResourceModel{
+		ID:             types.StringUnknown(),
+		PreScript:      types.StringValue("filter.py"),
+		EndQueueScript: types.StringValue(client.NoScript),
+	}
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if f.misc["pre_script"] != "filter.py" || f.misc["end_queue_script"] != client.NoScript {
+		t.Errorf("unexpected stored scripts: %v", f.misc)
+	}
+
+	var created GlobalScriptsResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != globalScriptsID {
+		t.Errorf("expected id %q, got %s", globalScriptsID, created.ID)
+	}
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if f.misc["pre_script"] != client.NoScript {
+		t.Errorf("expected destroy to disable the pre-queue script, got %v", f.misc["pre_script"])
+	}
+}
+
+func TestGlobalScriptsResourceModifyPlanWarnsMissingScript(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.scripts = []string{client.NoScript, "filter.py"}
+
+	r := &GlobalScriptsResource{client: c}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		preScript, endQueueScript string
+		wantWarnings              int
+	}{
+		"none":      {client.NoScript, client.NoScript, 0},
+		"available": {"filter.py", client.NoScript, 0},
+		"missing":   {"filter.py", "done.sh", 1},
+	}
+
+	for name, tc := range cases {
+		plan := GlobalScriptsResourceModel{
+			ID:             types.StringUnknown(),
+			PreScript:      types.StringValue(tc.preScript),
+			EndQueueScript: types.StringValue(tc.endQueueScript),
+		}
+		req := resource.ModifyPlanRequest{
+			Plan:   newPlan(t, s, &plan),
+			State:  newState(t, s, nil),
+			Config: newConfig(t, s, &plan),
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.wantWarnings, resp.Diagnostics)
+		}
+	}
+}
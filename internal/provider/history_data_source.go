@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// historyPageSize is the number of history entries requested per call.
+	historyPageSize = 50
+
+	// defaultHistoryMaxItems bounds the history read when max_items is unset.
+	defaultHistoryMaxItems = 100
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HistoryDataSource{}
+
+func NewHistoryDataSource() datasource.DataSource {
+	return &HistoryDataSource{}
+}
+
+// HistoryDataSource defines the data source implementation.
+type HistoryDataSource struct {
+	client *client.Client
+}
+
+// HistoryDataSourceModel describes the data source data model.
+type HistoryDataSourceModel struct {
+	ID          types.String       `tfsdk:"id"`
+	MaxItems    types.Int64        `tfsdk:"max_items"`
+	Total       types.Int64        `tfsdk:"total"`
+	FailedCount types.Int64        `tfsdk:"failed_count"`
+	Items       []HistoryItemModel `tfsdk:"items"`
+}
+
+// HistoryItemModel describes a single history entry.
+type HistoryItemModel struct {
+	NzoID            types.String `tfsdk:"nzo_id"`
+	Name             types.String `tfsdk:"name"`
+	Category         types.String `tfsdk:"category"`
+	Status           types.String `tfsdk:"status"`
+	Bytes            types.Int64  `tfsdk:"bytes"`
+	Completed        types.Int64  `tfsdk:"completed"`
+	CompletedTime    types.String `tfsdk:"completed_time"`
+	NormalizedStatus types.String `tfsdk:"normalized_status"`
+	FailMessage      types.String `tfsdk:"fail_message"`
+}
+
+func (d *HistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_history"
+}
+
+func (d *HistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the most recent entries from the SABnzbd download history, newest first. " +
+			"Large histories are read in pages.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"max_items": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of history entries to read. Defaults to `%d`.", defaultHistoryMaxItems),
+				Optional:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The total number of entries in the history, which may exceed the number returned in `items`.",
+				Computed:            true,
+			},
+			"failed_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of entries in `items` whose download failed.",
+				Computed:            true,
+			},
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The history entries, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nzo_id": schema.StringAttribute{
+							MarkdownDescription: "The SABnzbd job identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The job name.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category the job was downloaded with.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The job status as reported by SABnzbd.",
+							Computed:            true,
+						},
+						"completed_time": schema.StringAttribute{
+							MarkdownDescription: "The time the job finished as an RFC 3339 timestamp in UTC, or empty if it has not finished.",
+							Computed:            true,
+						},
+						"normalized_status": schema.StringAttribute{
+							MarkdownDescription: "The job status normalized to one of `completed`, `failed`, `queued`, " +
+								"`processing` (still being verified, repaired or unpacked) or `unknown`.",
+							Computed: true,
+						},
+						"fail_message": schema.StringAttribute{
+							MarkdownDescription: "The reason the job failed, or empty if it did not fail.",
+							Computed:            true,
+						},
+						"bytes": schema.Int64Attribute{
+							MarkdownDescription: "The size of the job in bytes.",
+							Computed:            true,
+						},
+						"completed": schema.Int64Attribute{
+							MarkdownDescription: "The time the job finished, as a Unix timestamp.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *HistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HistoryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxItems := defaultHistoryMaxItems
+	if !data.MaxItems.IsNull() {
+		if data.MaxItems.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_items"),
+				"Invalid Max Items",
+				"max_items must be at least 1.",
+			)
+			return
+		}
+		maxItems = int(data.MaxItems.ValueInt64())
+	}
+
+	slots, total, err := collectHistory(ctx, d.client, historyPageSize, maxItems)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read history", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-history")
+	data.Total = types.Int64Value(int64(total))
+	data.Items = make([]HistoryItemModel, len(slots))
+	failed := 0
+	for i, slot := range slots {
+		status := client.ParseHistoryStatus(slot.Status)
+		if status == client.HistoryStatusFailed {
+			failed++
+		}
+
+		data.Items[i] = HistoryItemModel{
+			NzoID:            types.StringValue(slot.NzoID),
+			Name:             types.StringValue(slot.Name),
+			Category:         types.StringValue(slot.Category),
+			Status:           types.StringValue(slot.Status),
+			Bytes:            types.Int64Value(slot.Bytes),
+			Completed:        types.Int64Value(slot.Completed),
+			CompletedTime:    types.StringValue(client.FormatCompletedTime(slot.Completed)),
+			NormalizedStatus: types.StringValue(string(status)),
+			FailMessage:      types.StringValue(slot.FailMessage),
+		}
+	}
+	data.FailedCount = types.Int64Value(int64(failed))
+
+	tflog.Trace(ctx, "read history data source", map[string]interface{}{"items": len(slots), "total": total})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// collectHistory pages through the history pageSize entries at a time until
+// maxItems entries have been read or the history is exhausted. It returns
+// the entries read and the total size of the history.
+func collectHistory(ctx context.Context, c *client.Client, pageSize, maxItems int) ([]client.HistorySlot, int, error) {
+	var slots []client.HistorySlot
+	total := 0
+
+	for len(slots) < maxItems {
+		// Stop between pages as soon as the read is cancelled.
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		limit := min(pageSize, maxItems-len(slots))
+
+		history, err := c.GetHistory(ctx, len(slots), limit)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total = history.NoOfSlots
+		slots = append(slots, history.Slots...)
+
+		// An empty page means the history shrank while paging.
+		if len(history.Slots) == 0 || len(slots) >= total {
+			break
+		}
+	}
+
+	if len(slots) > maxItems {
+		slots = slots[:maxItems]
+	}
+
+	return slots, total, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCollectHistoryPaging(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	for i := 0; i < 120; i++ {
+		f.history = append(f.history, client.HistorySlot{NzoID: fmt.Sprintf("SABnzbd_nzo_%d", i)})
+	}
+
+	tests := []struct {
+		maxItems     int
+		wantItems    int
+		wantRequests int
+	}{
+		{maxItems: 10, wantItems: 10, wantRequests: 1},
+		{maxItems: 110, wantItems: 110, wantRequests: 3},
+		{maxItems: 120, wantItems: 120, wantRequests: 3},
+		{maxItems: 500, wantItems: 120, wantRequests: 3},
+	}
+
+	for _, tt := range tests {
+		f.historyRequests = 0
+
+		slots, total, err := collectHistory(context.Background(), c, 50, tt.maxItems)
+		if err != nil {
+			t.Fatalf("max_items %d: unexpected error: %s", tt.maxItems, err)
+		}
+		if total != 120 {
+			t.Errorf("max_items %d: expected total 120, got %d", tt.maxItems, total)
+		}
+		if len(slots) != tt.wantItems {
+			t.Errorf("max_items %d: expected %d items, got %d", tt.maxItems, tt.wantItems, len(slots))
+		}
+		if f.historyRequests != tt.wantRequests {
+			t.Errorf("max_items %d: expected %d requests, got %d", tt.maxItems, tt.wantRequests, f.historyRequests)
+		}
+		for i, slot := range slots {
+			if want := fmt.Sprintf("SABnzbd_nzo_%d", i); slot.NzoID != want {
+				t.Errorf("max_items %d: item %d: expected %s, got %s", tt.maxItems, i, want, slot.NzoID)
+				break
+			}
+		}
+	}
+}
+
+func TestCollectHistoryEmpty(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+
+	slots, total, err := collectHistory(context.Background(), c, 50, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(slots) != 0 || total != 0 {
+		t.Errorf("expected empty history, got %d items of %d", len(slots), total)
+	}
+	if f.historyRequests != 1 {
+		t.Errorf("expected 1 request, got %d", f.historyRequests)
+	}
+}
+
+func TestHistoryDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	for i := 0; i < 75; i++ {
+		slot := client.HistorySlot{NzoID: fmt.Sprintf("SABnzbd_nzo_%d", i), Status: "Completed", Completed: 1700000000}
+		if i%10 == 0 {
+			slot.Status = "Failed"
+			slot.FailMessage = "Aborted, cannot be completed"
+		}
+		f.history = append(f.history, slot)
+	}
+
+	d := &HistoryDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &HistoryDataSourceModel{
+		ID:          types.StringNull(),
+		MaxItems:    types.Int64Value(60),
+		Total:       types.Int64Null(),
+		FailedCount: types.Int64Null(),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data HistoryDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.Total.ValueInt64() != 75 {
+		t.Errorf("expected total 75, got %d", data.Total.ValueInt64())
+	}
+	if len(data.Items) != 60 {
+		t.Fatalf("expected 60 items, got %d", len(data.Items))
+	}
+	if data.FailedCount.ValueInt64() != 6 {
+		t.Errorf("expected failed_count 6, got %d", data.FailedCount.ValueInt64())
+	}
+	if item := data.Items[0]; item.NormalizedStatus.ValueString() != "failed" || item.FailMessage.ValueString() == "" {
+		t.Errorf("expected first item to be failed with a message, got %+v", item)
+	}
+	if item := data.Items[1]; item.NormalizedStatus.ValueString() != "completed" || item.FailMessage.ValueString() != "" {
+		t.Errorf("expected second item to be completed without a message, got %+v", item)
+	}
+	if got := data.Items[1].CompletedTime.ValueString(); got != "2023-11-14T22:13:20Z" {
+		t.Errorf("expected completed_time 2023-11-14T22:13:20Z, got %q", got)
+	}
+}
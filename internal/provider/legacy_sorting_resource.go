@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LegacySortingResource{}
+var _ resource.ResourceWithImportState = &LegacySortingResource{}
+
+// legacySortingID is the ID of the legacy sorting singleton.
+const legacySortingID = "legacy_sorting"
+
+func NewLegacySortingResource() resource.Resource {
+	return &LegacySortingResource{}
+}
+
+// LegacySortingResource defines the resource implementation.
+type LegacySortingResource struct {
+	client *client.Client
+}
+
+// LegacySortingResourceModel describes the resource data model.
+type LegacySortingResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	TVSorting       types.Bool   `tfsdk:"tv_sorting"`
+	TVSortString    types.String `tfsdk:"tv_sort_string"`
+	TVCategories    types.List   `tfsdk:"tv_categories"`
+	MovieSorting    types.Bool   `tfsdk:"movie_sorting"`
+	MovieSortString types.String `tfsdk:"movie_sort_string"`
+	MovieSortExtra  types.String `tfsdk:"movie_sort_extra"`
+	MovieCategories types.List   `tfsdk:"movie_categories"`
+	DateSorting     types.Bool   `tfsdk:"date_sorting"`
+	DateSortString  types.String `tfsdk:"date_sort_string"`
+	DateCategories  types.List   `tfsdk:"date_categories"`
+}
+
+func (r *LegacySortingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_legacy_sorting"
+}
+
+func (r *LegacySortingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	sortingAttribute := func(kind string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			MarkdownDescription: fmt.Sprintf("Whether to sort %s jobs into folders named by the sort string. Defaults to `false`.", kind),
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		}
+	}
+	stringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description + " Defaults to empty.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(""),
+		}
+	}
+	categoriesAttribute := func(kind string) schema.ListAttribute {
+		return schema.ListAttribute{
+			MarkdownDescription: fmt.Sprintf("The categories whose jobs %s sorting applies to. Defaults to none.", kind),
+			Optional:            true,
+			Computed:            true,
+			ElementType:         types.StringType,
+			Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, nil)),
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the TV, movie and date sorting settings of SABnzbd versions before " +
+			client.SortersVersion + ", which keep them in the misc section. Newer versions replaced them with sorters, " +
+			"and creating or updating this resource against one is an error. This is a singleton resource; only one " +
+			"should exist per SABnzbd instance. Destroying the resource turns the three sorting types off and keeps " +
+			"their sort strings and categories.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The legacy sorting configuration identifier. Always `legacy_sorting`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tv_sorting":        sortingAttribute("TV"),
+			"tv_sort_string":    stringAttribute("The folder and file name pattern for TV jobs, e.g. `%sn/Season %s/%sn - %sx%0e - %en.%ext`."),
+			"tv_categories":     categoriesAttribute("TV"),
+			"movie_sorting":     sortingAttribute("movie"),
+			"movie_sort_string": stringAttribute("The folder and file name pattern for movie jobs, e.g. `%title (%y)/%title (%y).%ext`."),
+			"movie_sort_extra":  stringAttribute("The suffix for the parts of a movie split over several files, e.g. ` CD%1`."),
+			"movie_categories":  categoriesAttribute("movie"),
+			"date_sorting":      sortingAttribute("dated"),
+			"date_sort_string":  stringAttribute("The folder and file name pattern for dated jobs, e.g. `%t/%r/%t - %y-%0m-%0d.%ext`."),
+			"date_categories":   categoriesAttribute("date"),
+		},
+	}
+}
+
+func (r *LegacySortingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *LegacySortingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LegacySortingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := legacySortingInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.checkVersion(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetLegacySorting(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "create legacy sorting configuration", err)
+		return
+	}
+
+	sorting, err := r.client.GetLegacySorting(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read legacy sorting configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setLegacySortingModel(&data, sorting)...)
+
+	data.ID = types.StringValue(legacySortingID)
+	tflog.Trace(ctx, "created legacy sorting resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LegacySortingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LegacySortingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sorting, err := r.client.GetLegacySorting(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read legacy sorting configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setLegacySortingModel(&data, sorting)...)
+
+	data.ID = types.StringValue(legacySortingID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LegacySortingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data LegacySortingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := legacySortingInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.checkVersion(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetLegacySorting(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "update legacy sorting configuration", err)
+		return
+	}
+
+	sorting, err := r.client.GetLegacySorting(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read legacy sorting configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setLegacySortingModel(&data, sorting)...)
+
+	data.ID = types.StringValue(legacySortingID)
+	tflog.Trace(ctx, "updated legacy sorting resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LegacySortingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LegacySortingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := legacySortingInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sorting renames every matching job, so it is turned off rather than
+	// left running after the resource is gone.
+	input.TVSorting = false
+	input.MovieSorting = false
+	input.DateSorting = false
+
+	if err := r.client.SetLegacySorting(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "delete legacy sorting configuration", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted legacy sorting resource")
+}
+
+func (r *LegacySortingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), legacySortingID)...)
+}
+
+// checkVersion reports an error when SABnzbd is new enough to use sorters,
+// since it ignores the legacy settings. A version that cannot be parsed, such
+// as a development build, is assumed to support them.
+func (r *LegacySortingResource) checkVersion(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	version, err := r.client.GetVersion(ctx)
+	if err != nil {
+		addClientError(&diags, "read SABnzbd version", err)
+		return diags
+	}
+
+	legacy, err := client.UsesLegacySorting(version)
+	if err != nil {
+		tflog.Debug(ctx, "unable to compare SABnzbd version for legacy sorting", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+	if !legacy {
+		diags.AddError(
+			"Legacy Sorting Not Supported",
+			fmt.Sprintf("SABnzbd %s configures sorting with sorters, which replaced the TV, movie and date sorting "+
+				"settings in %s, and ignores those settings. Remove sabnzbd_legacy_sorting; the sabnzbd_sorters data "+
+				"source lists the sorters in use.", version, client.SortersVersion),
+		)
+	}
+
+	return diags
+}
+
+// legacySortingInputFromModel converts the resource model into a client input.
+func legacySortingInputFromModel(ctx context.Context, data *LegacySortingResourceModel) (*client.LegacySortingInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	input := &client.LegacySortingInput{
+		TVSorting:       data.TVSorting.ValueBool(),
+		TVSortString:    data.TVSortString.ValueString(),
+		MovieSorting:    data.MovieSorting.ValueBool(),
+		MovieSortString: data.MovieSortString.ValueString(),
+		MovieSortExtra:  data.MovieSortExtra.ValueString(),
+		DateSorting:     data.DateSorting.ValueBool(),
+		DateSortString:  data.DateSortString.ValueString(),
+	}
+	diags.Append(data.TVCategories.ElementsAs(ctx, &input.TVCategories, false)...)
+	diags.Append(data.MovieCategories.ElementsAs(ctx, &input.MovieCategories, false)...)
+	diags.Append(data.DateCategories.ElementsAs(ctx, &input.DateCategories, false)...)
+
+	return input, diags
+}
+
+// setLegacySortingModel copies the legacy sorting settings SABnzbd stores
+// into data.
+func setLegacySortingModel(data *LegacySortingResourceModel, sorting *client.LegacySorting) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.TVSorting = types.BoolValue(sorting.TVSorting == 1)
+	data.TVSortString = types.StringValue(sorting.TVSortString)
+	data.MovieSorting = types.BoolValue(sorting.MovieSorting == 1)
+	data.MovieSortString = types.StringValue(sorting.MovieSortString)
+	data.MovieSortExtra = types.StringValue(sorting.MovieSortExtra)
+	data.DateSorting = types.BoolValue(sorting.DateSorting == 1)
+	data.DateSortString = types.StringValue(sorting.DateSortString)
+
+	for _, list := range []struct {
+		field  *types.List
+		values []string
+	}{
+		{&data.TVCategories, sorting.TVCategories},
+		{&data.MovieCategories, sorting.MovieCategories},
+		{&data.DateCategories, sorting.DateCategories},
+	} {
+		value, d := types.ListValueFrom(context.Background(), types.StringType, list.values)
+		diags.Append(d...)
+		*list.field = value
+	}
+
+	return diags
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testLegacySortingModel returns a legacy sorting model that sorts TV jobs.
+func testLegacySortingModel() LegacySortingResourceModel {
+	empty := types.ListValueMust(types.StringType, nil)
+
+	return LegacySortingResourceModel{
+		ID:              types.StringUnknown(),
+		TVSorting:       types.BoolValue(true),
+		TVSortString:    types.StringValue("%sn/Season %s/%en.%ext"),
+		TVCategories:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("tv"), types.StringValue("anime")}),
+		MovieSorting:    types.BoolValue(false),
+		MovieSortString: types.StringValue(""),
+		MovieSortExtra:  types.StringValue(" CD%1"),
+		MovieCategories: empty,
+		DateSorting:     types.BoolValue(false),
+		DateSortString:  types.StringValue(""),
+		DateCategories:  empty,
+	}
+}
+
+func TestLegacySortingResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.status["version"] = "3.7.2"
+
+	r := &LegacySortingResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testLegacySortingModel()
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	want := map[string]interface{}{
+		"enable_tv_sorting":    "1",
+		"tv_sort_string":       "%sn/Season %s/%en.%ext",
+		"tv_categories":        "tv,anime",
+		"enable_movie_sorting": "0",
+		"movie_sort_extra":     " CD%1",
+		"movie_categories":     "",
+	}
+	for key, value := range want {
+		if f.misc[key] != value {
+			t.Errorf("expected %s=%q, got %v", key, value, f.misc[key])
+		}
+	}
+
+	// SABnzbd returns lists as JSON arrays and flags as numbers.
+	f.misc["tv_categories"] = []interface{}{"tv"}
+	f.misc["enable_date_sorting"] = 1
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read LegacySortingResourceModel
+	readResp.State.Get(ctx, &read)
+
+	var categories []string
+	read.TVCategories.ElementsAs(ctx, &categories, false)
+	if !reflect.DeepEqual(categories, []string{"tv"}) || !read.DateSorting.ValueBool() {
+		t.Errorf("expected the changes made outside Terraform, got %v and %s", categories, read.DateSorting)
+	}
+	if read.ID.ValueString() != legacySortingID || read.MovieSortExtra.ValueString() != " CD%1" {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+
+	deleteResp := resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if f.misc["enable_tv_sorting"] != "0" || f.misc["enable_date_sorting"] != "0" {
+		t.Errorf("expected destroy to turn sorting off, got %v and %v", f.misc["enable_tv_sorting"], f.misc["enable_date_sorting"])
+	}
+	if f.misc["tv_sort_string"] != "%sn/Season %s/%en.%ext" {
+		t.Errorf("expected destroy to keep the sort string, got %v", f.misc["tv_sort_string"])
+	}
+}
+
+func TestLegacySortingResourceSortersVersion(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &LegacySortingResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testLegacySortingModel()
+	resp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Legacy Sorting Not Supported" {
+		t.Fatalf("expected an error on SABnzbd %v, got %v", f.status["version"], resp.Diagnostics)
+	}
+	if _, ok := f.misc["enable_tv_sorting"]; ok {
+		t.Errorf("expected nothing to be written, got %v", f.misc)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MiscConfigDataSource{}
+
+func NewMiscConfigDataSource() datasource.DataSource {
+	return &MiscConfigDataSource{}
+}
+
+// MiscConfigDataSource defines the data source implementation.
+type MiscConfigDataSource struct {
+	client *client.Client
+}
+
+// MiscConfigDataSourceModel describes the data source data model.
+type MiscConfigDataSourceModel struct {
+	ID       types.String            `tfsdk:"id"`
+	Settings map[string]types.String `tfsdk:"settings"`
+}
+
+func (d *MiscConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_misc_config"
+}
+
+func (d *MiscConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves every setting of SABnzbd's misc section, including those no resource manages, " +
+			"to find out what a SABnzbd version supports before managing it. Credentials such as the API keys are " +
+			"replaced with `" + client.RedactedValue + "`; use `sabnzbd_config_export` to read them.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "The misc settings by name. Strings are kept as they are; numbers, booleans " +
+					"and lists are JSON-encoded, e.g. `8080`, `true` or `[\"exe\",\"com\"]`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *MiscConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *MiscConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MiscConfigDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	misc, err := d.client.GetMiscConfig(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read config", err)
+		return
+	}
+
+	client.RedactConfig(misc)
+
+	settings, err := client.StringifyConfigValues(misc)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode misc settings, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-misc-config")
+	data.Settings = make(map[string]types.String, len(settings))
+	for key, value := range settings {
+		data.Settings[key] = types.StringValue(value)
+	}
+
+	tflog.Trace(ctx, "read misc config data source", map[string]interface{}{"settings": len(settings)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
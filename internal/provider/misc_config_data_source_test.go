@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMiscConfigDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.misc = map[string]interface{}{
+		"host":                "::",
+		"port":                8080,
+		"api_key":             "abc123",
+		"unwanted_extensions": []interface{}{"exe", "com"},
+	}
+
+	d := &MiscConfigDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &MiscConfigDataSourceModel{ID: types.StringNull()})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got MiscConfigDataSourceModel
+	resp.State.Get(context.Background(), &got)
+
+	want := map[string]string{
+		"host":                "::",
+		"port":                "8080",
+		"api_key":             client.RedactedValue,
+		"unwanted_extensions": `["exe","com"]`,
+	}
+	if len(got.Settings) != len(want) {
+		t.Errorf("expected %d settings, got %v", len(want), got.Settings)
+	}
+	for key, value := range want {
+		if got.Settings[key].ValueString() != value {
+			t.Errorf("expected %s=%q, got %s", key, value, got.Settings[key])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NewznabFeedURLDataSource{}
+
+func NewNewznabFeedURLDataSource() datasource.DataSource {
+	return &NewznabFeedURLDataSource{}
+}
+
+// NewznabFeedURLDataSource defines the data source implementation. It only
+// builds a URL and never contacts SABnzbd.
+type NewznabFeedURLDataSource struct{}
+
+// NewznabFeedURLDataSourceModel describes the data source data model.
+type NewznabFeedURLDataSourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	BaseURL     types.String  `tfsdk:"base_url"`
+	APIKey      types.String  `tfsdk:"api_key"`
+	Categories  []types.Int64 `tfsdk:"categories"`
+	URL         types.String  `tfsdk:"url"`
+	RedactedURL types.String  `tfsdk:"redacted_url"`
+}
+
+func (d *NewznabFeedURLDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_newznab_feed_url"
+}
+
+func (d *NewznabFeedURLDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds the URL of a newznab indexer feed listing the newest items in some categories, " +
+			"for use as an RSS feed in SABnzbd. The URL carries the indexer API key, so it is sensitive; " +
+			"`redacted_url` can be shown in plan output instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The redacted URL.",
+				Computed:            true,
+			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "The http or https address of the indexer, with or without the trailing `/api`.",
+				Required:            true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "The indexer API key.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"categories": schema.ListAttribute{
+				MarkdownDescription: "The newznab categories to list, e.g. `5030` and `5040` for SD and HD TV.",
+				Required:            true,
+				ElementType:         types.Int64Type,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The feed URL, including the API key.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"redacted_url": schema.StringAttribute{
+				MarkdownDescription: "The feed URL with the API key replaced by `**REDACTED**`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *NewznabFeedURLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NewznabFeedURLDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories := make([]int, len(data.Categories))
+	for i, category := range data.Categories {
+		categories[i] = int(category.ValueInt64())
+	}
+
+	feedURL, err := client.NewznabFeedURL(data.BaseURL.ValueString(), data.APIKey.ValueString(), categories)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Newznab Feed", err.Error())
+		return
+	}
+
+	redacted, _ := client.RedactFeedURI(feedURL)
+	data.URL = types.StringValue(feedURL)
+	data.RedactedURL = types.StringValue(redacted)
+	data.ID = types.StringValue(redacted)
+
+	tflog.Trace(ctx, "read newznab feed url data source", map[string]interface{}{"url": redacted})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
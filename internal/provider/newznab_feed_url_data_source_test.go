@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newznabFeedURLConfig(t *testing.T, d *NewznabFeedURLDataSource, baseURL string, categories ...int64) (tfsdk.Config, datasource.ReadResponse) {
+	t.Helper()
+
+	s := dataSourceSchema(t, d)
+	cats := make([]types.Int64, len(categories))
+	for i, category := range categories {
+		cats[i] = types.Int64Value(category)
+	}
+	config := newDataSourceConfig(t, s, &NewznabFeedURLDataSourceModel{
+		ID:          types.StringNull(),
+		BaseURL:     types.StringValue(baseURL),
+		APIKey:      types.StringValue("secret"),
+		Categories:  cats,
+		URL:         types.StringNull(),
+		RedactedURL: types.StringNull(),
+	})
+
+	return config, datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+}
+
+func TestNewznabFeedURLDataSourceRead(t *testing.T) {
+	d := &NewznabFeedURLDataSource{}
+	config, resp := newznabFeedURLConfig(t, d, "https://indexer.example.com", 5030, 5040)
+
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got NewznabFeedURLDataSourceModel
+	resp.State.Get(context.Background(), &got)
+
+	if want := "https://indexer.example.com/api?apikey=secret&cat=5030%2C5040&dl=1&t=search"; got.URL.ValueString() != want {
+		t.Errorf("url = %q, want %q", got.URL.ValueString(), want)
+	}
+	if want := "https://indexer.example.com/api?apikey=**REDACTED**&cat=5030%2C5040&dl=1&t=search"; got.RedactedURL.ValueString() != want {
+		t.Errorf("redacted_url = %q, want %q", got.RedactedURL.ValueString(), want)
+	}
+	if got.ID != got.RedactedURL {
+		t.Errorf("id = %s, want the redacted url", got.ID)
+	}
+}
+
+func TestNewznabFeedURLDataSourceInvalid(t *testing.T) {
+	d := &NewznabFeedURLDataSource{}
+	config, resp := newznabFeedURLConfig(t, d, "ftp://indexer.example.com", 5030)
+
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a non-http base url")
+	}
+}
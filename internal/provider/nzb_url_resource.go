@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NzbURLResource{}
+var _ resource.ResourceWithValidateConfig = &NzbURLResource{}
+
+// unidentifiedNzoID is the ID of a download SABnzbd queued without a
+// known nzo_id; there is nothing to remove from the queue on destroy.
+const unidentifiedNzoID = "unidentified"
+
+func NewNzbURLResource() resource.Resource {
+	return &NzbURLResource{}
+}
+
+// NzbURLResource defines the resource implementation.
+type NzbURLResource struct {
+	client *client.Client
+}
+
+// NzbURLResourceModel describes the resource data model.
+type NzbURLResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	URL      types.String `tfsdk:"url"`
+	Category types.String `tfsdk:"category"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+func (r *NzbURLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nzb_url"
+}
+
+func (r *NzbURLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enqueues an NZB from a URL in SABnzbd, for example as a test download during provisioning. " +
+			"Destroying the resource removes the item from the download queue. Any change forces a new download.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The nzo_id SABnzbd assigned to the queued download, or `unidentified` if SABnzbd " +
+					"did not report it and it could not be found in the queue.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The http or https URL of the NZB to download.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "The category to assign to the download. Leave empty to use the default category.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "The priority of the download. " +
+					"Values: -100=Default, -2=Paused, -1=Low, 0=Normal, 1=High, 2=Force.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(-100),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NzbURLResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NzbURLResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.URL.IsNull() || data.URL.IsUnknown() {
+		return
+	}
+
+	if err := client.ValidateNZBURL(data.URL.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("url"), "Invalid NZB URL", err.Error())
+	}
+}
+
+func (r *NzbURLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *NzbURLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NzbURLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nzoID, err := r.client.AddURL(ctx, data.URL.ValueString(), data.Category.ValueString(),
+		strconv.FormatInt(data.Priority.ValueInt64(), 10))
+	if errors.Is(err, client.ErrJobNotIdentified) {
+		// The download is queued, so keep it in state rather than queueing it
+		// again on the next apply.
+		nzoID = unidentifiedNzoID
+		resp.Diagnostics.AddWarning(
+			"Queued Download Not Identified",
+			fmt.Sprintf("SABnzbd queued %s but did not report its nzo_id, and it could not be found in the queue. "+
+				"Destroying this resource will not remove the download; remove it in SABnzbd instead.", data.URL.ValueString()),
+		)
+	} else if err != nil {
+		addClientError(&resp.Diagnostics, "add NZB URL", err)
+		return
+	}
+
+	data.ID = types.StringValue(nzoID)
+	tflog.Trace(ctx, "created nzb url resource", map[string]interface{}{"nzo_id": nzoID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NzbURLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NzbURLResourceModel
+
+	// The queued item moves to history once it completes, so there is
+	// nothing to refresh; keep the state as created.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NzbURLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NzbURLResourceModel
+
+	// All configurable attributes force replacement.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NzbURLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NzbURLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.ValueString() == unidentifiedNzoID {
+		tflog.Trace(ctx, "deleted unidentified nzb url resource from state")
+		return
+	}
+
+	if err := r.client.DeleteQueueItem(ctx, data.ID.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "delete queue item", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted nzb url resource", map[string]interface{}{"nzo_id": data.ID.ValueString()})
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNzbURLResourceCreateAndDelete(t *testing.T) {
+	for _, plainText := range []bool{false, true} {
+		ctx := context.Background()
+		f, c := newFakeSabnzbd(t)
+		f.plainTextAddURL = plainText
+
+		r := &NzbURLResource{client: c}
+		s := resourceSchema(t, r)
+
+		plan := NzbURLResourceModel{
+			ID:       types.StringUnknown(),
+			URL:      types.StringValue("https://example.com/a.nzb"),
+			Category: types.StringValue("tv"),
+			Priority: types.Int64Value(-100),
+		}
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+		if len(createResp.Diagnostics) != 0 {
+			t.Fatalf("plain text %t: unexpected create diagnostics: %v", plainText, createResp.Diagnostics)
+		}
+
+		var created NzbURLResourceModel
+		createResp.State.Get(ctx, &created)
+		if created.ID.ValueString() != "SABnzbd_nzo_1" || len(f.queue.Slots) != 1 || f.queue.Slots[0].Category != "tv" {
+			t.Errorf("plain text %t: unexpected id %s and queue %+v", plainText, created.ID, f.queue.Slots)
+		}
+
+		deleteResp := resource.DeleteResponse{State: createResp.State}
+		r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+		if deleteResp.Diagnostics.HasError() {
+			t.Fatalf("plain text %t: unexpected delete diagnostics: %v", plainText, deleteResp.Diagnostics)
+		}
+		if len(f.queue.Slots) != 0 {
+			t.Errorf("plain text %t: expected the download to be removed, got %+v", plainText, f.queue.Slots)
+		}
+	}
+}
+
+func TestNzbURLResourceUnidentifiedJob(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.plainTextAddURL = true
+	f.addURLSkipsQueue = true
+
+	r := &NzbURLResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := NzbURLResourceModel{
+		ID:       types.StringUnknown(),
+		URL:      types.StringValue("https://example.com/a.nzb"),
+		Category: types.StringValue(""),
+		Priority: types.Int64Value(-100),
+	}
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() || createResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", createResp.Diagnostics)
+	}
+
+	// The download is kept in state so the next apply does not queue it
+	// again.
+	var created NzbURLResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != unidentifiedNzoID {
+		t.Errorf("expected id %q, got %s", unidentifiedNzoID, created.ID)
+	}
+
+	deleteResp := resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Errorf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+}
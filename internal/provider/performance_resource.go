@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PerformanceResource{}
+var _ resource.ResourceWithImportState = &PerformanceResource{}
+var _ resource.ResourceWithValidateConfig = &PerformanceResource{}
+
+// performanceID is the ID of the performance singleton.
+const performanceID = "performance"
+
+func NewPerformanceResource() resource.Resource {
+	return &PerformanceResource{}
+}
+
+// PerformanceResource defines the resource implementation.
+type PerformanceResource struct {
+	client *client.Client
+}
+
+// PerformanceResourceModel describes the resource data model.
+type PerformanceResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	CacheLimit          types.String `tfsdk:"cache_limit"`
+	DirectUnpackThreads types.Int64  `tfsdk:"direct_unpack_threads"`
+}
+
+func (r *PerformanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_performance"
+}
+
+func (r *PerformanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	defaults := client.DefaultPerformance
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages performance tuning from SABnzbd's misc settings. This is a singleton resource; " +
+			"only one should exist per SABnzbd instance. Unset attributes are reset to SABnzbd's defaults. " +
+			"Direct unpack itself is switched on with `sabnzbd_switches`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The performance configuration identifier. Always `performance`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_limit": schema.StringAttribute{
+				MarkdownDescription: "The memory SABnzbd uses to cache articles before writing them to disk, as a size " +
+					"such as `512M` or `2G`. `0` disables the cache. Defaults to `" + defaults.CacheLimit + "`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaults.CacheLimit),
+			},
+			"direct_unpack_threads": schema.Int64Attribute{
+				MarkdownDescription: "How many jobs direct unpack works on at once. " +
+					fmt.Sprintf("Defaults to `%d`.", defaults.DirectUnpackThreads),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(int64(defaults.DirectUnpackThreads)),
+			},
+		},
+	}
+}
+
+func (r *PerformanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PerformanceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.CacheLimit.IsNull() && !data.CacheLimit.IsUnknown() {
+		if _, err := client.ParseSize(data.CacheLimit.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cache_limit"), "Invalid Cache Limit",
+				fmt.Sprintf("cache_limit must be a size such as 512M or 2G: %s.", err))
+		}
+	}
+
+	if !data.DirectUnpackThreads.IsNull() && !data.DirectUnpackThreads.IsUnknown() && data.DirectUnpackThreads.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("direct_unpack_threads"), "Invalid Direct Unpack Threads",
+			fmt.Sprintf("direct_unpack_threads must be at least 1, got %d.", data.DirectUnpackThreads.ValueInt64()))
+	}
+}
+
+func (r *PerformanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *PerformanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PerformanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetPerformance(ctx, performanceInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "create performance configuration", err)
+		return
+	}
+
+	performance, err := r.client.GetPerformance(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read performance configuration", err)
+		return
+	}
+	setPerformanceModel(&data, performance)
+
+	data.ID = types.StringValue(performanceID)
+	tflog.Trace(ctx, "created performance resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PerformanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PerformanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	performance, err := r.client.GetPerformance(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read performance configuration", err)
+		return
+	}
+	setPerformanceModel(&data, performance)
+
+	data.ID = types.StringValue(performanceID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PerformanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PerformanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetPerformance(ctx, performanceInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "update performance configuration", err)
+		return
+	}
+
+	performance, err := r.client.GetPerformance(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read performance configuration", err)
+		return
+	}
+	setPerformanceModel(&data, performance)
+
+	data.ID = types.StringValue(performanceID)
+	tflog.Trace(ctx, "updated performance resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PerformanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The performance settings cannot be deleted, so they are kept and the
+	// resource is only removed from state.
+	tflog.Trace(ctx, "deleted performance resource from state")
+}
+
+func (r *PerformanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), performanceID)...)
+}
+
+// performanceInputFromModel converts the resource model into a client input.
+func performanceInputFromModel(data *PerformanceResourceModel) *client.PerformanceInput {
+	return &client.PerformanceInput{
+		CacheLimit:          data.CacheLimit.ValueString(),
+		DirectUnpackThreads: int(data.DirectUnpackThreads.ValueInt64()),
+	}
+}
+
+// setPerformanceModel copies the performance settings SABnzbd stores into
+// data.
+func setPerformanceModel(data *PerformanceResourceModel, performance *client.Performance) {
+	data.CacheLimit = types.StringValue(performance.CacheLimit)
+	data.DirectUnpackThreads = types.Int64Value(int64(performance.DirectUnpackThreads))
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPerformanceResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &PerformanceResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := PerformanceResourceModel{
+		ID:                  types.StringUnknown(),
+		CacheLimit:          types.StringValue("2G"),
+		DirectUnpackThreads: types.Int64Value(4),
+	}
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if f.misc["cache_limit"] != "2G" || f.misc["direct_unpack_threads"] != "4" {
+		t.Errorf("unexpected stored settings: %v", f.misc)
+	}
+
+	// A change made outside Terraform shows up on read.
+	f.misc["cache_limit"] = "512M"
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read PerformanceResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ID.ValueString() != performanceID || read.CacheLimit.ValueString() != "512M" || read.DirectUnpackThreads.ValueInt64() != 4 {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+}
+
+func TestPerformanceResourceValidateConfig(t *testing.T) {
+	r := &PerformanceResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		cacheLimit string
+		threads    int64
+		wantErr    bool
+	}{
+		"valid":         {cacheLimit: "1.5G", threads: 3},
+		"bytes":         {cacheLimit: "0", threads: 1},
+		"unknown unit":  {cacheLimit: "2X", threads: 3, wantErr: true},
+		"empty":         {cacheLimit: "", threads: 3, wantErr: true},
+		"no threads":    {cacheLimit: "1G", threads: 0, wantErr: true},
+		"not a number":  {cacheLimit: "lots", threads: 3, wantErr: true},
+		"trailing unit": {cacheLimit: "512 MB", threads: 3},
+	}
+
+	for name, tc := range cases {
+		config := PerformanceResourceModel{
+			ID:                  types.StringNull(),
+			CacheLimit:          types.StringValue(tc.cacheLimit),
+			DirectUnpackThreads: types.Int64Value(tc.threads),
+		}
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
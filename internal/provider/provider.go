@@ -5,7 +5,12 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -27,10 +32,44 @@ type SabnzbdProvider struct {
 	version string
 }
 
+// defaultMaxConnectionsWarn is the default connections count above which
+// servers get a plan-time warning.
+const defaultMaxConnectionsWarn = 50
+
+// maxResponseMB caps max_response_mb, so the byte limit cannot overflow.
+const maxResponseMB = 1 << 20
+
+// resourceData is handed to resources when they are configured.
+type resourceData struct {
+	client *client.Client
+
+	// maxConnectionsWarn is the connections count above which
+	// sabnzbd_server warns, or 0 to never warn.
+	maxConnectionsWarn int64
+
+	// defaultScript and defaultPriority replace the built-in defaults of
+	// sabnzbd_category, or are null to keep them.
+	defaultScript   types.String
+	defaultPriority types.Int64
+}
+
 // SabnzbdProviderModel describes the provider data model.
 type SabnzbdProviderModel struct {
-	URL    types.String `tfsdk:"url"`
-	APIKey types.String `tfsdk:"api_key"`
+	URL                 types.String `tfsdk:"url"`
+	APIKey              types.String `tfsdk:"api_key"`
+	UsePost             types.Bool   `tfsdk:"use_post"`
+	SerializeWrites     types.Bool   `tfsdk:"serialize_writes"`
+	MaxConnectionsWarn  types.Int64  `tfsdk:"max_connections_warn"`
+	SkipConnectionCheck types.Bool   `tfsdk:"skip_connection_check"`
+	UserAgent           types.String `tfsdk:"user_agent"`
+	APIPath             types.String `tfsdk:"api_path"`
+	OutputFormat        types.String `tfsdk:"output_format"`
+	MaxResponseMB       types.Int64  `tfsdk:"max_response_mb"`
+	RestartWaitSeconds  types.Int64  `tfsdk:"restart_wait_seconds"`
+	ConnectTimeout      types.Int64  `tfsdk:"connect_timeout_seconds"`
+	ValidateOnly        types.Bool   `tfsdk:"validate_only"`
+	DefaultScript       types.String `tfsdk:"default_script"`
+	DefaultPriority     types.Int64  `tfsdk:"default_priority"`
 }
 
 func (p *SabnzbdProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -45,6 +84,8 @@ func (p *SabnzbdProvider) Schema(ctx context.Context, req provider.SchemaRequest
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
 				MarkdownDescription: "The URL of the SABnzbd instance (e.g., `http://localhost:8080`). " +
+					"Include the path when SABnzbd is served below the root by a reverse proxy (e.g., `https://example.com/sabnzbd`). " +
+					"Query parameters a proxy requires are sent with every request; the API key must be set with `api_key` instead. " +
 					"Can also be set via the `SABNZBD_URL` environment variable.",
 				Optional: true,
 			},
@@ -54,6 +95,83 @@ func (p *SabnzbdProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:  true,
 				Sensitive: true,
 			},
+			"use_post": schema.BoolAttribute{
+				MarkdownDescription: "Send configuration changes (`set_config`/`del_config`) as POST requests with the " +
+					"API key and values in the request body instead of the URL, keeping them out of proxy and server logs. " +
+					"Read requests always use GET. Defaults to `false`.",
+				Optional: true,
+			},
+			"serialize_writes": schema.BoolAttribute{
+				MarkdownDescription: "Send configuration changes to SABnzbd one at a time. SABnzbd rewrites its config file " +
+					"on every change, so parallel writes from Terraform can overwrite each other and lose updates. " +
+					"Enabling this makes applies with many resources slower but correct. Defaults to `false`.",
+				Optional: true,
+			},
+			"max_connections_warn": schema.Int64Attribute{
+				MarkdownDescription: "Warn when planning a `sabnzbd_server` whose `connections` exceeds this value, " +
+					"to catch typos such as `800` instead of `8` before they get an account banned. " +
+					"Set to `0` to disable the warning. Defaults to `50`.",
+				Optional: true,
+			},
+			"skip_connection_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip checking that SABnzbd is reachable and accepts the API key when the provider " +
+					"is configured, e.g. to plan while SABnzbd is offline. Defaults to `false`.",
+				Optional: true,
+			},
+			"api_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the SABnzbd API below `url`, for setups where it is not served at " +
+					"`/api`, e.g. when a reverse proxy mounts it elsewhere to avoid a collision. Must start with `/`. " +
+					"Defaults to `/api`.",
+				Optional: true,
+			},
+			"output_format": schema.StringAttribute{
+				MarkdownDescription: "The `output` parameter sent with every request: `json`, or `none` to leave it unset, " +
+					"e.g. when a proxy or the query of `url` sets it. Query parameters in `url` are sent with every request, " +
+					"but never alongside one the provider sets. The provider only decodes JSON responses. Defaults to `json`.",
+				Optional: true,
+			},
+			"max_response_mb": schema.Int64Attribute{
+				MarkdownDescription: "The largest response, in MiB, the provider reads from SABnzbd. Larger responses fail " +
+					"instead of exhausting memory, e.g. when a proxy streams an error page. Raise it if a very long " +
+					"history exceeds it. Defaults to `50`.",
+				Optional: true,
+			},
+			"connect_timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long, in seconds, connecting to SABnzbd may take before a request fails, " +
+					"so an unreachable instance fails fast. Responses still have the full request timeout of 30 " +
+					"seconds to arrive. Defaults to `30`.",
+				Optional: true,
+			},
+			"restart_wait_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long, in seconds, a read waits for SABnzbd to come back when it stops answering " +
+					"during a run, e.g. while it restarts to apply a change. Reads are retried only after SABnzbd has " +
+					"answered once, and changes are never retried this way. Set to `0` to fail at once. Defaults to `60`.",
+				Optional: true,
+			},
+			"validate_only": schema.BoolAttribute{
+				MarkdownDescription: "Validate changes without applying them, e.g. to try a configuration against a " +
+					"production instance. The connection check and reads run as usual, but every change SABnzbd would " +
+					"make fails with an error listing the request that was not sent, so state keeps matching SABnzbd. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"default_script": schema.StringAttribute{
+				MarkdownDescription: "The post-processing script of `sabnzbd_category` resources that do not set `script`, " +
+					"instead of `None`. A `script` set on the resource always wins. Changing this updates every category " +
+					"that relies on it.",
+				Optional: true,
+			},
+			"default_priority": schema.Int64Attribute{
+				MarkdownDescription: "The priority of `sabnzbd_category` resources that do not set `priority`, " +
+					"instead of `-100` (Default). A `priority` set on the resource always wins. Changing this updates " +
+					"every category that relies on it.",
+				Optional: true,
+			},
+			"user_agent": schema.StringAttribute{
+				MarkdownDescription: "The `User-Agent` header sent with every request to SABnzbd, to identify provider " +
+					"traffic in SABnzbd and proxy logs. Defaults to `terraform-provider-sabnzbd/<version>`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -89,6 +207,17 @@ func (p *SabnzbdProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if url != "" {
+		if err := client.ValidateBaseURL(url); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Invalid SABnzbd URL",
+				"The provider cannot create the SABnzbd API client as the SABnzbd URL is invalid: "+err.Error()+". "+
+					"The URL must include the scheme and host, e.g. http://localhost:8080 or https://example.com/sabnzbd.",
+			)
+		}
+	}
+
 	if apiKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
@@ -98,28 +227,191 @@ func (p *SabnzbdProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	apiPath := client.DefaultAPIPath
+	if !data.APIPath.IsNull() {
+		apiPath = data.APIPath.ValueString()
+		if !strings.HasPrefix(apiPath, "/") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_path"),
+				"Invalid SABnzbd API Path",
+				fmt.Sprintf("api_path must start with /, got %q.", apiPath),
+			)
+		}
+	}
+
+	outputFormat := client.DefaultOutputFormat
+	if !data.OutputFormat.IsNull() {
+		switch data.OutputFormat.ValueString() {
+		case "json":
+		case "none":
+			outputFormat = ""
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_format"),
+				"Invalid SABnzbd Output Format",
+				fmt.Sprintf("output_format must be json or none, got %q.", data.OutputFormat.ValueString()),
+			)
+		}
+	}
+
+	maxResponseSize := int64(client.DefaultMaxResponseSize)
+	if !data.MaxResponseMB.IsNull() {
+		maxResponseSize = data.MaxResponseMB.ValueInt64() << 20
+		if data.MaxResponseMB.ValueInt64() < 1 || data.MaxResponseMB.ValueInt64() > maxResponseMB {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_response_mb"),
+				"Invalid Maximum Response Size",
+				fmt.Sprintf("max_response_mb must be between 1 and %d, got %d.", maxResponseMB, data.MaxResponseMB.ValueInt64()),
+			)
+		}
+	}
+
+	var connectTimeout time.Duration
+	if !data.ConnectTimeout.IsNull() {
+		connectTimeout = time.Duration(data.ConnectTimeout.ValueInt64()) * time.Second
+		if data.ConnectTimeout.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connect_timeout_seconds"),
+				"Invalid Connect Timeout",
+				fmt.Sprintf("connect_timeout_seconds must be at least 1, got %d.", data.ConnectTimeout.ValueInt64()),
+			)
+		}
+	}
+
+	restartWindow := client.DefaultRestartWindow
+	if !data.RestartWaitSeconds.IsNull() {
+		restartWindow = time.Duration(data.RestartWaitSeconds.ValueInt64()) * time.Second
+		if data.RestartWaitSeconds.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("restart_wait_seconds"),
+				"Invalid Restart Wait",
+				fmt.Sprintf("restart_wait_seconds must be 0 (disabled) or a positive number of seconds, got %d.", data.RestartWaitSeconds.ValueInt64()),
+			)
+		}
+	}
+
+	maxConnectionsWarn := int64(defaultMaxConnectionsWarn)
+	if !data.MaxConnectionsWarn.IsNull() {
+		maxConnectionsWarn = data.MaxConnectionsWarn.ValueInt64()
+		if maxConnectionsWarn < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_connections_warn"),
+				"Invalid Connections Warning Threshold",
+				"max_connections_warn must be 0 (disabled) or a positive number of connections.",
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	userAgent := client.DefaultUserAgent + "/" + p.version
+	if !data.UserAgent.IsNull() && data.UserAgent.ValueString() != "" {
+		userAgent = data.UserAgent.ValueString()
+	}
+
 	// Create the SABnzbd client.
-	sabnzbdClient := client.NewClient(url, apiKey)
+	sabnzbdClient := client.NewClient(url, apiKey,
+		client.WithPostWrites(data.UsePost.ValueBool()),
+		client.WithSerializedWrites(data.SerializeWrites.ValueBool()),
+		client.WithUserAgent(userAgent),
+		client.WithAPIPath(apiPath),
+		client.WithOutputFormat(outputFormat),
+		client.WithMaxResponseSize(maxResponseSize),
+		client.WithValidateOnly(data.ValidateOnly.ValueBool()),
+		client.WithRestartWindow(restartWindow),
+		client.WithConnectTimeout(connectTimeout),
+	)
+
+	if !data.SkipConnectionCheck.ValueBool() {
+		if err := sabnzbdClient.Ping(ctx); err != nil {
+			resp.Diagnostics.AddError("Unable to Connect to SABnzbd", connectionCheckDetail(err))
+			return
+		}
+
+		if httpsURL, ok := sabnzbdClient.HTTPSRedirect(); ok {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("url"),
+				"SABnzbd Redirects to HTTPS",
+				fmt.Sprintf("SABnzbd redirected the connection check from %s to %s. Requests follow the redirect, "+
+					"but each one first sends the API key over plain http. Set url to %s.",
+					sabnzbdClient.BaseURL(), httpsURL, httpsURL),
+			)
+		}
+	}
 
 	resp.DataSourceData = sabnzbdClient
-	resp.ResourceData = sabnzbdClient
+	resp.ResourceData = &resourceData{
+		client:             sabnzbdClient,
+		maxConnectionsWarn: maxConnectionsWarn,
+		defaultScript:      data.DefaultScript,
+		defaultPriority:    data.DefaultPriority,
+	}
+}
+
+// connectionCheckDetail explains a failed connection check, pointing at the
+// setting that most likely needs fixing.
+func connectionCheckDetail(err error) string {
+	var authErr *client.AuthError
+	var accessErr *client.AccessError
+	var urlErr *neturl.Error
+
+	hint := "Check that the url points at SABnzbd, including any path it is served below."
+	switch {
+	case errors.As(err, &authErr):
+		hint = "Check the api_key value or the SABNZBD_API_KEY environment variable."
+	case errors.As(err, &accessErr):
+		hint = "Check that the API is enabled and allows this machine's address in SABnzbd's settings, " +
+			"that no login page or proxy intercepts requests to it, and the api_key value."
+	case errors.As(err, &urlErr):
+		hint = "Check that SABnzbd is running and reachable at the configured url."
+	}
+
+	return fmt.Sprintf("The provider could not verify the connection to SABnzbd: %s\n\n%s "+
+		"Set skip_connection_check = true to configure the provider without connecting.", err, hint)
 }
 
 func (p *SabnzbdProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewServerResource,
 		NewCategoryResource,
+		NewArrCategoryResource,
+		NewCategoriesResource,
+		NewDefaultCategoryResource,
 		NewFoldersResource,
+		NewWebServerResource,
+		NewExternalAccessResource,
+		NewSwitchesResource,
+		NewPerformanceResource,
+		NewToolsResource,
+		NewGlobalScriptsResource,
+		NewLegacySortingResource,
+		NewScheduleResource,
+		NewNzbURLResource,
+		NewRSSTriggerResource,
+		NewEmailTestResource,
+		NewConfigBackupResource,
 	}
 }
 
 func (p *SabnzbdProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewConfigDataSource,
+		NewConfigExportDataSource,
+		NewDiskSpaceDataSource,
+		NewFreeSpaceCheckDataSource,
+		NewServerDataSource,
+		NewCategoryDataSource,
+		NewHistoryDataSource,
+		NewQueueDataSource,
+		NewMiscConfigDataSource,
+		NewNewznabFeedURLDataSource,
+		NewRSSFeedsDataSource,
+		NewSortersDataSource,
+		NewServerStatsDataSource,
+		NewServerTestDataSource,
+		NewStatusDataSource,
 	}
 }
 
@@ -4,8 +4,20 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -17,3 +29,248 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 }
 
 var _ = testAccProtoV6ProviderFactories
+
+// configureProvider runs Configure with model as the provider configuration.
+func configureProvider(t *testing.T, model SabnzbdProviderModel) provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	// tfsdk.Config has no setter, so build the value through a State.
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected config diagnostics: %v", diags)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+	return resp
+}
+
+func TestProviderConfigureConnectionCheck(t *testing.T) {
+	f, _ := newFakeSabnzbd(t)
+
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue(f.url),
+		APIKey:              types.StringValue("test-key"),
+		UsePost:             types.BoolNull(),
+		SerializeWrites:     types.BoolNull(),
+		MaxConnectionsWarn:  types.Int64Null(),
+		SkipConnectionCheck: types.BoolNull(),
+		UserAgent:           types.StringNull(),
+		APIPath:             types.StringNull(),
+	}
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	model.URL = types.StringValue("http://127.0.0.1:1")
+	resp := configureProvider(t, model)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an unreachable SABnzbd")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "reachable") {
+		t.Errorf("expected a hint about reachability, got %q", detail)
+	}
+
+	model.SkipConnectionCheck = types.BoolValue(true)
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics with the check skipped: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureAccessDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<html><body><h1>403 Forbidden</h1></body></html>")
+	}))
+	t.Cleanup(srv.Close)
+
+	model := SabnzbdProviderModel{
+		URL:    types.StringValue(srv.URL),
+		APIKey: types.StringValue("test-key"),
+	}
+
+	resp := configureProvider(t, model)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when SABnzbd refuses API access")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "API is enabled") {
+		t.Errorf("expected a hint about the API being disabled, got %q", detail)
+	}
+}
+
+func TestProviderConfigureUserAgent(t *testing.T) {
+	f, _ := newFakeSabnzbd(t)
+
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue(f.url),
+		APIKey:              types.StringValue("test-key"),
+		UsePost:             types.BoolNull(),
+		SerializeWrites:     types.BoolNull(),
+		MaxConnectionsWarn:  types.Int64Null(),
+		SkipConnectionCheck: types.BoolNull(),
+		UserAgent:           types.StringNull(),
+		APIPath:             types.StringNull(),
+	}
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if f.userAgent != "terraform-provider-sabnzbd/test" {
+		t.Errorf("expected the default User-Agent with the provider version, got %q", f.userAgent)
+	}
+
+	model.UserAgent = types.StringValue("infra-bot/1.0")
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if f.userAgent != "infra-bot/1.0" {
+		t.Errorf("expected the configured User-Agent, got %q", f.userAgent)
+	}
+}
+
+func TestProviderConfigureOutputFormat(t *testing.T) {
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue("http://localhost:8080"),
+		APIKey:              types.StringValue("test-key"),
+		SkipConnectionCheck: types.BoolValue(true),
+		OutputFormat:        types.StringValue("xml"),
+	}
+
+	if resp := configureProvider(t, model); !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an unsupported output_format")
+	}
+
+	for _, format := range []string{"json", "none"} {
+		model.OutputFormat = types.StringValue(format)
+		if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected diagnostics: %v", format, resp.Diagnostics)
+		}
+	}
+}
+
+func TestProviderConfigureMaxResponseMB(t *testing.T) {
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue("http://localhost:8080"),
+		APIKey:              types.StringValue("test-key"),
+		SkipConnectionCheck: types.BoolValue(true),
+	}
+
+	for _, mb := range []int64{0, -1, maxResponseMB + 1} {
+		model.MaxResponseMB = types.Int64Value(mb)
+		if resp := configureProvider(t, model); !resp.Diagnostics.HasError() {
+			t.Errorf("%d: expected an error for an out-of-range max_response_mb", mb)
+		}
+	}
+
+	model.MaxResponseMB = types.Int64Value(200)
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureConnectTimeout(t *testing.T) {
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue("http://localhost:8080"),
+		APIKey:              types.StringValue("test-key"),
+		SkipConnectionCheck: types.BoolValue(true),
+		ConnectTimeout:      types.Int64Value(0),
+	}
+
+	if resp := configureProvider(t, model); !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a connect_timeout_seconds below 1")
+	}
+
+	model.ConnectTimeout = types.Int64Value(5)
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureRestartWaitSeconds(t *testing.T) {
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue("http://localhost:8080"),
+		APIKey:              types.StringValue("test-key"),
+		SkipConnectionCheck: types.BoolValue(true),
+		RestartWaitSeconds:  types.Int64Value(-1),
+	}
+
+	if resp := configureProvider(t, model); !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a negative restart_wait_seconds")
+	}
+
+	for _, seconds := range []int64{0, 300} {
+		model.RestartWaitSeconds = types.Int64Value(seconds)
+		if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+			t.Errorf("%d: unexpected diagnostics: %v", seconds, resp.Diagnostics)
+		}
+	}
+}
+
+func TestProviderConfigureValidateOnly(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newFakeSabnzbd(t)
+
+	configured := configureProvider(t, SabnzbdProviderModel{
+		URL:          types.StringValue(f.url),
+		APIKey:       types.StringValue("test-key"),
+		ValidateOnly: types.BoolValue(true),
+	})
+	if configured.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", configured.Diagnostics)
+	}
+
+	r := &GlobalScriptsResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: configured.ResourceData}, &resource.ConfigureResponse{})
+	s := resourceSchema(t, r)
+
+	plan := GlobalScriptsResourceModel{
+		ID:             types.StringUnknown(),
+		PreScript:      types.StringValue("filter.py"),
+		EndQueueScript: types.StringValue("None"),
+	}
+	resp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "SABnzbd Change Not Applied" {
+		t.Fatalf("expected the change to be withheld, got %v", resp.Diagnostics)
+	}
+	if !strings.Contains(resp.Diagnostics[0].Detail(), "pre_script=filter.py") {
+		t.Errorf("expected the detail to list the request, got %q", resp.Diagnostics[0].Detail())
+	}
+	if _, ok := f.misc["pre_script"]; ok {
+		t.Errorf("expected nothing to be written, got %v", f.misc)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state, got %v", resp.State.Raw)
+	}
+}
+
+func TestProviderConfigureAPIPath(t *testing.T) {
+	model := SabnzbdProviderModel{
+		URL:                 types.StringValue("http://localhost:8080"),
+		APIKey:              types.StringValue("test-key"),
+		UsePost:             types.BoolNull(),
+		SerializeWrites:     types.BoolNull(),
+		MaxConnectionsWarn:  types.Int64Null(),
+		SkipConnectionCheck: types.BoolValue(true),
+		UserAgent:           types.StringNull(),
+		APIPath:             types.StringValue("sabnzbd-api"),
+	}
+
+	resp := configureProvider(t, model)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an api_path without a leading slash")
+	}
+
+	model.APIPath = types.StringValue("/sabnzbd-api")
+	if resp := configureProvider(t, model); resp.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
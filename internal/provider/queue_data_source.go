@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &QueueDataSource{}
+
+func NewQueueDataSource() datasource.DataSource {
+	return &QueueDataSource{}
+}
+
+// QueueDataSource defines the data source implementation.
+type QueueDataSource struct {
+	client *client.Client
+}
+
+// QueueDataSourceModel describes the data source data model.
+type QueueDataSourceModel struct {
+	ID              types.String     `tfsdk:"id"`
+	Status          types.String     `tfsdk:"status"`
+	Paused          types.Bool       `tfsdk:"paused"`
+	TimeLeft        types.String     `tfsdk:"timeleft"`
+	TimeLeftSeconds types.Int64      `tfsdk:"timeleft_seconds"`
+	ETA             types.String     `tfsdk:"eta"`
+	Total           types.Int64      `tfsdk:"total"`
+	Items           []QueueItemModel `tfsdk:"items"`
+}
+
+// QueueItemModel describes a single queued job.
+type QueueItemModel struct {
+	NzoID           types.String `tfsdk:"nzo_id"`
+	Name            types.String `tfsdk:"name"`
+	Category        types.String `tfsdk:"category"`
+	Status          types.String `tfsdk:"status"`
+	Percentage      types.Int64  `tfsdk:"percentage"`
+	TimeLeft        types.String `tfsdk:"timeleft"`
+	TimeLeftSeconds types.Int64  `tfsdk:"timeleft_seconds"`
+	ETA             types.String `tfsdk:"eta"`
+}
+
+func (d *QueueDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queue"
+}
+
+func (d *QueueDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the SABnzbd download queue with the progress and expected finish of each job, " +
+			"e.g. to wait until the queue is empty.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The queue status, e.g. `Downloading`, `Paused` or `Idle`.",
+				Computed:            true,
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Whether the queue is paused.",
+				Computed:            true,
+			},
+			"timeleft": schema.StringAttribute{
+				MarkdownDescription: "The time until the queue is finished at the current speed, as `H:MM:SS`, " +
+					"or `D:HH:MM:SS` from a day on.",
+				Computed: true,
+			},
+			"timeleft_seconds": schema.Int64Attribute{
+				MarkdownDescription: "`timeleft` in seconds, or null if SABnzbd reports it in a form the provider cannot parse.",
+				Computed:            true,
+			},
+			"eta": schema.StringAttribute{
+				MarkdownDescription: "The local time SABnzbd expects the queue to finish, or `unknown`.",
+				Computed:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The number of jobs in the queue.",
+				Computed:            true,
+			},
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The queued jobs, in download order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nzo_id": schema.StringAttribute{
+							MarkdownDescription: "The SABnzbd job identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The job name.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category the job is downloaded with.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The job status as reported by SABnzbd, e.g. `Downloading` or `Queued`.",
+							Computed:            true,
+						},
+						"percentage": schema.Int64Attribute{
+							MarkdownDescription: "How much of the job has been downloaded, from 0 to 100.",
+							Computed:            true,
+						},
+						"timeleft": schema.StringAttribute{
+							MarkdownDescription: "The time until the job is downloaded at the current speed, as `H:MM:SS`.",
+							Computed:            true,
+						},
+						"timeleft_seconds": schema.Int64Attribute{
+							MarkdownDescription: "`timeleft` in seconds, or null if it cannot be parsed.",
+							Computed:            true,
+						},
+						"eta": schema.StringAttribute{
+							MarkdownDescription: "The local time SABnzbd expects the job to finish, or `unknown`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *QueueDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *QueueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QueueDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queue, err := d.client.GetQueue(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read queue", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-queue")
+	data.Status = types.StringValue(queue.Status)
+	data.Paused = types.BoolValue(queue.Paused)
+	data.TimeLeft = types.StringValue(queue.TimeLeft)
+	data.TimeLeftSeconds = timeLeftSeconds(ctx, queue.TimeLeft)
+	data.ETA = types.StringValue(queue.ETA)
+	data.Total = types.Int64Value(int64(queue.NoOfSlots))
+	data.Items = make([]QueueItemModel, len(queue.Slots))
+	for i, slot := range queue.Slots {
+		data.Items[i] = QueueItemModel{
+			NzoID:           types.StringValue(slot.NzoID),
+			Name:            types.StringValue(slot.Filename),
+			Category:        types.StringValue(slot.Category),
+			Status:          types.StringValue(slot.Status),
+			Percentage:      types.Int64Value(int64(slot.Percentage)),
+			TimeLeft:        types.StringValue(slot.TimeLeft),
+			TimeLeftSeconds: timeLeftSeconds(ctx, slot.TimeLeft),
+			ETA:             types.StringValue(slot.ETA),
+		}
+	}
+
+	tflog.Trace(ctx, "read queue data source", map[string]interface{}{"items": len(queue.Slots)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// timeLeftSeconds converts a time left reported by SABnzbd into seconds, or
+// null if it cannot be parsed.
+func timeLeftSeconds(ctx context.Context, timeLeft string) types.Int64 {
+	seconds, err := client.ParseTimeLeft(timeLeft)
+	if err != nil {
+		tflog.Debug(ctx, "unable to parse time left", map[string]interface{}{"error": err.Error()})
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(seconds)
+}
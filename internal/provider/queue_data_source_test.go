@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestQueueDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.queue = client.Queue{
+		Status:    "Downloading",
+		TimeLeft:  "1:02:03:04",
+		ETA:       "21:45 Sat 17 Oct",
+		NoOfSlots: 2,
+		Slots: []client.QueueSlot{
+			{NzoID: "SABnzbd_nzo_1", Filename: "Show.S01E01", Category: "tv", Status: "Downloading", Percentage: 45, TimeLeft: "0:05:12", ETA: "21:38 Fri 16 Oct"},
+			{NzoID: "SABnzbd_nzo_2", Filename: "Movie", Category: "movies", Status: "Queued", TimeLeft: "unknown", ETA: "unknown"},
+		},
+	}
+
+	d := &QueueDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &QueueDataSourceModel{
+		ID:              types.StringNull(),
+		Status:          types.StringNull(),
+		Paused:          types.BoolNull(),
+		TimeLeft:        types.StringNull(),
+		TimeLeftSeconds: types.Int64Null(),
+		ETA:             types.StringNull(),
+		Total:           types.Int64Null(),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data QueueDataSourceModel
+	resp.State.Get(ctx, &data)
+	if data.Total.ValueInt64() != 2 || data.TimeLeftSeconds.ValueInt64() != 93784 || data.ETA.ValueString() != "21:45 Sat 17 Oct" {
+		t.Errorf("unexpected queue: %+v", data)
+	}
+	if len(data.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(data.Items))
+	}
+	if item := data.Items[0]; item.Name.ValueString() != "Show.S01E01" || item.Percentage.ValueInt64() != 45 || item.TimeLeftSeconds.ValueInt64() != 312 {
+		t.Errorf("unexpected first item: %+v", item)
+	}
+	if item := data.Items[1]; !item.TimeLeftSeconds.IsNull() || item.TimeLeft.ValueString() != "unknown" {
+		t.Errorf("expected an unparseable time left to be null, got %+v", item)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RSSFeedsDataSource{}
+
+func NewRSSFeedsDataSource() datasource.DataSource {
+	return &RSSFeedsDataSource{}
+}
+
+// RSSFeedsDataSource defines the data source implementation.
+type RSSFeedsDataSource struct {
+	client *client.Client
+}
+
+// RSSFeedsDataSourceModel describes the data source data model.
+type RSSFeedsDataSourceModel struct {
+	ID    types.String   `tfsdk:"id"`
+	Feeds []RSSFeedModel `tfsdk:"feeds"`
+}
+
+// RSSFeedModel describes a single RSS feed.
+type RSSFeedModel struct {
+	Name         types.String   `tfsdk:"name"`
+	URIs         []types.String `tfsdk:"uris"`
+	RedactedURIs []types.String `tfsdk:"redacted_uris"`
+	HasAPIKey    types.Bool     `tfsdk:"has_api_key"`
+	Category     types.String   `tfsdk:"category"`
+	PP           types.String   `tfsdk:"pp"`
+	Script       types.String   `tfsdk:"script"`
+	Enable       types.Bool     `tfsdk:"enable"`
+	Priority     types.Int64    `tfsdk:"priority"`
+}
+
+func (d *RSSFeedsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rss_feeds"
+}
+
+func (d *RSSFeedsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves all RSS feeds configured in SABnzbd, including feeds managed outside Terraform.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"feeds": schema.ListNestedAttribute{
+				MarkdownDescription: "The configured RSS feeds, in the order SABnzbd lists them. Empty when there are none.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the feed.",
+							Computed:            true,
+						},
+						"uris": schema.ListAttribute{
+							MarkdownDescription: "The URLs the feed reads, in the order SABnzbd stores them. Sensitive, " +
+								"since indexer feed URLs usually carry an API key; use `redacted_uris` to display them.",
+							Computed:    true,
+							Sensitive:   true,
+							ElementType: types.StringType,
+						},
+						"redacted_uris": schema.ListAttribute{
+							MarkdownDescription: "The URLs of `uris` with the value of every API key parameter " +
+								"(`apikey`, `api_key` or `r`) replaced by `**REDACTED**`.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"has_api_key": schema.BoolAttribute{
+							MarkdownDescription: "Whether any of the feed's URLs carries an API key.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category assigned to jobs from this feed.",
+							Computed:            true,
+						},
+						"pp": schema.StringAttribute{
+							MarkdownDescription: "The post-processing option for jobs from this feed.",
+							Computed:            true,
+						},
+						"script": schema.StringAttribute{
+							MarkdownDescription: "The post-processing script for jobs from this feed.",
+							Computed:            true,
+						},
+						"enable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the feed is read.",
+							Computed:            true,
+						},
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "The priority of jobs from this feed.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RSSFeedsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *RSSFeedsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RSSFeedsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	feeds, err := d.client.GetRSSFeeds(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read rss feeds", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-rss-feeds")
+
+	// Non-nil slices, so no feeds (or URIs) is an empty list rather than null.
+	data.Feeds = make([]RSSFeedModel, len(feeds))
+	for i, feed := range feeds {
+		uris := make([]types.String, len(feed.URI))
+		redactedURIs := make([]types.String, len(feed.URI))
+		hasAPIKey := false
+		for j, uri := range feed.URI {
+			redacted, hasKey := client.RedactFeedURI(uri)
+			uris[j] = types.StringValue(uri)
+			redactedURIs[j] = types.StringValue(redacted)
+			hasAPIKey = hasAPIKey || hasKey
+		}
+
+		data.Feeds[i] = RSSFeedModel{
+			Name:         types.StringValue(feed.Name),
+			URIs:         uris,
+			RedactedURIs: redactedURIs,
+			HasAPIKey:    types.BoolValue(hasAPIKey),
+			Category:     types.StringValue(feed.Cat),
+			PP:           types.StringValue(feed.PP),
+			Script:       types.StringValue(feed.Script),
+			Enable:       types.BoolValue(feed.Enable == 1),
+			Priority:     types.Int64Value(int64(feed.Priority)),
+		}
+	}
+
+	tflog.Trace(ctx, "read rss feeds data source", map[string]interface{}{"count": len(feeds)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
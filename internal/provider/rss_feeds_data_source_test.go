@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readRSSFeeds reads the sabnzbd_rss_feeds data source against c.
+func readRSSFeeds(t *testing.T, c *client.Client) RSSFeedsDataSourceModel {
+	t.Helper()
+
+	d := &RSSFeedsDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &RSSFeedsDataSourceModel{ID: types.StringNull()})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got RSSFeedsDataSourceModel
+	resp.State.Get(context.Background(), &got)
+
+	return got
+}
+
+func TestRSSFeedsDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.rss = []client.RSSFeed{
+		{Name: "tv", URI: client.StringList{"https://a.example.com/rss", "https://b.example.com/rss?t=5000&r=secret"}, Cat: "tv", PP: "3", Script: "None", Enable: 1, Priority: -100},
+		{Name: "paused", URI: client.StringList{}, Cat: "*", Enable: 0, Priority: 1},
+	}
+
+	got := readRSSFeeds(t, c)
+
+	want := []RSSFeedModel{
+		{
+			Name:         types.StringValue("tv"),
+			URIs:         []types.String{types.StringValue("https://a.example.com/rss"), types.StringValue("https://b.example.com/rss?t=5000&r=secret")},
+			RedactedURIs: []types.String{types.StringValue("https://a.example.com/rss"), types.StringValue("https://b.example.com/rss?t=5000&r=" + client.RedactedValue)},
+			HasAPIKey:    types.BoolValue(true),
+			Category:     types.StringValue("tv"),
+			PP:           types.StringValue("3"),
+			Script:       types.StringValue("None"),
+			Enable:       types.BoolValue(true),
+			Priority:     types.Int64Value(-100),
+		},
+		{
+			Name:         types.StringValue("paused"),
+			URIs:         []types.String{},
+			RedactedURIs: []types.String{},
+			HasAPIKey:    types.BoolValue(false),
+			Category:     types.StringValue("*"),
+			PP:           types.StringValue(""),
+			Script:       types.StringValue(""),
+			Enable:       types.BoolValue(false),
+			Priority:     types.Int64Value(1),
+		},
+	}
+	if !reflect.DeepEqual(got.Feeds, want) {
+		t.Errorf("expected %+v, got %+v", want, got.Feeds)
+	}
+}
+
+func TestRSSFeedsDataSourceEmpty(t *testing.T) {
+	_, c := newFakeSabnzbd(t)
+
+	got := readRSSFeeds(t, c)
+	if got.Feeds == nil || len(got.Feeds) != 0 {
+		t.Errorf("expected an empty feed list, got %#v", got.Feeds)
+	}
+}
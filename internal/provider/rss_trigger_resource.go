@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RSSTriggerResource{}
+
+func NewRSSTriggerResource() resource.Resource {
+	return &RSSTriggerResource{}
+}
+
+// RSSTriggerResource defines the resource implementation.
+type RSSTriggerResource struct {
+	client *client.Client
+}
+
+// RSSTriggerResourceModel describes the resource data model.
+type RSSTriggerResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Feed     types.String `tfsdk:"feed"`
+	Triggers types.Map    `tfsdk:"triggers"`
+}
+
+func (r *RSSTriggerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rss_trigger"
+}
+
+func (r *RSSTriggerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Makes SABnzbd read its RSS feeds now instead of waiting for the next scheduled check, " +
+			"for example after changing a feed's configuration. The feeds are read when the resource is created " +
+			"and again whenever `feed` or `triggers` change. Destroying the resource does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The feed name, or `*` when no feed is set.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"feed": schema.StringAttribute{
+				MarkdownDescription: "The RSS feed that must exist before the feeds are read. " +
+					"SABnzbd cannot read a single feed on demand, so every enabled feed is read either way. " +
+					"Leave empty to skip the check.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause the feeds to be read again when they change, " +
+					"such as the feed's URIs or filters.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RSSTriggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RSSTriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RSSTriggerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	feed := data.Feed.ValueString()
+	if err := r.client.TriggerRSS(ctx, feed); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("feed"), "RSS Feed Not Found",
+				fmt.Sprintf("No RSS feed named %q is configured in SABnzbd.", feed))
+			return
+		}
+		addClientError(&resp.Diagnostics, "trigger RSS feeds", err)
+		return
+	}
+
+	data.ID = types.StringValue(feed)
+	if feed == "" {
+		data.ID = types.StringValue("*")
+	}
+	tflog.Trace(ctx, "triggered rss feeds", map[string]interface{}{"feed": feed})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RSSTriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RSSTriggerResourceModel
+
+	// Reading the feeds leaves nothing behind to refresh; keep the state as
+	// created.
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RSSTriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RSSTriggerResourceModel
+
+	// All configurable attributes force replacement.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RSSTriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// There is nothing to undo in SABnzbd.
+	tflog.Trace(ctx, "deleted rss trigger resource")
+}
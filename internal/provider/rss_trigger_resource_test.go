@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRSSTriggerResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.rss = []client.RSSFeed{{Name: "tv", URI: client.StringList{"https://a.example.com/rss"}, Enable: 1}}
+
+	r := &RSSTriggerResource{client: c}
+	s := resourceSchema(t, r)
+
+	for feed, wantID := range map[string]string{"tv": "tv", "": "*"} {
+		plan := RSSTriggerResourceModel{
+			ID:   types.StringUnknown(),
+			Feed: types.StringValue(feed),
+			Triggers: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"uri": types.StringValue("https://a.example.com/rss"),
+			}),
+		}
+
+		before := f.rssNowRequests
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("%q: unexpected create diagnostics: %v", feed, createResp.Diagnostics)
+		}
+
+		if f.rssNowRequests != before+1 {
+			t.Errorf("%q: expected one rss_now request, got %d", feed, f.rssNowRequests-before)
+		}
+
+		var created RSSTriggerResourceModel
+		createResp.State.Get(ctx, &created)
+		if created.ID.ValueString() != wantID {
+			t.Errorf("%q: expected id %q, got %q", feed, wantID, created.ID.ValueString())
+		}
+	}
+}
+
+func TestRSSTriggerResourceUnknownFeed(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &RSSTriggerResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := RSSTriggerResourceModel{
+		ID:       types.StringUnknown(),
+		Feed:     types.StringValue("missing"),
+		Triggers: types.MapNull(types.StringType),
+	}
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an unknown feed")
+	}
+	if got := createResp.Diagnostics.Errors()[0].Summary(); got != "RSS Feed Not Found" {
+		t.Errorf("expected RSS Feed Not Found, got %q", got)
+	}
+	if f.rssNowRequests != 0 {
+		t.Errorf("expected no rss_now requests, got %d", f.rssNowRequests)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ScheduleResource{}
+var _ resource.ResourceWithImportState = &ScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &ScheduleResource{}
+
+func NewScheduleResource() resource.Resource {
+	return &ScheduleResource{}
+}
+
+// ScheduleResource defines the resource implementation.
+type ScheduleResource struct {
+	client *client.Client
+}
+
+// ScheduleResourceModel describes the resource data model.
+type ScheduleResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Hour    types.Int64  `tfsdk:"hour"`
+	Minute  types.Int64  `tfsdk:"minute"`
+	Days    types.Set    `tfsdk:"days"`
+	Action  types.String `tfsdk:"action"`
+	Value   types.String `tfsdk:"value"`
+}
+
+func (r *ScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schedule"
+}
+
+func (r *ScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a scheduled action in SABnzbd's scheduler, such as pausing downloads at night " +
+			"or changing the speed limit. Each resource manages a single entry in SABnzbd's schedule list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The schedule entry in SABnzbd's schedline format without the enabled flag " +
+					"(e.g., `0 7 1234567 pause`), so the entry is still found after it is toggled in SABnzbd.",
+				Computed: true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether this scheduled action is enabled.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"hour": schema.Int64Attribute{
+				MarkdownDescription: "The hour (0-23) at which the action runs.",
+				Required:            true,
+			},
+			"minute": schema.Int64Attribute{
+				MarkdownDescription: "The minute (0-59) at which the action runs.",
+				Required:            true,
+			},
+			"days": schema.SetAttribute{
+				MarkdownDescription: "The days of the week on which the action runs, where 1 is Monday and 7 is Sunday.",
+				Required:            true,
+				ElementType:         types.Int64Type,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "The scheduler action to run. Values: " +
+					"`" + strings.Join(client.ScheduleActions, "`, `") + "`.",
+				Required: true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The argument for the action, such as the speed limit for `speedlimit` " +
+					"or the server name for `enable_server`/`disable_server`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+func (r *ScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Action.IsNull() && !data.Action.IsUnknown() && !client.IsValidScheduleAction(data.Action.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("action"),
+			"Invalid Schedule Action",
+			fmt.Sprintf("%q is not a known SABnzbd scheduler action. Valid actions are: %s.",
+				data.Action.ValueString(), strings.Join(client.ScheduleActions, ", ")),
+		)
+	}
+
+	if !data.Hour.IsNull() && !data.Hour.IsUnknown() && (data.Hour.ValueInt64() < 0 || data.Hour.ValueInt64() > 23) {
+		resp.Diagnostics.AddAttributeError(path.Root("hour"), "Invalid Schedule Hour", "The hour must be between 0 and 23.")
+	}
+
+	if !data.Minute.IsNull() && !data.Minute.IsUnknown() && (data.Minute.ValueInt64() < 0 || data.Minute.ValueInt64() > 59) {
+		resp.Diagnostics.AddAttributeError(path.Root("minute"), "Invalid Schedule Minute", "The minute must be between 0 and 59.")
+	}
+
+	if !data.Days.IsNull() && !data.Days.IsUnknown() {
+		var days []types.Int64
+		resp.Diagnostics.Append(data.Days.ElementsAs(ctx, &days, false)...)
+		if len(days) == 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("days"), "Invalid Schedule Days", "At least one day must be specified.")
+		}
+		for _, d := range days {
+			if !d.IsUnknown() && (d.ValueInt64() < 1 || d.ValueInt64() > 7) {
+				resp.Diagnostics.AddAttributeError(path.Root("days"), "Invalid Schedule Days",
+					fmt.Sprintf("Day %d is out of range; days must be between 1 (Monday) and 7 (Sunday).", d.ValueInt64()))
+			}
+		}
+	}
+}
+
+func (r *ScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := scheduleFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over an identical entry, e.g. one added in SABnzbd, rather than
+	// adding a duplicate.
+	line := schedule.String()
+	err := r.client.UpdateSchedules(ctx, func(lines []string) ([]string, bool) {
+		if index := findSchedule(lines, schedule.Key()); index >= 0 {
+			resp.Diagnostics.AddWarning(
+				"Adopting Existing Schedule",
+				fmt.Sprintf("SABnzbd already has the schedule %q. It is now managed by this resource instead of being added again.", lines[index]),
+			)
+			lines[index] = line
+			return lines, true
+		}
+		return append(lines, line), true
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create schedule", err)
+		return
+	}
+
+	data.ID = types.StringValue(schedule.Key())
+	tflog.Trace(ctx, "created schedule resource", map[string]interface{}{"id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lines, err := r.client.GetSchedules(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read schedules", err)
+		return
+	}
+
+	index := findSchedule(lines, data.ID.ValueString())
+	if index < 0 {
+		tflog.Trace(ctx, "schedule no longer exists, removing from state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	schedule, err := client.ParseSchedule(lines[index])
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse schedule, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(scheduleToModel(ctx, schedule, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := scheduleFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	line := schedule.String()
+	err := r.client.UpdateSchedules(ctx, func(lines []string) ([]string, bool) {
+		if index := findSchedule(lines, state.ID.ValueString()); index >= 0 {
+			lines[index] = line
+			return lines, true
+		}
+		return append(lines, line), true
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update schedule", err)
+		return
+	}
+
+	data.ID = types.StringValue(schedule.Key())
+	tflog.Trace(ctx, "updated schedule resource", map[string]interface{}{"id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateSchedules(ctx, func(lines []string) ([]string, bool) {
+		index := findSchedule(lines, data.ID.ValueString())
+		if index < 0 {
+			return lines, false
+		}
+		return append(lines[:index], lines[index+1:]...), true
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete schedule", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted schedule resource", map[string]interface{}{"id": data.ID.ValueString()})
+}
+
+func (r *ScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// findSchedule returns the index of the schedline with the key id, or -1.
+// Keys leave out the enabled flag, so an entry toggled in SABnzbd is still
+// found, and are compared in normalized form so that SABnzbd's own formatting
+// (e.g. unsorted days) still matches. A full schedline, as earlier IDs held,
+// is accepted too.
+func findSchedule(lines []string, id string) int {
+	want, err := client.ParseScheduleKey(id)
+	if err != nil {
+		return -1
+	}
+
+	for i, line := range lines {
+		schedule, err := client.ParseSchedule(line)
+		if err != nil {
+			continue
+		}
+		if schedule.Key() == want.Key() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// scheduleFromModel converts the resource model into a client schedule.
+func scheduleFromModel(ctx context.Context, data *ScheduleResourceModel) (*client.Schedule, diag.Diagnostics) {
+	var days []int64
+	diags := data.Days.ElementsAs(ctx, &days, false)
+
+	schedule := &client.Schedule{
+		Enabled: data.Enabled.ValueBool(),
+		Minute:  int(data.Minute.ValueInt64()),
+		Hour:    int(data.Hour.ValueInt64()),
+		Action:  data.Action.ValueString(),
+		Value:   data.Value.ValueString(),
+	}
+	for _, d := range days {
+		schedule.Days = append(schedule.Days, int(d))
+	}
+
+	return schedule, diags
+}
+
+// scheduleToModel copies a client schedule into the resource model.
+func scheduleToModel(ctx context.Context, schedule *client.Schedule, data *ScheduleResourceModel) diag.Diagnostics {
+	days := make([]int64, len(schedule.Days))
+	for i, d := range schedule.Days {
+		days[i] = int64(d)
+	}
+
+	daysSet, diags := types.SetValueFrom(ctx, types.Int64Type, days)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.ID = types.StringValue(schedule.Key())
+	data.Enabled = types.BoolValue(schedule.Enabled)
+	data.Hour = types.Int64Value(int64(schedule.Hour))
+	data.Minute = types.Int64Value(int64(schedule.Minute))
+	data.Days = daysSet
+	data.Action = types.StringValue(schedule.Action)
+	data.Value = types.StringValue(schedule.Value)
+
+	return diags
+}
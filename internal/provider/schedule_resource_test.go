@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testScheduleModel returns a planned schedule running action at hour:00 on
+// weekdays.
+func testScheduleModel(hour int64, action, value string) ScheduleResourceModel {
+	days := []attr.Value{}
+	for d := int64(1); d <= 5; d++ {
+		days = append(days, types.Int64Value(d))
+	}
+
+	return ScheduleResourceModel{
+		ID:      types.StringUnknown(),
+		Enabled: types.BoolValue(true),
+		Hour:    types.Int64Value(hour),
+		Minute:  types.Int64Value(0),
+		Days:    types.SetValueMust(types.Int64Type, days),
+		Action:  types.StringValue(action),
+		Value:   types.StringValue(value),
+	}
+}
+
+func TestScheduleResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc["schedlines"] = []interface{}{"1 0 1 67 pause"}
+
+	r := &ScheduleResource{client: c}
+	s := resourceSchema(t, r)
+
+	// An argument with a comma must reach SABnzbd as part of its line.
+	plan := testScheduleModel(7, "disable_server", "news,example")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	want := []interface{}{"1 0 1 67 pause", "1 0 7 12345 disable_server news,example"}
+	if !reflect.DeepEqual(f.misc["schedlines"], want) {
+		t.Fatalf("expected schedlines %q, got %q", want, f.misc["schedlines"])
+	}
+
+	var created ScheduleResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != "0 7 12345 disable_server news,example" {
+		t.Errorf("expected an ID without the enabled flag, got %s", created.ID)
+	}
+
+	// Disabling the entry in SABnzbd keeps it the same resource.
+	f.misc["schedlines"] = []interface{}{"1 0 1 67 pause", "0 0 7 54321 disable_server news,example"}
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("expected the toggled schedule to stay in state")
+	}
+
+	var read ScheduleResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Enabled.ValueBool() || read.ID.ValueString() != created.ID.ValueString() {
+		t.Errorf("expected the disabled schedule under the same ID, got %+v", read)
+	}
+
+	// Re-enabling and moving it replaces the entry instead of adding one.
+	plan = testScheduleModel(8, "disable_server", "news,example")
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &plan), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	want = []interface{}{"1 0 1 67 pause", "1 0 8 12345 disable_server news,example"}
+	if !reflect.DeepEqual(f.misc["schedlines"], want) {
+		t.Fatalf("expected schedlines %q, got %q", want, f.misc["schedlines"])
+	}
+
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	want = []interface{}{"1 0 1 67 pause"}
+	if !reflect.DeepEqual(f.misc["schedlines"], want) {
+		t.Errorf("expected schedlines %q, got %q", want, f.misc["schedlines"])
+	}
+}
+
+func TestScheduleResourceParallelCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ScheduleResource{client: c}
+	s := resourceSchema(t, r)
+
+	// Terraform creates independent schedules in parallel; every one must
+	// survive the others' writes.
+	var reqs []resource.CreateRequest
+	for hour := int64(0); hour < 8; hour++ {
+		plan := testScheduleModel(hour, "pause", "")
+		reqs = append(reqs, resource.CreateRequest{Plan: newPlan(t, s, &plan)})
+	}
+
+	resps := make([]resource.CreateResponse, len(reqs))
+	var wg sync.WaitGroup
+	for i := range reqs {
+		resps[i] = resource.CreateResponse{State: newState(t, s, nil)}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Create(ctx, reqs[i], &resps[i])
+		}()
+	}
+	wg.Wait()
+
+	for i, resp := range resps {
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("create %d: unexpected diagnostics: %v", i, resp.Diagnostics)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lines, ok := f.misc["schedlines"].([]interface{})
+	if !ok || len(lines) != len(reqs) {
+		t.Errorf("expected %d schedlines, got %v", len(reqs), f.misc["schedlines"])
+	}
+}
+
+func TestScheduleResourceAdoptsExisting(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc["schedlines"] = []interface{}{"0 0 7 12345 pause"}
+
+	r := &ScheduleResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testScheduleModel(7, "pause", "")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() || createResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", createResp.Diagnostics)
+	}
+
+	want := []interface{}{"1 0 7 12345 pause"}
+	if !reflect.DeepEqual(f.misc["schedlines"], want) {
+		t.Errorf("expected the existing entry to be taken over, got %q", f.misc["schedlines"])
+	}
+}
+
+func TestScheduleResourceLegacyID(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc["schedlines"] = []interface{}{"0 0 7 12345 pause"}
+
+	r := &ScheduleResource{client: c}
+	s := resourceSchema(t, r)
+
+	// Earlier IDs held the whole line, including the enabled flag.
+	state := testScheduleModel(7, "pause", "")
+	state.ID = types.StringValue("1 0 7 12345 pause")
+	readResp := resource.ReadResponse{State: newState(t, s, &state)}
+	r.Read(ctx, resource.ReadRequest{State: newState(t, s, &state)}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ScheduleResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ID.ValueString() != "0 7 12345 pause" {
+		t.Errorf("expected the ID to be migrated to the key, got %s", read.ID)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerDataSource{}
+
+func NewServerDataSource() datasource.DataSource {
+	return &ServerDataSource{}
+}
+
+// ServerDataSource defines the data source implementation.
+type ServerDataSource struct {
+	client *client.Client
+}
+
+// ServerDataSourceModel describes the data source data model.
+type ServerDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Host        types.String `tfsdk:"host"`
+	Port        types.Int64  `tfsdk:"port"`
+	Username    types.String `tfsdk:"username"`
+	Connections types.Int64  `tfsdk:"connections"`
+	SSL         types.Bool   `tfsdk:"ssl"`
+	SSLVerify   types.Int64  `tfsdk:"ssl_verify"`
+	SSLCiphers  types.String `tfsdk:"ssl_ciphers"`
+	Enable      types.Bool   `tfsdk:"enable"`
+	Optional    types.Bool   `tfsdk:"optional"`
+	Retention   types.Int64  `tfsdk:"retention"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	Required    types.Bool   `tfsdk:"required"`
+	Notes       types.String `tfsdk:"notes"`
+}
+
+func (d *ServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (d *ServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a news server configuration from SABnzbd by name. " +
+			"Useful for referencing a server that is managed outside of Terraform. The password is never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the server to look up.",
+				Required:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "The hostname or IP address of the news server.",
+				Computed:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "The port number for the news server.",
+				Computed:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username for authentication.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"connections": schema.Int64Attribute{
+				MarkdownDescription: "The number of connections used for this server.",
+				Computed:            true,
+			},
+			"ssl": schema.BoolAttribute{
+				MarkdownDescription: "Whether SSL/TLS is used for the connection.",
+				Computed:            true,
+			},
+			"ssl_verify": schema.Int64Attribute{
+				MarkdownDescription: "SSL certificate verification level: 0=Disabled, 1=Minimal, 2=Medium, 3=Strict.",
+				Computed:            true,
+			},
+			"ssl_ciphers": schema.StringAttribute{
+				MarkdownDescription: "Custom SSL ciphers in use.",
+				Computed:            true,
+			},
+			"enable": schema.BoolAttribute{
+				MarkdownDescription: "Whether this server is enabled.",
+				Computed:            true,
+			},
+			"optional": schema.BoolAttribute{
+				MarkdownDescription: "Whether this server is optional (used only when primary servers fail).",
+				Computed:            true,
+			},
+			"retention": schema.Int64Attribute{
+				MarkdownDescription: "The retention period in days (0 for unlimited).",
+				Computed:            true,
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Connection timeout in seconds.",
+				Computed:            true,
+			},
+			"priority": schema.Int64Attribute{
+				MarkdownDescription: "Server priority (0 is highest priority).",
+				Computed:            true,
+			},
+			"required": schema.BoolAttribute{
+				MarkdownDescription: "Whether this server is required for downloads to complete.",
+				Computed:            true,
+			},
+			"notes": schema.StringAttribute{
+				MarkdownDescription: "Notes about this server.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ServerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *ServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := d.client.GetServer(ctx, data.Name.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Server Not Found",
+			fmt.Sprintf("No server named %q is configured in SABnzbd.", data.Name.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read server", err)
+		return
+	}
+
+	data.Host = types.StringValue(server.Host)
+	data.Port = types.Int64Value(int64(server.Port))
+	data.Username = types.StringValue(server.Username)
+	data.Connections = types.Int64Value(int64(server.Connections))
+	data.SSL = types.BoolValue(server.SSL == 1)
+	data.SSLVerify = types.Int64Value(int64(server.SSLVerify))
+	data.SSLCiphers = types.StringValue(server.SSLCiphers)
+	data.Enable = types.BoolValue(server.Enable == 1)
+	data.Optional = types.BoolValue(server.Optional == 1)
+	data.Retention = types.Int64Value(int64(server.Retention))
+	data.Timeout = types.Int64Value(int64(server.Timeout))
+	data.Priority = types.Int64Value(int64(server.Priority))
+	data.Required = types.BoolValue(server.Required == 1)
+	data.Notes = types.StringValue(server.Notes)
+
+	tflog.Trace(ctx, "read server data source", map[string]interface{}{"name": data.Name.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
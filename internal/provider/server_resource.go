@@ -5,9 +5,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +28,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServerResource{}
 var _ resource.ResourceWithImportState = &ServerResource{}
+var _ resource.ResourceWithModifyPlan = &ServerResource{}
+var _ resource.ResourceWithValidateConfig = &ServerResource{}
+
+const (
+	defaultPortSSL   = 563
+	defaultPortPlain = 119
+)
 
 func NewServerResource() resource.Resource {
 	return &ServerResource{}
@@ -30,27 +42,36 @@ func NewServerResource() resource.Resource {
 
 // ServerResource defines the resource implementation.
 type ServerResource struct {
-	client *client.Client
+	client             *client.Client
+	maxConnectionsWarn int64
 }
 
 // ServerResourceModel describes the resource data model.
 type ServerResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Host        types.String `tfsdk:"host"`
-	Port        types.Int64  `tfsdk:"port"`
-	Username    types.String `tfsdk:"username"`
-	Password    types.String `tfsdk:"password"`
-	Connections types.Int64  `tfsdk:"connections"`
-	SSL         types.Bool   `tfsdk:"ssl"`
-	SSLVerify   types.Int64  `tfsdk:"ssl_verify"`
-	SSLCiphers  types.String `tfsdk:"ssl_ciphers"`
-	Enable      types.Bool   `tfsdk:"enable"`
-	Optional    types.Bool   `tfsdk:"optional"`
-	Retention   types.Int64  `tfsdk:"retention"`
-	Timeout     types.Int64  `tfsdk:"timeout"`
-	Priority    types.Int64  `tfsdk:"priority"`
-	Required    types.Bool   `tfsdk:"required"`
-	Notes       types.String `tfsdk:"notes"`
+	Name              types.String `tfsdk:"name"`
+	Host              types.String `tfsdk:"host"`
+	Port              types.Int64  `tfsdk:"port"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Connections       types.Int64  `tfsdk:"connections"`
+	SSL               types.Bool   `tfsdk:"ssl"`
+	SSLVerify         types.Int64  `tfsdk:"ssl_verify"`
+	SSLCiphers        types.String `tfsdk:"ssl_ciphers"`
+	SSLCipherPreset   types.String `tfsdk:"ssl_cipher_preset"`
+	Enable            types.Bool   `tfsdk:"enable"`
+	Optional          types.Bool   `tfsdk:"optional"`
+	Retention         types.Int64  `tfsdk:"retention"`
+	RetentionDays     types.String `tfsdk:"retention_days"`
+	Timeout           types.Int64  `tfsdk:"timeout"`
+	Priority          types.Int64  `tfsdk:"priority"`
+	Required          types.Bool   `tfsdk:"required"`
+	Notes             types.String `tfsdk:"notes"`
+	SendGroup         types.Bool   `tfsdk:"send_group"`
+	ExpireDate        types.String `tfsdk:"expire_date"`
+	Quota             types.String `tfsdk:"quota"`
+	Timeouts          types.Object `tfsdk:"timeouts"`
 }
 
 func (r *ServerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,11 +84,10 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The unique name/identifier for this server configuration.",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "The unique name/identifier for this server configuration. Changing the name " +
+					"renames the server in place: a server with the new name and the same settings is added, " +
+					"then the old one is removed.",
+				Required: true,
 			},
 			"host": schema.StringAttribute{
 				MarkdownDescription: "The hostname or IP address of the news server.",
@@ -77,7 +97,7 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The port number for the news server. Default is 563 for SSL, 119 for non-SSL.",
 				Optional:            true,
 				Computed:            true,
-				Default:             int64default.StaticInt64(563),
+				Default:             int64default.StaticInt64(defaultPortSSL),
 			},
 			"username": schema.StringAttribute{
 				MarkdownDescription: "The username for authentication.",
@@ -87,11 +107,28 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             stringdefault.StaticString(""),
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The password for authentication.",
-				Optional:            true,
-				Sensitive:           true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "The password for authentication. The value is stored in Terraform state; " +
+					"use `password_wo` instead to keep it out of state. When unset, the password already in state, " +
+					"such as an imported one, is kept until `password_wo` is set, which removes it from state.",
+				Optional:  true,
+				Sensitive: true,
+				Computed:  true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				DeprecationMessage: "Use password_wo instead, which is sent to SABnzbd but never stored in Terraform state.",
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The password for authentication. This value is sent to SABnzbd but never stored in " +
+					"Terraform state. Change `password_wo_version` to apply a new password. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "A version number for `password_wo`. Terraform cannot detect changes to write-only values, " +
+					"so increment this to update the password in SABnzbd.",
+				Optional: true,
 			},
 			"connections": schema.Int64Attribute{
 				MarkdownDescription: "The number of connections to use for this server.",
@@ -112,10 +149,18 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:             int64default.StaticInt64(3),
 			},
 			"ssl_ciphers": schema.StringAttribute{
-				MarkdownDescription: "Custom SSL ciphers to use (leave empty for default).",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Custom SSL ciphers to use (leave empty for default). SABnzbd has no setting for a " +
+					"minimum TLS version; restricting the ciphers to ones only TLS 1.2 and later support is the closest equivalent.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"ssl_cipher_preset": schema.StringAttribute{
+				MarkdownDescription: "A named set of ciphers to use instead of writing `ssl_ciphers` by hand: " +
+					"`modern` (forward-secret AEAD ciphers, TLS 1.2 and later), `compatible` (adds DHE key exchange) " +
+					"or `legacy` (adds CBC ciphers for old servers). When set, `ssl_ciphers` is computed from it. " +
+					"Conflicts with `ssl_ciphers`.",
+				Optional: true,
 			},
 			"enable": schema.BoolAttribute{
 				MarkdownDescription: "Whether this server is enabled.",
@@ -135,6 +180,12 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Default:             int64default.StaticInt64(0),
 			},
+			"retention_days": schema.StringAttribute{
+				MarkdownDescription: "The retention period in a readable form, as a number of days with an optional unit suffix: " +
+					"`d` (days), `w` (weeks, 7 days) or `m` (months, 30 days), e.g. `90d`, `12w` or `6m`. " +
+					"When set, `retention` is computed from this value.",
+				Optional: true,
+			},
 			"timeout": schema.Int64Attribute{
 				MarkdownDescription: "Connection timeout in seconds.",
 				Optional:            true,
@@ -159,41 +210,194 @@ func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
 			},
+			"send_group": schema.BoolAttribute{
+				MarkdownDescription: "Whether to send a GROUP command before requesting articles. " +
+					"Only needed for servers that require it.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"expire_date": schema.StringAttribute{
+				MarkdownDescription: "The date the server account expires, in the form `YYYY-MM-DD` such as `2026-12-31`, " +
+					"or as an RFC 3339 timestamp such as `2026-12-31T00:00:00Z`. SABnzbd stores no time, so a timestamp " +
+					"is sent as its date in its own offset. SABnzbd warns as the date approaches and stops using the " +
+					"server after it. Leave empty for no expiry. " +
+					"Requires SABnzbd 3.2.0 or later.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"quota": schema.StringAttribute{
+				MarkdownDescription: "The download quota of the server account, as a size with an optional " +
+					"`K`, `M`, `G` or `T` suffix, e.g. `500G`. SABnzbd stops using the server once it is reached. " +
+					"Leave empty for no quota. Requires SABnzbd 3.2.0 or later.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"timeouts": timeoutsAttribute(),
 		},
 	}
 }
 
-func (r *ServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
+func (r *ServerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ServerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+	if !data.Password.IsNull() && !data.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting Password Settings",
+			"Set only one of password and password_wo.",
 		)
-		return
 	}
 
-	r.client = c
+	if !data.SSLCipherPreset.IsNull() && !data.SSLCiphers.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssl_cipher_preset"),
+			"Conflicting Cipher Settings",
+			"Set only one of ssl_ciphers and ssl_cipher_preset.",
+		)
+	}
+
+	if preset := data.SSLCipherPreset; !preset.IsNull() && !preset.IsUnknown() {
+		if _, ok := client.SSLCipherPresets[preset.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssl_cipher_preset"),
+				"Invalid Cipher Preset",
+				fmt.Sprintf("%q is not a known cipher preset. Valid presets are: %s.",
+					preset.ValueString(), strings.Join(client.SSLCipherPresetNames(), ", ")),
+			)
+		}
+	}
+
+	if !data.ExpireDate.IsNull() && !data.ExpireDate.IsUnknown() {
+		if _, err := normalizeExpireDate(data.ExpireDate.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expire_date"), "Invalid Expiry Date", err.Error())
+		}
+	}
 }
 
-func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data ServerResourceModel
+func (r *ServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// On destroy, only warn about losing a required server.
+	if req.Plan.Raw.IsNull() {
+		var state ServerResourceModel
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(requiredServerDestroyWarnings(&state)...)
+		return
+	}
+
+	var plan ServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RetentionDays.IsNull() && !plan.RetentionDays.IsUnknown() {
+		days, err := client.ParseRetentionDays(plan.RetentionDays.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retention_days"), "Invalid Retention", err.Error())
+			return
+		}
+
+		var configured types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("retention"), &configured)...)
+		if !configured.IsNull() && !configured.IsUnknown() && configured.ValueInt64() != int64(days) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retention_days"),
+				"Conflicting Retention Settings",
+				fmt.Sprintf("retention_days %q is %d days but retention is set to %d. Set only one of them.",
+					plan.RetentionDays.ValueString(), days, configured.ValueInt64()),
+			)
+			return
+		}
+
+		plan.Retention = types.Int64Value(int64(days))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("retention"), plan.Retention)...)
+	}
+
+	// A password kept in state, e.g. from before switching to password_wo,
+	// is dropped once the write-only password is used.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if !passwordWO.IsNull() {
+		plan.Password = types.StringValue("")
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), plan.Password)...)
+	}
+
+	if !plan.SSLCipherPreset.IsNull() && !plan.SSLCipherPreset.IsUnknown() {
+		if ciphers, ok := client.SSLCipherPresets[plan.SSLCipherPreset.ValueString()]; ok {
+			plan.SSLCiphers = types.StringValue(ciphers)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ssl_ciphers"), plan.SSLCiphers)...)
+		}
+	}
+
+	if !plan.Connections.IsUnknown() && exceedsConnectionsWarn(plan.Connections.ValueInt64(), r.maxConnectionsWarn) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("connections"),
+			"Unusually High Connection Count",
+			fmt.Sprintf("connections is set to %d, above the provider's max_connections_warn of %d. "+
+				"Most news providers allow far fewer connections and may block accounts that exceed their limit.",
+				plan.Connections.ValueInt64(), r.maxConnectionsWarn),
+		)
+	}
+
+	// The remaining checks compare against the current state.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state ServerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	input := &client.ServerInput{
+	if plan.SSL.IsUnknown() || plan.Port.IsUnknown() {
+		return
+	}
+
+	if suggested, ok := sslPortSuggestion(state.SSL.ValueBool(), plan.SSL.ValueBool(), state.Port.ValueInt64(), plan.Port.ValueInt64()); ok {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("port"),
+			"Server Port May Not Match SSL Setting",
+			fmt.Sprintf("The ssl setting is changing but port remains %d, the standard port for the previous setting. "+
+				"Consider setting port to %d, the standard port for the new setting.", plan.Port.ValueInt64(), suggested),
+		)
+	}
+}
+
+// serverInputFromModel builds the SABnzbd server settings from data,
+// preferring the write-only password when it is set.
+func serverInputFromModel(data *ServerResourceModel) *client.ServerInput {
+	password := data.Password.ValueString()
+	if !data.PasswordWO.IsNull() && !data.PasswordWO.IsUnknown() {
+		password = data.PasswordWO.ValueString()
+	}
+
+	// ValidateConfig has rejected dates that cannot be normalized.
+	expireDate, err := normalizeExpireDate(data.ExpireDate.ValueString())
+	if err != nil {
+		expireDate = data.ExpireDate.ValueString()
+	}
+
+	return &client.ServerInput{
 		Name:        data.Name.ValueString(),
 		Host:        data.Host.ValueString(),
 		Port:        int(data.Port.ValueInt64()),
 		Username:    data.Username.ValueString(),
-		Password:    data.Password.ValueString(),
+		Password:    password,
 		Connections: int(data.Connections.ValueInt64()),
 		SSL:         data.SSL.ValueBool(),
 		SSLVerify:   int(data.SSLVerify.ValueInt64()),
@@ -205,13 +409,193 @@ func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest,
 		Priority:    int(data.Priority.ValueInt64()),
 		Required:    data.Required.ValueBool(),
 		Notes:       data.Notes.ValueString(),
+		SendGroup:   data.SendGroup.ValueBool(),
+		ExpireDate:  expireDate,
+		Quota:       data.Quota.ValueString(),
+	}
+}
+
+// setServerModel copies the settings SABnzbd stores for server into data.
+// Username and password are left alone, since SABnzbd does not return them.
+func setServerModel(data *ServerResourceModel, server *client.Server) {
+	data.Host = types.StringValue(server.Host)
+	data.Port = types.Int64Value(int64(server.Port))
+	data.Connections = types.Int64Value(int64(server.Connections))
+	data.SSL = types.BoolValue(server.SSL == 1)
+	data.SSLVerify = types.Int64Value(int64(server.SSLVerify))
+	data.SSLCiphers = types.StringValue(server.SSLCiphers)
+	data.Enable = types.BoolValue(server.Enable == 1)
+	data.Optional = types.BoolValue(server.Optional == 1)
+	data.Retention = types.Int64Value(int64(server.Retention))
+	data.Timeout = types.Int64Value(int64(server.Timeout))
+	data.Priority = types.Int64Value(int64(server.Priority))
+	data.Required = types.BoolValue(server.Required == 1)
+	data.Notes = types.StringValue(server.Notes)
+	data.SendGroup = types.BoolValue(server.SendGroup == 1)
+	data.ExpireDate = types.StringValue(server.ExpireDate)
+	data.Quota = types.StringValue(server.Quota)
+}
+
+// adoptStoredServer reconciles data with the settings SABnzbd stored for
+// server after a write; see adoptStoredValue.
+func adoptStoredServer(data *ServerResourceModel, server *client.Server) diag.Diagnostics {
+	var stored ServerResourceModel
+	setServerModel(&stored, server)
+
+	var diags diag.Diagnostics
+	adoptStoredValue(&diags, path.Root("host"), &data.Host, stored.Host)
+	adoptStoredValue(&diags, path.Root("port"), &data.Port, stored.Port)
+	adoptStoredValue(&diags, path.Root("connections"), &data.Connections, stored.Connections)
+	adoptStoredValue(&diags, path.Root("ssl"), &data.SSL, stored.SSL)
+	adoptStoredValue(&diags, path.Root("ssl_verify"), &data.SSLVerify, stored.SSLVerify)
+	adoptStoredValue(&diags, path.Root("ssl_ciphers"), &data.SSLCiphers, stored.SSLCiphers)
+	adoptStoredValue(&diags, path.Root("enable"), &data.Enable, stored.Enable)
+	adoptStoredValue(&diags, path.Root("optional"), &data.Optional, stored.Optional)
+	adoptStoredValue(&diags, path.Root("retention"), &data.Retention, stored.Retention)
+	adoptStoredValue(&diags, path.Root("timeout"), &data.Timeout, stored.Timeout)
+	adoptStoredValue(&diags, path.Root("priority"), &data.Priority, stored.Priority)
+	adoptStoredValue(&diags, path.Root("required"), &data.Required, stored.Required)
+	adoptStoredValue(&diags, path.Root("notes"), &data.Notes, stored.Notes)
+	adoptStoredValue(&diags, path.Root("send_group"), &data.SendGroup, stored.SendGroup)
+	keepEquivalentExpireDate(&stored.ExpireDate, data.ExpireDate)
+	adoptStoredValue(&diags, path.Root("expire_date"), &data.ExpireDate, stored.ExpireDate)
+	adoptStoredValue(&diags, path.Root("quota"), &data.Quota, stored.Quota)
+
+	return diags
+}
+
+// normalizeExpireDate returns date, given as YYYY-MM-DD or as an RFC 3339
+// timestamp, in the YYYY-MM-DD form SABnzbd stores. A timestamp keeps the
+// date of its own offset. Empty means no expiry and is returned as is.
+func normalizeExpireDate(date string) (string, error) {
+	if date == "" {
+		return "", nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, date); err == nil {
+		return t.Format(time.DateOnly), nil
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t.Format(time.DateOnly), nil
+	}
+
+	return "", fmt.Errorf("expire_date must be a date in the form YYYY-MM-DD or an RFC 3339 timestamp, got %q", date)
+}
+
+// keepEquivalentExpireDate replaces the stored date with prior when prior is
+// another form of the same date, so a configured timestamp does not show as
+// changed once SABnzbd has stored only its date.
+func keepEquivalentExpireDate(stored *types.String, prior types.String) {
+	if prior.IsNull() || prior.IsUnknown() {
+		return
+	}
+
+	if date, err := normalizeExpireDate(prior.ValueString()); err == nil && date == stored.ValueString() {
+		*stored = prior
+	}
+}
+
+// requiredServerDestroyWarnings returns a warning when state describes a
+// required server, since SABnzbd cannot complete downloads without it.
+func requiredServerDestroyWarnings(state *ServerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if state.Required.ValueBool() {
+		diags.AddWarning(
+			"Destroying Required Server",
+			fmt.Sprintf("The server %q is marked as required. Downloads that depend on it may fail once it is removed. "+
+				"Set required = false first if another server should take over.", state.Name.ValueString()),
+		)
 	}
 
+	return diags
+}
+
+// exceedsConnectionsWarn reports whether connections is above threshold. A
+// threshold of 0 disables the check.
+func exceedsConnectionsWarn(connections, threshold int64) bool {
+	return threshold > 0 && connections > threshold
+}
+
+// sslPortSuggestion reports whether toggling ssl while leaving the port at the
+// old mode's standard value warrants a warning, and which port to suggest.
+func sslPortSuggestion(oldSSL, newSSL bool, oldPort, newPort int64) (int64, bool) {
+	if oldSSL == newSSL || oldPort != newPort {
+		return 0, false
+	}
+
+	if oldSSL && oldPort == defaultPortSSL {
+		return defaultPortPlain, true
+	}
+	if !oldSSL && oldPort == defaultPortPlain {
+		return defaultPortSSL, true
+	}
+
+	return 0, false
+}
+
+func (r *ServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+	r.maxConnectionsWarn = data.maxConnectionsWarn
+}
+
+func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutCreate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	// Write-only values are only available from the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &data.PasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := serverInputFromModel(&data)
+
 	if err := r.client.SetServer(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create server, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create server", err)
 		return
 	}
 
+	// Never persist the write-only password, nor a password it replaced. An
+	// unset password with nothing in state to keep was sent empty.
+	if !data.PasswordWO.IsNull() || data.Password.IsUnknown() {
+		data.Password = types.StringValue("")
+	}
+	data.PasswordWO = types.StringNull()
+
+	// SABnzbd may rewrite values on save; fill in what it kept for anything
+	// the plan left unknown. The server exists either way, so save the state
+	// even when reading it back fails.
+	if server, err := r.client.GetServer(ctx, data.Name.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "read server after creating it", err)
+	} else {
+		resp.Diagnostics.Append(adoptStoredServer(&data, server)...)
+	}
+
 	tflog.Trace(ctx, "created server resource", map[string]interface{}{"name": data.Name.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -225,26 +609,22 @@ func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutRead)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	server, err := r.client.GetServer(ctx, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read server", err)
 		return
 	}
 
-	data.Host = types.StringValue(server.Host)
-	data.Port = types.Int64Value(int64(server.Port))
-	// Note: Username and Password are not returned by the API for security reasons.
-	data.Connections = types.Int64Value(int64(server.Connections))
-	data.SSL = types.BoolValue(server.SSL == 1)
-	data.SSLVerify = types.Int64Value(int64(server.SSLVerify))
-	data.SSLCiphers = types.StringValue(server.SSLCiphers)
-	data.Enable = types.BoolValue(server.Enable == 1)
-	data.Optional = types.BoolValue(server.Optional == 1)
-	data.Retention = types.Int64Value(int64(server.Retention))
-	data.Timeout = types.Int64Value(int64(server.Timeout))
-	data.Priority = types.Int64Value(int64(server.Priority))
-	data.Required = types.BoolValue(server.Required == 1)
-	data.Notes = types.StringValue(server.Notes)
+	prior := data.ExpireDate
+	setServerModel(&data, server)
+	keepEquivalentExpireDate(&data.ExpireDate, prior)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -257,32 +637,60 @@ func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	input := &client.ServerInput{
-		Name:        data.Name.ValueString(),
-		Host:        data.Host.ValueString(),
-		Port:        int(data.Port.ValueInt64()),
-		Username:    data.Username.ValueString(),
-		Password:    data.Password.ValueString(),
-		Connections: int(data.Connections.ValueInt64()),
-		SSL:         data.SSL.ValueBool(),
-		SSLVerify:   int(data.SSLVerify.ValueInt64()),
-		SSLCiphers:  data.SSLCiphers.ValueString(),
-		Enable:      data.Enable.ValueBool(),
-		Optional:    data.Optional.ValueBool(),
-		Retention:   int(data.Retention.ValueInt64()),
-		Timeout:     int(data.Timeout.ValueInt64()),
-		Priority:    int(data.Priority.ValueInt64()),
-		Required:    data.Required.ValueBool(),
-		Notes:       data.Notes.ValueString(),
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutUpdate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	// Write-only values are only available from the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &data.PasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	var oldName types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &oldName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := serverInputFromModel(&data)
+
 	if err := r.client.SetServer(ctx, input); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update server, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update server", err)
 		return
 	}
 
+	// Never persist the write-only password, nor a password it replaced. An
+	// unset password with nothing in state to keep was sent empty.
+	if !data.PasswordWO.IsNull() || data.Password.IsUnknown() {
+		data.Password = types.StringValue("")
+	}
+	data.PasswordWO = types.StringNull()
+
+	// SABnzbd identifies servers by name, so a rename adds the server under
+	// its new name above and removes the old one here.
+	if renamed := oldName.ValueString(); renamed != data.Name.ValueString() {
+		if err := r.client.DeleteServer(ctx, renamed); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("remove server %q after renaming it", renamed), err)
+		}
+
+		tflog.Trace(ctx, "renamed server resource", map[string]interface{}{"from": renamed, "to": data.Name.ValueString()})
+	}
+
 	tflog.Trace(ctx, "updated server resource", map[string]interface{}{"name": data.Name.ValueString()})
 
+	// Fill in what SABnzbd kept, as on create.
+	if server, err := r.client.GetServer(ctx, data.Name.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "read server after updating it", err)
+	} else {
+		resp.Diagnostics.Append(adoptStoredServer(&data, server)...)
+	}
+
+	// Save the state even when removing the old name or reading the server
+	// back failed, since the server now exists under its new name.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -294,8 +702,15 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, data.Timeouts, timeoutDelete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
 	if err := r.client.DeleteServer(ctx, data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete server, got error: %s", err))
+		addClientError(&resp.Diagnostics, "delete server", err)
 		return
 	}
 
@@ -303,5 +718,59 @@ func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Refuse unknown names, since the imported state would otherwise hold
+	// only the name and the next apply would create a server from defaults.
+	_, err := r.client.GetServer(ctx, req.ID)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Server Not Found",
+			fmt.Sprintf("Cannot import server %q: no server with that name is configured in SABnzbd. "+
+				"The import ID must be the server's name exactly as SABnzbd lists it.", req.ID),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read server", err)
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+
+	// SABnzbd never returns server passwords, so take it from the
+	// environment when available to keep the imported state accurate.
+	envVar := serverPasswordEnvVar(req.ID)
+	password, ok := os.LookupEnv(envVar)
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Server Password Not Imported",
+			fmt.Sprintf("SABnzbd does not return server passwords, so the password of %q was not imported. "+
+				"Set password_wo (or password) in the configuration before the next apply, or SABnzbd's stored "+
+				"password may be replaced. To import it instead, set the %s environment variable and import again.",
+				req.ID, envVar),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Server Password Imported",
+		fmt.Sprintf("The password of %q was imported from the %s environment variable and is stored in Terraform "+
+			"state. It is kept while password is unset in the configuration. Make sure it is SABnzbd's current "+
+			"password, since the next apply sends it to SABnzbd.", req.ID, envVar),
+	)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("password"), password)...)
+}
+
+// serverPasswordEnvVar returns the environment variable ImportState reads the
+// password of the named server from: SABNZBD_SERVER_PASSWORD_ followed by the
+// name in upper case, with every character other than a letter or digit
+// replaced by an underscore.
+func serverPasswordEnvVar(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(name))
+
+	return "SABNZBD_SERVER_PASSWORD_" + sanitized
 }
@@ -0,0 +1,666 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testServerModel returns a fully populated server model.
+func testServerModel(name string) ServerResourceModel {
+	return ServerResourceModel{
+		Name:              types.StringValue(name),
+		Host:              types.StringValue("news.example.com"),
+		Port:              types.Int64Value(563),
+		Username:          types.StringValue("user"),
+		Password:          types.StringValue(""),
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: types.Int64Null(),
+		Connections:       types.Int64Value(8),
+		SSL:               types.BoolValue(true),
+		SSLVerify:         types.Int64Value(2),
+		SSLCiphers:        types.StringValue(""),
+		SSLCipherPreset:   types.StringNull(),
+		Enable:            types.BoolValue(true),
+		Optional:          types.BoolValue(false),
+		Retention:         types.Int64Value(0),
+		RetentionDays:     types.StringNull(),
+		Timeout:           types.Int64Value(60),
+		Priority:          types.Int64Value(0),
+		Required:          types.BoolValue(false),
+		Notes:             types.StringValue(""),
+		SendGroup:         types.BoolValue(false),
+		ExpireDate:        types.StringValue(""),
+		Quota:             types.StringValue(""),
+		Timeouts:          types.ObjectNull(timeoutsAttrTypes),
+	}
+}
+
+func TestSSLPortSuggestion(t *testing.T) {
+	cases := []struct {
+		name          string
+		oldSSL        bool
+		newSSL        bool
+		oldPort       int64
+		newPort       int64
+		wantSuggested int64
+		wantWarn      bool
+	}{
+		{"ssl disabled on 563", true, false, 563, 563, 119, true},
+		{"ssl enabled on 119", false, true, 119, 119, 563, true},
+		{"ssl disabled with port change", true, false, 563, 119, 0, false},
+		{"ssl disabled on custom port", true, false, 443, 443, 0, false},
+		{"ssl unchanged", true, true, 563, 563, 0, false},
+		{"ssl enabled on custom port", false, true, 8119, 8119, 0, false},
+	}
+
+	for _, tc := range cases {
+		suggested, warn := sslPortSuggestion(tc.oldSSL, tc.newSSL, tc.oldPort, tc.newPort)
+		if warn != tc.wantWarn || suggested != tc.wantSuggested {
+			t.Errorf("%s: expected (%d, %t), got (%d, %t)", tc.name, tc.wantSuggested, tc.wantWarn, suggested, warn)
+		}
+	}
+}
+
+func TestExceedsConnectionsWarn(t *testing.T) {
+	cases := []struct {
+		connections int64
+		threshold   int64
+		want        bool
+	}{
+		{8, 50, false},
+		{50, 50, false},
+		{800, 50, true},
+		{800, 0, false},
+		{120, 100, true},
+	}
+
+	for _, tc := range cases {
+		if got := exceedsConnectionsWarn(tc.connections, tc.threshold); got != tc.want {
+			t.Errorf("exceedsConnectionsWarn(%d, %d): expected %t, got %t", tc.connections, tc.threshold, tc.want, got)
+		}
+	}
+}
+
+func TestServerResourcePasswordWriteOnly(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	config := testServerModel("primary")
+	config.Password = types.StringNull()
+	config.PasswordWO = types.StringValue("s3cret")
+	config.PasswordWOVersion = types.Int64Value(1)
+
+	// Terraform always plans write-only attributes as null.
+	plan := config
+	plan.Password = types.StringValue("")
+	plan.PasswordWO = types.StringNull()
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan), Config: newConfig(t, s, &config)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if len(f.servers) != 1 || f.servers[0].Password != "s3cret" {
+		t.Fatalf("expected password_wo to be sent to SABnzbd, got %+v", f.servers)
+	}
+
+	var state ServerResourceModel
+	createResp.State.Get(ctx, &state)
+	if !state.PasswordWO.IsNull() {
+		t.Errorf("expected password_wo to be null in state, got %q", state.PasswordWO.ValueString())
+	}
+	if state.Password.ValueString() != "" {
+		t.Errorf("expected password to stay empty in state, got %q", state.Password.ValueString())
+	}
+}
+
+func TestServerResourceSwitchToPasswordWriteOnly(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "primary", Host: "news.example.com"}}
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	state := testServerModel("primary")
+	state.Password = types.StringValue("old-secret")
+
+	config := testServerModel("primary")
+	config.Password = types.StringNull()
+	config.PasswordWO = types.StringValue("new-secret")
+	config.PasswordWOVersion = types.Int64Value(1)
+
+	// The unset password is planned from state, and password_wo as null.
+	plan := config
+	plan.Password = state.Password
+	plan.PasswordWO = types.StringNull()
+
+	modifyReq := resource.ModifyPlanRequest{
+		Plan:   newPlan(t, s, &plan),
+		State:  newState(t, s, &state),
+		Config: newConfig(t, s, &config),
+	}
+	modifyResp := resource.ModifyPlanResponse{Plan: modifyReq.Plan}
+	r.ModifyPlan(ctx, modifyReq, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected plan diagnostics: %v", modifyResp.Diagnostics)
+	}
+
+	var planned ServerResourceModel
+	modifyResp.Plan.Get(ctx, &planned)
+	if planned.Password.ValueString() != "" {
+		t.Errorf("expected the old password to be planned away, got %q", planned.Password.ValueString())
+	}
+
+	updateResp := resource.UpdateResponse{State: newState(t, s, &state)}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:   modifyResp.Plan,
+		State:  newState(t, s, &state),
+		Config: newConfig(t, s, &config),
+	}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if f.servers[0].Password != "new-secret" {
+		t.Errorf("expected password_wo to be sent to SABnzbd, got %q", f.servers[0].Password)
+	}
+
+	var updated ServerResourceModel
+	updateResp.State.Get(ctx, &updated)
+	if updated.Password.ValueString() != "" || !updated.PasswordWO.IsNull() {
+		t.Errorf("expected no password in state, got password %q and password_wo %s",
+			updated.Password.ValueString(), updated.PasswordWO)
+	}
+}
+
+func TestServerResourcePasswordConflict(t *testing.T) {
+	r := &ServerResource{}
+	s := resourceSchema(t, r)
+
+	config := testServerModel("primary")
+	config.Password = types.StringValue("old")
+	config.PasswordWO = types.StringValue("new")
+
+	var resp resource.ValidateConfigResponse
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when both password and password_wo are set")
+	}
+}
+
+func TestServerResourceEnableRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	model := testServerModel("primary")
+	model.Enable = types.BoolValue(false)
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if f.servers[0].Enable != 0 {
+		t.Fatalf("expected SABnzbd to store enable=0, got %d", f.servers[0].Enable)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ServerResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Enable.ValueBool() {
+		t.Fatal("expected enable to read back as false")
+	}
+
+	model.Enable = types.BoolValue(true)
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	readResp = resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	readResp.State.Get(ctx, &read)
+	if !read.Enable.ValueBool() {
+		t.Fatal("expected enable to read back as true after re-enabling")
+	}
+}
+
+func TestServerResourceRenameInPlace(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	model := testServerModel("primary")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	renamed := testServerModel("main")
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &renamed), Config: newConfig(t, s, &renamed), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if len(f.servers) != 1 || f.servers[0].Name != "main" || f.servers[0].Host != "news.example.com" {
+		t.Fatalf("expected only the renamed server to remain, got %+v", f.servers)
+	}
+
+	var name types.String
+	updateResp.State.GetAttribute(ctx, path.Root("name"), &name)
+	if name.ValueString() != "main" {
+		t.Errorf("expected name main in state, got %s", name)
+	}
+}
+
+func TestServerResourceUnlimitedRetention(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	model := testServerModel("primary")
+	model.Retention = types.Int64Value(1000)
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Going back to unlimited must reach SABnzbd rather than being skipped
+	// as an empty value.
+	model.Retention = types.Int64Value(0)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if f.servers[0].Retention != 0 {
+		t.Fatalf("expected SABnzbd to store retention 0, got %d", f.servers[0].Retention)
+	}
+
+	readResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ServerResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Retention.IsNull() || read.Retention.ValueInt64() != 0 {
+		t.Errorf("expected retention 0 after read, got %s", read.Retention)
+	}
+}
+
+func TestServerResourceAdvancedFieldsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	model := testServerModel("primary")
+	model.SendGroup = types.BoolValue(true)
+	model.ExpireDate = types.StringValue("2027-01-31")
+	model.Quota = types.StringValue("500G")
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if got := f.servers[0]; got.SendGroup != 1 || got.ExpireDate != "2027-01-31" || got.Quota != "500G" {
+		t.Fatalf("expected SABnzbd to store the advanced fields, got send_group=%d expire_date=%q quota=%q",
+			got.SendGroup, got.ExpireDate, got.Quota)
+	}
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ServerResourceModel
+	readResp.State.Get(ctx, &read)
+	if !read.SendGroup.ValueBool() || read.ExpireDate.ValueString() != "2027-01-31" || read.Quota.ValueString() != "500G" {
+		t.Errorf("expected the advanced fields to round-trip, got send_group=%s expire_date=%s quota=%s",
+			read.SendGroup, read.ExpireDate, read.Quota)
+	}
+}
+
+func TestServerResourceValidateExpireDate(t *testing.T) {
+	r := &ServerResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]bool{
+		"":                          false,
+		"2027-01-31":                false,
+		"2027-01-31T12:00:00+01:00": false,
+		"2027-01-31T23:30:00Z":      false,
+		"31/01/2027":                true,
+		"2027-02-30":                true,
+		"2027-01-31T12:00:00":       true,
+	}
+	for date, wantErr := range cases {
+		config := testServerModel("primary")
+		config.ExpireDate = types.StringValue(date)
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("%q: expected error %t, got %v", date, wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestServerResourceExpireDateFormats(t *testing.T) {
+	ctx := context.Background()
+
+	for _, date := range []string{"2027-01-31", "2027-01-31T23:30:00-05:00"} {
+		f, c := newFakeSabnzbd(t)
+		r := &ServerResource{client: c}
+		s := resourceSchema(t, r)
+
+		model := testServerModel("primary")
+		model.ExpireDate = types.StringValue(date)
+
+		createResp := resource.CreateResponse{State: newState(t, s, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf("%q: unexpected create diagnostics: %v", date, createResp.Diagnostics)
+		}
+
+		// SABnzbd is sent the date alone, in the timestamp's own offset.
+		if got := f.servers[0].ExpireDate; got != "2027-01-31" {
+			t.Errorf("%q: expected SABnzbd to store 2027-01-31, got %q", date, got)
+		}
+
+		// The configured form stays in state, so there is no diff to plan.
+		readResp := resource.ReadResponse{State: createResp.State}
+		r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("%q: unexpected read diagnostics: %v", date, readResp.Diagnostics)
+		}
+
+		var read ServerResourceModel
+		readResp.State.Get(ctx, &read)
+		if read.ExpireDate.ValueString() != date {
+			t.Errorf("%q: expected expire_date to stay as configured, got %s", date, read.ExpireDate)
+		}
+	}
+}
+
+func TestServerResourceValidateCipherPreset(t *testing.T) {
+	r := &ServerResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		ciphers types.String
+		preset  types.String
+		wantErr bool
+	}{
+		"preset":         {ciphers: types.StringNull(), preset: types.StringValue("modern")},
+		"raw ciphers":    {ciphers: types.StringValue("HIGH"), preset: types.StringNull()},
+		"both":           {ciphers: types.StringValue("HIGH"), preset: types.StringValue("modern"), wantErr: true},
+		"unknown preset": {ciphers: types.StringNull(), preset: types.StringValue("paranoid"), wantErr: true},
+	}
+
+	for name, tc := range cases {
+		config := testServerModel("primary")
+		config.SSLCiphers = tc.ciphers
+		config.SSLCipherPreset = tc.preset
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestServerResourceModifyPlanCipherPreset(t *testing.T) {
+	r := &ServerResource{}
+	s := resourceSchema(t, r)
+
+	plan := testServerModel("primary")
+	plan.SSLCipherPreset = types.StringValue("compatible")
+	config := plan
+	config.SSLCiphers = types.StringNull()
+
+	req := resource.ModifyPlanRequest{
+		Plan:   newPlan(t, s, &plan),
+		State:  newState(t, s, nil),
+		Config: newConfig(t, s, &config),
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got ServerResourceModel
+	resp.Plan.Get(context.Background(), &got)
+	if got.SSLCiphers.ValueString() != client.SSLCipherPresets["compatible"] {
+		t.Errorf("expected ssl_ciphers from the compatible preset, got %s", got.SSLCiphers)
+	}
+}
+
+func TestServerResourceKeepsPlannedValues(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.serverConnectionsLimit = 50
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	// Terraform rejects an apply result that differs from a known planned
+	// value, so a value SABnzbd clamps is kept as planned and warned about.
+	model := testServerModel("primary")
+	model.Connections = types.Int64Value(100)
+
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+	if createResp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a warning about the clamped connections, got %v", createResp.Diagnostics)
+	}
+
+	var created ServerResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Connections.ValueInt64() != 100 || created.Username.ValueString() != "user" {
+		t.Errorf("expected the planned values to be kept, got %+v", created)
+	}
+
+	model.Connections = types.Int64Value(40)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, &model), Config: newConfig(t, s, &model), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() || updateResp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	var updated ServerResourceModel
+	updateResp.State.Get(ctx, &updated)
+	if updated.Connections.ValueInt64() != 40 {
+		t.Errorf("expected connections 40 after update, got %s", updated.Connections)
+	}
+}
+
+func TestAdoptStoredServerFillsUnknown(t *testing.T) {
+	model := testServerModel("primary")
+	model.Retention = types.Int64Unknown()
+
+	diags := adoptStoredServer(&model, &client.Server{
+		Name: "primary", Host: model.Host.ValueString(), Port: int(model.Port.ValueInt64()),
+		Connections: int(model.Connections.ValueInt64()), SSL: 1, SSLVerify: int(model.SSLVerify.ValueInt64()),
+		Enable: 1, Retention: 1200, Timeout: int(model.Timeout.ValueInt64()), Required: 0,
+	})
+	if model.Retention.ValueInt64() != 1200 {
+		t.Errorf("expected the unknown retention to take the stored 1200, got %s", model.Retention)
+	}
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics when the stored values match, got %v", diags)
+	}
+}
+
+func TestRequiredServerDestroyWarnings(t *testing.T) {
+	model := testServerModel("primary")
+	if diags := requiredServerDestroyWarnings(&model); len(diags) != 0 {
+		t.Errorf("expected no warning for an optional server, got %v", diags)
+	}
+
+	model.Required = types.BoolValue(true)
+	diags := requiredServerDestroyWarnings(&model)
+	if diags.WarningsCount() != 1 || diags.HasError() {
+		t.Errorf("expected a single warning for a required server, got %v", diags)
+	}
+}
+
+func TestServerResourceModifyPlanDestroyRequired(t *testing.T) {
+	r := &ServerResource{}
+	s := resourceSchema(t, r)
+
+	model := testServerModel("primary")
+	model.Required = types.BoolValue(true)
+
+	// A destroy plan is null; newState with a nil model builds exactly that.
+	destroy := newState(t, s, nil)
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: destroy.Raw},
+		State: newState(t, s, &model),
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a single warning when destroying a required server, got %v", resp.Diagnostics)
+	}
+}
+
+func TestServerPasswordEnvVar(t *testing.T) {
+	cases := map[string]string{
+		"primary":          "SABNZBD_SERVER_PASSWORD_PRIMARY",
+		"news.example.com": "SABNZBD_SERVER_PASSWORD_NEWS_EXAMPLE_COM",
+		"Backup-2":         "SABNZBD_SERVER_PASSWORD_BACKUP_2",
+	}
+
+	for name, want := range cases {
+		if got := serverPasswordEnvVar(name); got != want {
+			t.Errorf("%q: expected %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestServerResourceImportPassword(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "news.example.com", Host: "news.example.com"}}
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	// Without the variable, the import warns that the password is missing.
+	resp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "news.example.com"}, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	t.Setenv("SABNZBD_SERVER_PASSWORD_NEWS_EXAMPLE_COM", "secret")
+
+	// With it, the import warns that the password is now in state.
+	resp = resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "news.example.com"}, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 ||
+		resp.Diagnostics[0].Summary() != "Server Password Imported" {
+		t.Fatalf("expected a single imported password warning, got %v", resp.Diagnostics)
+	}
+
+	var password types.String
+	resp.State.GetAttribute(ctx, path.Root("password"), &password)
+	if password.ValueString() != "secret" {
+		t.Errorf("expected the password from the environment, got %s", password)
+	}
+}
+
+func TestServerResourceKeepsImportedPassword(t *testing.T) {
+	ctx := context.Background()
+	s := resourceSchema(t, &ServerResource{})
+
+	attr, ok := s.Attributes["password"].(schema.StringAttribute)
+	if !ok {
+		t.Fatalf("unexpected password attribute type %T", s.Attributes["password"])
+	}
+
+	imported := testServerModel("news.example.com")
+	imported.Password = types.StringValue("secret")
+
+	// A configuration without password plans it as unknown; the imported
+	// password in state must be planned instead of an empty one.
+	req := planmodifier.StringRequest{
+		Path:        path.Root("password"),
+		State:       newState(t, s, &imported),
+		ConfigValue: types.StringNull(),
+		StateValue:  types.StringValue("secret"),
+		PlanValue:   types.StringUnknown(),
+	}
+	resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+	for _, modifier := range attr.PlanModifiers {
+		modifier.PlanModifyString(ctx, req, &resp)
+	}
+	if resp.PlanValue.ValueString() != "secret" {
+		t.Errorf("expected the imported password to be kept, got %s", resp.PlanValue)
+	}
+}
+
+func TestServerResourceImportUnknown(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{{Name: "news.example.com", Host: "news.example.com"}}
+
+	r := &ServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	resp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "news.exmaple.com"}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error importing an unknown server")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Server Not Found" {
+		t.Errorf("expected a Server Not Found error, got %q", summary)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected no state for an unknown server")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerStatsDataSource{}
+
+func NewServerStatsDataSource() datasource.DataSource {
+	return &ServerStatsDataSource{}
+}
+
+// ServerStatsDataSource defines the data source implementation.
+type ServerStatsDataSource struct {
+	client *client.Client
+}
+
+// ServerStatsDataSourceModel describes the data source data model.
+type ServerStatsDataSourceModel struct {
+	ID      types.String                     `tfsdk:"id"`
+	Day     types.Int64                      `tfsdk:"day"`
+	Week    types.Int64                      `tfsdk:"week"`
+	Month   types.Int64                      `tfsdk:"month"`
+	Total   types.Int64                      `tfsdk:"total"`
+	Servers map[string]ServerStatsCountModel `tfsdk:"servers"`
+}
+
+// ServerStatsCountModel describes the byte counts of a single server.
+type ServerStatsCountModel struct {
+	Day   types.Int64 `tfsdk:"day"`
+	Week  types.Int64 `tfsdk:"week"`
+	Month types.Int64 `tfsdk:"month"`
+	Total types.Int64 `tfsdk:"total"`
+}
+
+func (d *ServerStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_stats"
+}
+
+func (d *ServerStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the number of bytes SABnzbd has downloaded, overall and per news server, " +
+			"for the current day, week and month and in total. Useful for monitoring usage of metered accounts.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"day": schema.Int64Attribute{
+				MarkdownDescription: "Bytes downloaded today across all servers.",
+				Computed:            true,
+			},
+			"week": schema.Int64Attribute{
+				MarkdownDescription: "Bytes downloaded this week across all servers.",
+				Computed:            true,
+			},
+			"month": schema.Int64Attribute{
+				MarkdownDescription: "Bytes downloaded this month across all servers.",
+				Computed:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Bytes downloaded in total across all servers.",
+				Computed:            true,
+			},
+			"servers": schema.MapNestedAttribute{
+				MarkdownDescription: "Byte counts per news server, keyed by server name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"day": schema.Int64Attribute{
+							MarkdownDescription: "Bytes downloaded from this server today.",
+							Computed:            true,
+						},
+						"week": schema.Int64Attribute{
+							MarkdownDescription: "Bytes downloaded from this server this week.",
+							Computed:            true,
+						},
+						"month": schema.Int64Attribute{
+							MarkdownDescription: "Bytes downloaded from this server this month.",
+							Computed:            true,
+						},
+						"total": schema.Int64Attribute{
+							MarkdownDescription: "Bytes downloaded from this server in total.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServerStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *ServerStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stats, err := d.client.GetServerStats(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read server stats", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-server-stats")
+	data.Day = types.Int64Value(stats.Day)
+	data.Week = types.Int64Value(stats.Week)
+	data.Month = types.Int64Value(stats.Month)
+	data.Total = types.Int64Value(stats.Total)
+	data.Servers = make(map[string]ServerStatsCountModel, len(stats.Servers))
+	for name, counts := range stats.Servers {
+		data.Servers[name] = ServerStatsCountModel{
+			Day:   types.Int64Value(counts.Day),
+			Week:  types.Int64Value(counts.Week),
+			Month: types.Int64Value(counts.Month),
+			Total: types.Int64Value(counts.Total),
+		}
+	}
+
+	tflog.Trace(ctx, "read server stats data source", map[string]interface{}{"servers": len(stats.Servers)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerTestDataSource{}
+
+func NewServerTestDataSource() datasource.DataSource {
+	return &ServerTestDataSource{}
+}
+
+// ServerTestDataSource defines the data source implementation.
+type ServerTestDataSource struct {
+	client *client.Client
+}
+
+// ServerTestDataSourceModel describes the data source data model.
+type ServerTestDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Reachable types.Bool   `tfsdk:"reachable"`
+	Message   types.String `tfsdk:"message"`
+}
+
+func (d *ServerTestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_test"
+}
+
+func (d *ServerTestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Asks SABnzbd to connect to a configured news server and reports whether it succeeded. " +
+			"The test runs on every read, so this can be used to check server connectivity on each plan. " +
+			"An unreachable server is reported through `reachable` rather than as an error.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source (same as `name`).",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the server to test.",
+				Required:            true,
+			},
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether SABnzbd connected to the server with its stored settings.",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The message SABnzbd reported for the test, e.g. why the connection failed. " +
+					"May be empty when the test succeeded.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ServerTestDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *ServerTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerTestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reachable, message, err := d.client.TestServer(ctx, data.Name.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Server Not Found",
+			fmt.Sprintf("No server named %q is configured in SABnzbd.", data.Name.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "test server", err)
+		return
+	}
+
+	data.ID = data.Name
+	data.Reachable = types.BoolValue(reachable)
+	data.Message = types.StringValue(message)
+
+	tflog.Trace(ctx, "read server test data source", map[string]interface{}{
+		"name":      data.Name.ValueString(),
+		"reachable": reachable,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestServerTestDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.servers = []client.Server{
+		{Name: "primary", Host: "news.example.com", Port: 563, SSL: 1},
+		{Name: "backup", Host: "backup.example.com", Port: 119},
+	}
+	f.serverTestErrors = map[string]string{"backup.example.com": "Authentication failed, check username/password."}
+
+	d := &ServerTestDataSource{client: c}
+	s := dataSourceSchema(t, d)
+
+	cases := map[string]struct {
+		reachable bool
+		message   string
+	}{
+		"primary": {true, "Connection Successful!"},
+		"backup":  {false, "Authentication failed, check username/password."},
+	}
+
+	for name, tc := range cases {
+		config := newDataSourceConfig(t, s, &ServerTestDataSourceModel{
+			ID:        types.StringNull(),
+			Name:      types.StringValue(name),
+			Reachable: types.BoolNull(),
+			Message:   types.StringNull(),
+		})
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+		}
+
+		var got ServerTestDataSourceModel
+		resp.State.Get(context.Background(), &got)
+		if got.Reachable.ValueBool() != tc.reachable || got.Message.ValueString() != tc.message {
+			t.Errorf("%s: expected (%t, %q), got (%s, %s)", name, tc.reachable, tc.message, got.Reachable, got.Message)
+		}
+	}
+
+	config := newDataSourceConfig(t, s, &ServerTestDataSourceModel{
+		ID:        types.StringNull(),
+		Name:      types.StringValue("missing"),
+		Reachable: types.BoolNull(),
+		Message:   types.StringNull(),
+	})
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for an unknown server")
+	}
+}
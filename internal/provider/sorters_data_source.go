@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SortersDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &SortersDataSource{}
+
+func NewSortersDataSource() datasource.DataSource {
+	return &SortersDataSource{}
+}
+
+// SortersDataSource defines the data source implementation.
+type SortersDataSource struct {
+	client *client.Client
+}
+
+// SortersDataSourceModel describes the data source data model.
+type SortersDataSourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	SortType types.String  `tfsdk:"sort_type"`
+	Sorters  []SorterModel `tfsdk:"sorters"`
+}
+
+// SorterModel describes a single sorter.
+type SorterModel struct {
+	Name       types.String   `tfsdk:"name"`
+	Order      types.Int64    `tfsdk:"order"`
+	SortString types.String   `tfsdk:"sort_string"`
+	Categories []types.String `tfsdk:"categories"`
+	SortTypes  []types.String `tfsdk:"sort_types"`
+	IsActive   types.Bool     `tfsdk:"is_active"`
+}
+
+func (d *SortersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sorters"
+}
+
+func (d *SortersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the sorters SABnzbd uses to rename and move completed downloads. Sorters " +
+			"replaced the TV, movie and date sorting settings in SABnzbd " + client.SortersVersion + "; use " +
+			"`sabnzbd_legacy_sorting` for older versions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"sort_type": schema.StringAttribute{
+				MarkdownDescription: "Only list sorters that apply to this type of download, or to `all`. Values: " +
+					"`" + strings.Join(client.SortTypeNames(), "`, `") + "`.",
+				Optional: true,
+			},
+			"sorters": schema.ListNestedAttribute{
+				MarkdownDescription: "The sorters, in the order SABnzbd lists them. Empty when there are none.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the sorter.",
+							Computed:            true,
+						},
+						"order": schema.Int64Attribute{
+							MarkdownDescription: "The position in which SABnzbd tries the sorter.",
+							Computed:            true,
+						},
+						"sort_string": schema.StringAttribute{
+							MarkdownDescription: "The pattern completed downloads are renamed and moved with.",
+							Computed:            true,
+						},
+						"categories": schema.ListAttribute{
+							MarkdownDescription: "The categories the sorter applies to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"sort_types": schema.ListAttribute{
+							MarkdownDescription: "The types of download the sorter applies to, by name, e.g. `series` or `movie`.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"is_active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the sorter is used.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SortersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data SortersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SortType.IsNull() || data.SortType.IsUnknown() {
+		return
+	}
+
+	if _, err := client.ParseSortTypes([]string{data.SortType.ValueString()}); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("sort_type"), "Invalid Sort Type", err.Error())
+	}
+}
+
+func (d *SortersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SortersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SortersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sorters, err := d.client.GetSorters(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read sorters", err)
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-sorters")
+
+	// Non-nil slices, so no sorters (or categories) is an empty list rather
+	// than null.
+	data.Sorters = []SorterModel{}
+	for _, sorter := range sorters {
+		names, err := client.FormatSortTypes(sorter.SortType)
+		if err != nil {
+			resp.Diagnostics.AddError("Unknown Sort Type",
+				fmt.Sprintf("Sorter %q uses a sort type this provider does not know: %s.", sorter.Name, err))
+			return
+		}
+
+		if !data.SortType.IsNull() && !sorterAppliesTo(names, data.SortType.ValueString()) {
+			continue
+		}
+
+		categories := make([]types.String, len(sorter.SortCats))
+		for i, cat := range sorter.SortCats {
+			categories[i] = types.StringValue(cat)
+		}
+		sortTypes := make([]types.String, len(names))
+		for i, name := range names {
+			sortTypes[i] = types.StringValue(name)
+		}
+
+		data.Sorters = append(data.Sorters, SorterModel{
+			Name:       types.StringValue(sorter.Name),
+			Order:      types.Int64Value(int64(sorter.Order)),
+			SortString: types.StringValue(sorter.SortString),
+			Categories: categories,
+			SortTypes:  sortTypes,
+			IsActive:   types.BoolValue(sorter.IsActive == 1),
+		})
+	}
+
+	tflog.Trace(ctx, "read sorters data source", map[string]interface{}{"count": len(data.Sorters)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sorterAppliesTo reports whether a sorter with the named sort types handles
+// downloads of sortType.
+func sorterAppliesTo(names []string, sortType string) bool {
+	return slices.Contains(names, sortType) || slices.Contains(names, "all")
+}
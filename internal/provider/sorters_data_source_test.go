@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSortersDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.sorters = []client.Sorter{
+		{Name: "tv", Order: 0, SortString: "%sn/Season %s/%sn - %sx%0e", SortCats: []string{"tv"}, SortType: []int{client.SortTypeSeries}, IsActive: 1},
+		{Name: "movies", Order: 1, SortString: "%title (%y)/%title", SortCats: []string{"movies"}, SortType: []int{client.SortTypeMovie}, IsActive: 1},
+		{Name: "everything", Order: 2, SortString: "%dn", SortCats: []string{}, SortType: []int{client.SortTypeAll}, IsActive: 0},
+	}
+
+	d := &SortersDataSource{client: c}
+	s := dataSourceSchema(t, d)
+
+	cases := map[string]struct {
+		sortType types.String
+		want     []string
+	}{
+		"all":    {types.StringNull(), []string{"tv", "movies", "everything"}},
+		"series": {types.StringValue("series"), []string{"tv", "everything"}},
+	}
+
+	for name, tc := range cases {
+		config := newDataSourceConfig(t, s, &SortersDataSourceModel{ID: types.StringNull(), SortType: tc.sortType})
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+		d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+		}
+
+		var got SortersDataSourceModel
+		resp.State.Get(ctx, &got)
+
+		var names []string
+		for _, sorter := range got.Sorters {
+			names = append(names, sorter.Name.ValueString())
+		}
+		if !reflect.DeepEqual(names, tc.want) {
+			t.Errorf("%s: expected sorters %v, got %v", name, tc.want, names)
+		}
+		if len(got.Sorters) > 0 && !reflect.DeepEqual(got.Sorters[0].SortTypes, []types.String{types.StringValue("series")}) {
+			t.Errorf("%s: expected sort types by name, got %v", name, got.Sorters[0].SortTypes)
+		}
+	}
+}
+
+func TestSortersDataSourceValidateSortType(t *testing.T) {
+	d := &SortersDataSource{}
+	s := dataSourceSchema(t, d)
+
+	for sortType, wantErr := range map[string]bool{"movie": false, "all": false, "tv": true} {
+		config := newDataSourceConfig(t, s, &SortersDataSourceModel{ID: types.StringNull(), SortType: types.StringValue(sortType)})
+
+		var resp datasource.ValidateConfigResponse
+		d.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("%q: expected error %t, got %v", sortType, wantErr, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &StatusDataSource{}
+
+func NewStatusDataSource() datasource.DataSource {
+	return &StatusDataSource{}
+}
+
+// StatusDataSource defines the data source implementation.
+type StatusDataSource struct {
+	client *client.Client
+}
+
+// StatusDataSourceModel describes the data source data model.
+type StatusDataSourceModel struct {
+	ID            types.String  `tfsdk:"id"`
+	Version       types.String  `tfsdk:"version"`
+	Paused        types.Bool    `tfsdk:"paused"`
+	PausedAll     types.Bool    `tfsdk:"paused_all"`
+	PauseReason   types.String  `tfsdk:"pause_reason"`
+	PauseTimeLeft types.String  `tfsdk:"pause_time_left"`
+	Uptime        types.String  `tfsdk:"uptime"`
+	KBPerSec      types.Float64 `tfsdk:"kbpersec"`
+	MBLeft        types.Float64 `tfsdk:"mbleft"`
+	TimeLeft      types.String  `tfsdk:"timeleft"`
+	WarningsCount types.Int64   `tfsdk:"warnings_count"`
+	HasWarnings   types.Bool    `tfsdk:"has_warnings"`
+}
+
+func (d *StatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_status"
+}
+
+func (d *StatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the current state of SABnzbd, including uptime, download speed and the " +
+			"amount of data left in the queue, for use in monitoring outputs.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of SABnzbd.",
+				Computed:            true,
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Whether the download queue is paused.",
+				Computed:            true,
+			},
+			"paused_all": schema.BoolAttribute{
+				MarkdownDescription: "Whether post-processing is paused along with the download queue.",
+				Computed:            true,
+			},
+			"pause_reason": schema.StringAttribute{
+				MarkdownDescription: "Why the download queue is paused: `none` when it is running, `timed` when it " +
+					"was paused for a set time and resumes by itself, or `manual` when it stays paused until resumed. " +
+					"SABnzbd does not report whether a user or the scheduler paused it, so both are `manual`.",
+				Computed: true,
+			},
+			"pause_time_left": schema.StringAttribute{
+				MarkdownDescription: "The time until a timed pause ends, as reported (e.g. `12:30`). Empty unless " +
+					"`pause_reason` is `timed`.",
+				Computed: true,
+			},
+			"uptime": schema.StringAttribute{
+				MarkdownDescription: "How long SABnzbd has been running, as reported (e.g. `2d`).",
+				Computed:            true,
+			},
+			"kbpersec": schema.Float64Attribute{
+				MarkdownDescription: "The current download speed in KB/s.",
+				Computed:            true,
+			},
+			"mbleft": schema.Float64Attribute{
+				MarkdownDescription: "The amount of data left to download in the queue, in MB.",
+				Computed:            true,
+			},
+			"timeleft": schema.StringAttribute{
+				MarkdownDescription: "The estimated time until the queue is finished, as reported (e.g. `0:12:30`).",
+				Computed:            true,
+			},
+			"warnings_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of warnings SABnzbd has logged and not yet cleared.",
+				Computed:            true,
+			},
+			"has_warnings": schema.BoolAttribute{
+				MarkdownDescription: "Whether SABnzbd has any uncleared warnings.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *StatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *StatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := d.client.GetStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read status", err)
+		return
+	}
+
+	warnings, err := client.ParseWarningsCount(status.HaveWarnings)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse have_warnings, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("sabnzbd-status")
+	data.Version = types.StringValue(status.Version)
+	data.Paused = types.BoolValue(status.Paused)
+	data.PausedAll = types.BoolValue(status.PausedAll)
+	data.PauseReason = types.StringValue(status.PauseReason())
+	data.PauseTimeLeft = types.StringValue(status.PauseTimeLeft())
+	data.Uptime = types.StringValue(status.Uptime)
+	data.KBPerSec = types.Float64Value(float64(status.KBPerSec))
+	data.MBLeft = types.Float64Value(float64(status.MBLeft))
+	data.TimeLeft = types.StringValue(status.TimeLeft)
+	data.WarningsCount = types.Int64Value(warnings)
+	data.HasWarnings = types.BoolValue(warnings > 0)
+
+	tflog.Trace(ctx, "read status data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStatusDataSourceRead(t *testing.T) {
+	f, c := newFakeSabnzbd(t)
+	f.status["uptime"] = "3h"
+	f.status["kbpersec"] = "1024.50" // SABnzbd sends the speed as a string
+	f.status["mbleft"] = 250.75
+	f.status["timeleft"] = "0:04:10"
+	f.status["have_warnings"] = "2"
+
+	d := &StatusDataSource{client: c}
+	s := dataSourceSchema(t, d)
+	config := newDataSourceConfig(t, s, &StatusDataSourceModel{
+		ID:            types.StringNull(),
+		Version:       types.StringNull(),
+		Paused:        types.BoolNull(),
+		PausedAll:     types.BoolNull(),
+		PauseReason:   types.StringNull(),
+		PauseTimeLeft: types.StringNull(),
+		Uptime:        types.StringNull(),
+		KBPerSec:      types.Float64Null(),
+		MBLeft:        types.Float64Null(),
+		TimeLeft:      types.StringNull(),
+		WarningsCount: types.Int64Null(),
+		HasWarnings:   types.BoolNull(),
+	})
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got StatusDataSourceModel
+	resp.State.Get(context.Background(), &got)
+
+	if got.Version.ValueString() != "4.3.2" || got.Paused.ValueBool() {
+		t.Errorf("unexpected version/paused: %s, %s", got.Version, got.Paused)
+	}
+	if got.PauseReason.ValueString() != "none" || got.PauseTimeLeft.ValueString() != "" {
+		t.Errorf("unexpected pause_reason/pause_time_left: %s, %s", got.PauseReason, got.PauseTimeLeft)
+	}
+	if got.Uptime.ValueString() != "3h" || got.TimeLeft.ValueString() != "0:04:10" {
+		t.Errorf("unexpected uptime/timeleft: %s, %s", got.Uptime, got.TimeLeft)
+	}
+	if got.WarningsCount.ValueInt64() != 2 || !got.HasWarnings.ValueBool() {
+		t.Errorf("unexpected warnings_count/has_warnings: %s, %s", got.WarningsCount, got.HasWarnings)
+	}
+	// Float64 values hold pointers, so compare the plain numbers.
+	if got.KBPerSec.ValueFloat64() != 1024.5 || got.MBLeft.ValueFloat64() != 250.75 {
+		t.Errorf("unexpected kbpersec/mbleft: %s, %s", got.KBPerSec, got.MBLeft)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SwitchesResource{}
+var _ resource.ResourceWithImportState = &SwitchesResource{}
+var _ resource.ResourceWithValidateConfig = &SwitchesResource{}
+
+// switchesID is the ID of the switches singleton.
+const switchesID = "switches"
+
+func NewSwitchesResource() resource.Resource {
+	return &SwitchesResource{}
+}
+
+// SwitchesResource defines the resource implementation.
+type SwitchesResource struct {
+	client *client.Client
+}
+
+// SwitchesResourceModel describes the resource data model.
+type SwitchesResourceModel struct {
+	ID                         types.String `tfsdk:"id"`
+	DeobfuscateFinalFilenames  types.Bool   `tfsdk:"deobfuscate_final_filenames"`
+	DirectUnpack               types.Bool   `tfsdk:"direct_unpack"`
+	UnwantedExtensions         types.List   `tfsdk:"unwanted_extensions"`
+	ActionOnUnwantedExtensions types.String `tfsdk:"action_on_unwanted_extensions"`
+}
+
+func (r *SwitchesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switches"
+}
+
+func (r *SwitchesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	defaults := client.DefaultSwitches
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages download and post-processing switches from SABnzbd's misc settings. " +
+			"This is a singleton resource; only one should exist per SABnzbd instance. " +
+			"Unset attributes are reset to SABnzbd's defaults.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The switches configuration identifier. Always `switches`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deobfuscate_final_filenames": schema.BoolAttribute{
+				MarkdownDescription: "Whether to rename obfuscated files to the job name after post-processing. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(defaults.DeobfuscateFinalFilenames == 1),
+			},
+			"direct_unpack": schema.BoolAttribute{
+				MarkdownDescription: "Whether to unpack archives while the job is still downloading. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(defaults.DirectUnpack == 1),
+			},
+			"unwanted_extensions": schema.ListAttribute{
+				MarkdownDescription: "File extensions, without the dot, that trigger `action_on_unwanted_extensions`, " +
+					"e.g. `exe` and `com`. Defaults to none.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, nil)),
+			},
+			"action_on_unwanted_extensions": schema.StringAttribute{
+				MarkdownDescription: "What to do with a job containing a file with an unwanted extension. Values: " +
+					"`" + strings.Join(client.UnwantedExtensionActions, "`, `") + "`. Defaults to `off`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.UnwantedExtensionActions[defaults.ActionOnUnwantedExtensions]),
+			},
+		},
+	}
+}
+
+func (r *SwitchesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SwitchesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	action := data.ActionOnUnwantedExtensions
+	if !action.IsNull() && !action.IsUnknown() && !slices.Contains(client.UnwantedExtensionActions, action.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("action_on_unwanted_extensions"),
+			"Invalid Unwanted Extensions Action",
+			fmt.Sprintf("%q is not a known action. Valid actions are: %s.",
+				action.ValueString(), strings.Join(client.UnwantedExtensionActions, ", ")),
+		)
+	}
+
+	if data.UnwantedExtensions.IsNull() || data.UnwantedExtensions.IsUnknown() {
+		return
+	}
+
+	var extensions []types.String
+	resp.Diagnostics.Append(data.UnwantedExtensions.ElementsAs(ctx, &extensions, false)...)
+	for _, extension := range extensions {
+		if extension.IsUnknown() {
+			continue
+		}
+		// SABnzbd splits the list on commas and matches extensions without
+		// the dot.
+		if v := extension.ValueString(); v == "" || strings.HasPrefix(v, ".") || strings.Contains(v, ",") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("unwanted_extensions"),
+				"Invalid Unwanted Extension",
+				fmt.Sprintf("Extensions must be non-empty, without a leading dot or commas, got %q.", v),
+			)
+		}
+	}
+}
+
+func (r *SwitchesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SwitchesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SwitchesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := switchesInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetSwitches(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "create switches configuration", err)
+		return
+	}
+
+	switches, err := r.client.GetSwitches(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read switches configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setSwitchesModel(&data, switches)...)
+
+	data.ID = types.StringValue(switchesID)
+	tflog.Trace(ctx, "created switches resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwitchesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SwitchesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switches, err := r.client.GetSwitches(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read switches configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setSwitchesModel(&data, switches)...)
+
+	data.ID = types.StringValue(switchesID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwitchesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SwitchesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := switchesInputFromModel(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetSwitches(ctx, input); err != nil {
+		addClientError(&resp.Diagnostics, "update switches configuration", err)
+		return
+	}
+
+	switches, err := r.client.GetSwitches(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read switches configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(setSwitchesModel(&data, switches)...)
+
+	data.ID = types.StringValue(switchesID)
+	tflog.Trace(ctx, "updated switches resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SwitchesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The switches cannot be deleted, so they are kept and the resource is
+	// only removed from state.
+	tflog.Trace(ctx, "deleted switches resource from state")
+}
+
+func (r *SwitchesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), switchesID)...)
+}
+
+// switchesInputFromModel converts the resource model into a client input.
+func switchesInputFromModel(ctx context.Context, data *SwitchesResourceModel) (*client.SwitchesInput, diag.Diagnostics) {
+	var extensions []string
+	diags := data.UnwantedExtensions.ElementsAs(ctx, &extensions, false)
+
+	return &client.SwitchesInput{
+		DeobfuscateFinalFilenames:  data.DeobfuscateFinalFilenames.ValueBool(),
+		DirectUnpack:               data.DirectUnpack.ValueBool(),
+		UnwantedExtensions:         extensions,
+		ActionOnUnwantedExtensions: slices.Index(client.UnwantedExtensionActions, data.ActionOnUnwantedExtensions.ValueString()),
+	}, diags
+}
+
+// setSwitchesModel copies the switches SABnzbd stores into data. An action
+// value the provider does not know is reported rather than stored.
+func setSwitchesModel(data *SwitchesResourceModel, switches *client.Switches) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.DeobfuscateFinalFilenames = types.BoolValue(switches.DeobfuscateFinalFilenames == 1)
+	data.DirectUnpack = types.BoolValue(switches.DirectUnpack == 1)
+
+	extensions, d := types.ListValueFrom(context.Background(), types.StringType, switches.UnwantedExtensions)
+	diags.Append(d...)
+	data.UnwantedExtensions = extensions
+
+	action := switches.ActionOnUnwantedExtensions
+	if action < 0 || action >= len(client.UnwantedExtensionActions) {
+		diags.AddAttributeError(path.Root("action_on_unwanted_extensions"), "Unknown Unwanted Extensions Action",
+			fmt.Sprintf("SABnzbd reports action_on_unwanted_extensions = %d, which this provider does not support.", action))
+		return diags
+	}
+	data.ActionOnUnwantedExtensions = types.StringValue(client.UnwantedExtensionActions[action])
+
+	return diags
+}
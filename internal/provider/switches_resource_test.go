@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSwitchesModel returns a fully populated switches model.
+func testSwitchesModel(extensions ...string) SwitchesResourceModel {
+	values := make([]attr.Value, len(extensions))
+	for i, extension := range extensions {
+		values[i] = types.StringValue(extension)
+	}
+
+	return SwitchesResourceModel{
+		ID:                         types.StringUnknown(),
+		DeobfuscateFinalFilenames:  types.BoolValue(false),
+		DirectUnpack:               types.BoolValue(true),
+		UnwantedExtensions:         types.ListValueMust(types.StringType, values),
+		ActionOnUnwantedExtensions: types.StringValue("abort"),
+	}
+}
+
+func TestSwitchesResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &SwitchesResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testSwitchesModel("exe", "com")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	want := map[string]interface{}{
+		"deobfuscate_final_filenames":   "0",
+		"direct_unpack":                 "1",
+		"unwanted_extensions":           "exe,com",
+		"action_on_unwanted_extensions": "2",
+	}
+	for key, value := range want {
+		if f.misc[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, f.misc[key])
+		}
+	}
+
+	// SABnzbd returns lists as JSON arrays and flags as numbers.
+	f.misc["unwanted_extensions"] = []interface{}{"exe", "com", "scr"}
+	f.misc["direct_unpack"] = 0
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read SwitchesResourceModel
+	readResp.State.Get(ctx, &read)
+
+	var extensions []string
+	read.UnwantedExtensions.ElementsAs(ctx, &extensions, false)
+	if !reflect.DeepEqual(extensions, []string{"exe", "com", "scr"}) {
+		t.Errorf("expected the extensions changed outside Terraform, got %v", extensions)
+	}
+	if read.ID.ValueString() != switchesID || read.DirectUnpack.ValueBool() || read.ActionOnUnwantedExtensions.ValueString() != "abort" {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+}
+
+func TestSwitchesResourceUnknownAction(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc["action_on_unwanted_extensions"] = "7"
+
+	r := &SwitchesResource{client: c}
+	s := resourceSchema(t, r)
+
+	state := testSwitchesModel()
+	state.ID = types.StringValue(switchesID)
+	readResp := resource.ReadResponse{State: newState(t, s, &state)}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, &readResp)
+	if !readResp.Diagnostics.HasError() {
+		t.Error("expected an error for an unknown action")
+	}
+}
+
+func TestSwitchesResourceValidateConfig(t *testing.T) {
+	r := &SwitchesResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		extensions []string
+		action     string
+		wantErr    bool
+	}{
+		"valid":          {extensions: []string{"exe", "com"}, action: "pause"},
+		"no extensions":  {action: "off"},
+		"leading dot":    {extensions: []string{".exe"}, action: "pause", wantErr: true},
+		"comma":          {extensions: []string{"exe,com"}, action: "pause", wantErr: true},
+		"empty":          {extensions: []string{""}, action: "pause", wantErr: true},
+		"unknown action": {action: "delete", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		config := testSwitchesModel(tc.extensions...)
+		config.ActionOnUnwantedExtensions = types.StringValue(tc.action)
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// defaultOperationTimeout bounds a resource operation when the timeouts
+// attribute does not set one. Keep the documented default in
+// timeoutsAttribute and the Timeouts section of the resource docs in sync.
+const defaultOperationTimeout = 5 * time.Minute
+
+// Operations that can be given a timeout.
+const (
+	timeoutCreate = "create"
+	timeoutRead   = "read"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// timeoutsAttrTypes are the attribute types of the timeouts object.
+var timeoutsAttrTypes = map[string]attr.Type{
+	timeoutCreate: types.StringType,
+	timeoutRead:   types.StringType,
+	timeoutUpdate: types.StringType,
+	timeoutDelete: types.StringType,
+}
+
+// timeoutsModel describes the timeouts attribute.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsAttribute returns the optional timeouts attribute, which follows
+// the shape of the terraform-plugin-framework-timeouts module. That module
+// is not used because it is not available to this build: the module proxy
+// the provider is built against does not serve it. The attribute names and
+// duration syntax match timeouts.Attributes, so switching to it later does
+// not change configurations or state.
+func timeoutsAttribute() schema.SingleNestedAttribute {
+	description := func(operation string) string {
+		return fmt.Sprintf("How long to wait for the %s operation, as a duration string such as `30s` or `10m`. "+
+			"Defaults to `5m`.", operation)
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Per-operation timeouts. Each API request is additionally limited to 30 seconds.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			timeoutCreate: schema.StringAttribute{
+				MarkdownDescription: description(timeoutCreate),
+				Optional:            true,
+			},
+			timeoutRead: schema.StringAttribute{
+				MarkdownDescription: description(timeoutRead),
+				Optional:            true,
+			},
+			timeoutUpdate: schema.StringAttribute{
+				MarkdownDescription: description(timeoutUpdate),
+				Optional:            true,
+			},
+			timeoutDelete: schema.StringAttribute{
+				MarkdownDescription: description(timeoutDelete),
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// withOperationTimeout derives a context bounded by the timeout configured
+// for operation in timeouts, or defaultOperationTimeout if none is set.
+func withOperationTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	timeout, diags := operationTimeout(ctx, timeouts, operation)
+	if diags.HasError() {
+		return ctx, func() {}, diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}
+
+// operationTimeout returns the timeout configured for operation in timeouts,
+// or defaultOperationTimeout if none is set.
+func operationTimeout(ctx context.Context, timeouts types.Object, operation string) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultOperationTimeout, diags
+	}
+
+	var data timeoutsModel
+	diags.Append(timeouts.As(ctx, &data, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	value := map[string]types.String{
+		timeoutCreate: data.Create,
+		timeoutRead:   data.Read,
+		timeoutUpdate: data.Update,
+		timeoutDelete: data.Delete,
+	}[operation]
+	if value.IsNull() || value.IsUnknown() {
+		return defaultOperationTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid Timeout",
+			fmt.Sprintf("%q is not a valid positive duration such as \"30s\" or \"10m\".", value.ValueString()),
+		)
+		return 0, diags
+	}
+
+	return timeout, diags
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testTimeouts(t *testing.T, create, read string) types.Object {
+	t.Helper()
+
+	value := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+
+	obj, diags := types.ObjectValue(timeoutsAttrTypes, map[string]attr.Value{
+		timeoutCreate: value(create),
+		timeoutRead:   value(read),
+		timeoutUpdate: types.StringNull(),
+		timeoutDelete: types.StringNull(),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return obj
+}
+
+func TestOperationTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		timeouts  types.Object
+		operation string
+		want      time.Duration
+	}{
+		{"null timeouts", types.ObjectNull(timeoutsAttrTypes), timeoutCreate, defaultOperationTimeout},
+		{"configured create", testTimeouts(t, "90s", ""), timeoutCreate, 90 * time.Second},
+		{"configured read", testTimeouts(t, "", "10m"), timeoutRead, 10 * time.Minute},
+		{"unset operation", testTimeouts(t, "90s", ""), timeoutDelete, defaultOperationTimeout},
+	}
+
+	for _, tc := range cases {
+		got, diags := operationTimeout(ctx, tc.timeouts, tc.operation)
+		if diags.HasError() {
+			t.Errorf("%s: unexpected diagnostics: %v", tc.name, diags)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+
+	for _, invalid := range []string{"soon", "-1m", "0s"} {
+		if _, diags := operationTimeout(ctx, testTimeouts(t, invalid, ""), timeoutCreate); !diags.HasError() {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	ctx, cancel, diags := withOperationTimeout(context.Background(), testTimeouts(t, "1m", ""), timeoutCreate)
+	defer cancel()
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected the context to have a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > time.Minute || remaining < 50*time.Second {
+		t.Errorf("expected a deadline about 1m away, got %s", remaining)
+	}
+}
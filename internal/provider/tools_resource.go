@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ToolsResource{}
+var _ resource.ResourceWithImportState = &ToolsResource{}
+var _ resource.ResourceWithValidateConfig = &ToolsResource{}
+
+// toolsID is the ID of the tools singleton.
+const toolsID = "tools"
+
+func NewToolsResource() resource.Resource {
+	return &ToolsResource{}
+}
+
+// ToolsResource defines the resource implementation.
+type ToolsResource struct {
+	client *client.Client
+}
+
+// ToolsResourceModel describes the resource data model.
+type ToolsResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Par2Path     types.String `tfsdk:"par2_path"`
+	UnrarPath    types.String `tfsdk:"unrar_path"`
+	SevenZipPath types.String `tfsdk:"sevenzip_path"`
+}
+
+// toolPathAttributes maps the path attributes to their misc keys.
+var toolPathAttributes = map[string]string{
+	"par2_path":     client.Par2PathKey,
+	"unrar_path":    client.UnrarPathKey,
+	"sevenzip_path": client.SevenZipPathKey,
+}
+
+func (r *ToolsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tools"
+}
+
+func (r *ToolsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the paths of the external tools SABnzbd runs for repair and unpacking, " +
+			"e.g. when a container image installs them outside the default locations. This is a singleton resource; " +
+			"only one should exist per SABnzbd instance. Paths are on the SABnzbd host and are not checked for existence. " +
+			"Setting a path that the running SABnzbd version does not support is an error.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The tools configuration identifier. Always `tools`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"par2_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the par2 executable. Leave empty to let SABnzbd find it.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"unrar_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the unrar executable. Leave empty to let SABnzbd find it.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"sevenzip_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the 7-Zip executable. Leave empty to let SABnzbd find it.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+func (r *ToolsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ToolsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attribute, value := range toolPathValues(&data) {
+		// SABnzbd resolves relative paths against its working directory,
+		// which is rarely what was meant.
+		if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" && !client.IsAbsolutePath(value.ValueString()) {
+			resp.Diagnostics.AddAttributeWarning(path.Root(attribute), "Relative Tool Path",
+				fmt.Sprintf("%q is not an absolute path; SABnzbd may not find the executable.", value.ValueString()))
+		}
+	}
+}
+
+func (r *ToolsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ToolsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ToolsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetTools(ctx, toolsInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "create tools configuration", err)
+		return
+	}
+
+	tools, err := r.client.GetTools(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tools configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(unsupportedToolPaths(&data, tools)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setToolsModel(&data, tools)
+
+	data.ID = types.StringValue(toolsID)
+	tflog.Trace(ctx, "created tools resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ToolsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tools, err := r.client.GetTools(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tools configuration", err)
+		return
+	}
+	setToolsModel(&data, tools)
+
+	// Imported state has no value for paths SABnzbd does not support.
+	data.ID = types.StringValue(toolsID)
+	for _, value := range []*types.String{&data.Par2Path, &data.UnrarPath, &data.SevenZipPath} {
+		if value.IsNull() {
+			*value = types.StringValue("")
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ToolsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetTools(ctx, toolsInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "update tools configuration", err)
+		return
+	}
+
+	tools, err := r.client.GetTools(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tools configuration", err)
+		return
+	}
+	resp.Diagnostics.Append(unsupportedToolPaths(&data, tools)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setToolsModel(&data, tools)
+
+	data.ID = types.StringValue(toolsID)
+	tflog.Trace(ctx, "updated tools resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ToolsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Tool paths cannot be deleted, so they are kept and the resource is
+	// only removed from state.
+	tflog.Trace(ctx, "deleted tools resource from state")
+}
+
+func (r *ToolsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), toolsID)...)
+}
+
+// toolPathValues returns the path attributes of data by attribute name.
+func toolPathValues(data *ToolsResourceModel) map[string]types.String {
+	return map[string]types.String{
+		"par2_path":     data.Par2Path,
+		"unrar_path":    data.UnrarPath,
+		"sevenzip_path": data.SevenZipPath,
+	}
+}
+
+// toolsInputFromModel converts the resource model into a client input.
+func toolsInputFromModel(data *ToolsResourceModel) *client.ToolsInput {
+	return &client.ToolsInput{
+		Par2Path:     data.Par2Path.ValueString(),
+		UnrarPath:    data.UnrarPath.ValueString(),
+		SevenZipPath: data.SevenZipPath.ValueString(),
+	}
+}
+
+// unsupportedToolPaths reports the paths set in data that SABnzbd ignored
+// because it does not know their setting.
+func unsupportedToolPaths(data *ToolsResourceModel, tools *client.Tools) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for attribute, value := range toolPathValues(data) {
+		if key := toolPathAttributes[attribute]; value.ValueString() != "" && tools.Unsupported[key] {
+			diags.AddAttributeError(path.Root(attribute), "Tool Path Not Supported",
+				fmt.Sprintf("SABnzbd does not have a %s setting, so the path has no effect. "+
+					"Remove %s or upgrade SABnzbd.", key, attribute))
+		}
+	}
+
+	return diags
+}
+
+// setToolsModel copies the tool paths SABnzbd stores into data. Paths
+// SABnzbd does not support are left alone.
+func setToolsModel(data *ToolsResourceModel, tools *client.Tools) {
+	if !tools.Unsupported[client.Par2PathKey] {
+		data.Par2Path = types.StringValue(tools.Par2Path)
+	}
+	if !tools.Unsupported[client.UnrarPathKey] {
+		data.UnrarPath = types.StringValue(tools.UnrarPath)
+	}
+	if !tools.Unsupported[client.SevenZipPathKey] {
+		data.SevenZipPath = types.StringValue(tools.SevenZipPath)
+	}
+}
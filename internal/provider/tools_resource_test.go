@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testToolsModel returns a tools model with only par2_path set.
+func testToolsModel(par2Path string) ToolsResourceModel {
+	return ToolsResourceModel{
+		ID:           types.StringUnknown(),
+		Par2Path:     types.StringValue(par2Path),
+		UnrarPath:    types.StringValue(""),
+		SevenZipPath: types.StringValue(""),
+	}
+}
+
+func TestToolsResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+
+	r := &ToolsResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testToolsModel("/usr/local/bin/par2")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if f.misc[client.Par2PathKey] != "/usr/local/bin/par2" || f.misc[client.UnrarPathKey] != "" {
+		t.Errorf("unexpected stored paths: %v", f.misc)
+	}
+
+	f.misc[client.UnrarPathKey] = "/opt/bin/unrar"
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read ToolsResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ID.ValueString() != toolsID || read.UnrarPath.ValueString() != "/opt/bin/unrar" {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+}
+
+func TestToolsResourceUnsupportedPath(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.unknownMisc = map[string]bool{client.Par2PathKey: true}
+
+	r := &ToolsResource{client: c}
+	s := resourceSchema(t, r)
+
+	// Leaving an unsupported path empty is fine.
+	plan := testToolsModel("")
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	plan = testToolsModel("/usr/local/bin/par2")
+	createResp = resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a path SABnzbd ignores")
+	}
+	diag := createResp.Diagnostics.Errors()[0]
+	if withPath, ok := diag.(interface{ Path() path.Path }); !ok || !withPath.Path().Equal(path.Root("par2_path")) {
+		t.Errorf("expected the error on par2_path, got %v", diag)
+	}
+}
+
+func TestToolsResourceValidateConfig(t *testing.T) {
+	r := &ToolsResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		par2Path     string
+		wantWarnings int
+	}{
+		"empty":    {par2Path: ""},
+		"absolute": {par2Path: "/usr/bin/par2"},
+		"windows":  {par2Path: `C:\Program Files\par2.exe`},
+		"relative": {par2Path: "bin/par2", wantWarnings: 1},
+	}
+
+	for name, tc := range cases {
+		config := testToolsModel(tc.par2Path)
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.wantWarnings, resp.Diagnostics)
+		}
+	}
+}
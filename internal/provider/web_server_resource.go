@@ -0,0 +1,384 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/apatheticriku/terraform-provider-sabnzbd/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebServerResource{}
+var _ resource.ResourceWithImportState = &WebServerResource{}
+var _ resource.ResourceWithModifyPlan = &WebServerResource{}
+var _ resource.ResourceWithValidateConfig = &WebServerResource{}
+
+// webServerID is the ID of the web server singleton.
+const webServerID = "web_server"
+
+func NewWebServerResource() resource.Resource {
+	return &WebServerResource{}
+}
+
+// WebServerResource defines the resource implementation.
+type WebServerResource struct {
+	client *client.Client
+}
+
+// WebServerResourceModel describes the resource data model.
+type WebServerResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Host                  types.String `tfsdk:"host"`
+	Port                  types.Int64  `tfsdk:"port"`
+	EnableHTTPS           types.Bool   `tfsdk:"enable_https"`
+	HTTPSPort             types.Int64  `tfsdk:"https_port"`
+	Username              types.String `tfsdk:"username"`
+	Password              types.String `tfsdk:"password"`
+	Language              types.String `tfsdk:"language"`
+	AllowConnectionChange types.Bool   `tfsdk:"allow_connection_change"`
+}
+
+func (r *WebServerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_web_server"
+}
+
+func (r *WebServerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the address, login and language of SABnzbd's web interface. This is a singleton resource; " +
+			"only one should exist per SABnzbd instance.\n\n" +
+			"~> **Warning:** The provider reaches SABnzbd's API through the web interface. Changing `host`, `port`, " +
+			"`enable_https` or `https_port` moves SABnzbd to a new address once it restarts, after which the provider " +
+			"can no longer connect until its `url` is updated. Such changes are refused unless " +
+			"`allow_connection_change` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The web server configuration identifier. Always `web_server`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "The address the web interface listens on, e.g. `0.0.0.0` or `::` for all interfaces.",
+				Required:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "The port the web interface listens on.",
+				Required:            true,
+			},
+			"enable_https": schema.BoolAttribute{
+				MarkdownDescription: "Whether to serve the web interface over HTTPS.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"https_port": schema.Int64Attribute{
+				MarkdownDescription: "The port to serve HTTPS on, in addition to HTTP on `port`. " +
+					"0 serves HTTPS on `port` instead of HTTP.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username required to log in to the web interface. Leave empty to disable the login.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password required to log in to the web interface. " +
+					"SABnzbd does not return it, so changes made outside Terraform are not detected.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Default:   stringdefault.StaticString(""),
+			},
+			"language": schema.StringAttribute{
+				MarkdownDescription: "The language of the web interface and notifications. Values: `" +
+					strings.Join(client.Languages, "`, `") + "`. Defaults to `" + client.DefaultLanguage + "`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.DefaultLanguage),
+			},
+			"allow_connection_change": schema.BoolAttribute{
+				MarkdownDescription: "Allow changes to `host`, `port`, `enable_https` and `https_port` that move SABnzbd " +
+					"to a new address. Update the provider `url` in the same change.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *WebServerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data WebServerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Port.IsNull() && !data.Port.IsUnknown() && (data.Port.ValueInt64() < 1 || data.Port.ValueInt64() > 65535) {
+		resp.Diagnostics.AddAttributeError(path.Root("port"), "Invalid Port",
+			fmt.Sprintf("port must be between 1 and 65535, got %d.", data.Port.ValueInt64()))
+	}
+
+	if !data.HTTPSPort.IsNull() && !data.HTTPSPort.IsUnknown() && (data.HTTPSPort.ValueInt64() < 0 || data.HTTPSPort.ValueInt64() > 65535) {
+		resp.Diagnostics.AddAttributeError(path.Root("https_port"), "Invalid Port",
+			fmt.Sprintf("https_port must be between 0 and 65535, got %d.", data.HTTPSPort.ValueInt64()))
+	}
+
+	if !data.Language.IsNull() && !data.Language.IsUnknown() && !slices.Contains(client.Languages, data.Language.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("language"), "Invalid Language",
+			fmt.Sprintf("%q is not a language SABnzbd supports. Valid languages are: %s.",
+				data.Language.ValueString(), strings.Join(client.Languages, ", ")))
+	}
+
+	// A password without a username locks nobody out but protects nothing,
+	// and a username without a password leaves the login open.
+	if !data.Username.IsUnknown() && !data.Password.IsUnknown() &&
+		(data.Username.ValueString() == "") != (data.Password.ValueString() == "") {
+		resp.Diagnostics.AddAttributeError(path.Root("password"), "Incomplete Login",
+			"Set both username and password to require a login, or neither to disable it.")
+	}
+}
+
+func (r *WebServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WebServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Host.IsUnknown() || plan.Port.IsUnknown() || plan.EnableHTTPS.IsUnknown() ||
+		plan.HTTPSPort.IsUnknown() || plan.AllowConnectionChange.IsUnknown() {
+		return
+	}
+
+	planned := webServerAddress(plan.Host.ValueString(), plan.Port.ValueInt64(), plan.EnableHTTPS.ValueBool(), plan.HTTPSPort.ValueInt64())
+
+	// Compare against the state, or against SABnzbd itself on create.
+	var current string
+	if !req.State.Raw.IsNull() {
+		var state WebServerResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		current = webServerAddress(state.Host.ValueString(), state.Port.ValueInt64(), state.EnableHTTPS.ValueBool(), state.HTTPSPort.ValueInt64())
+	} else {
+		// No API to ask before the provider is configured.
+		if r.client == nil {
+			return
+		}
+
+		webServer, err := r.client.GetWebServer(ctx)
+		if err != nil {
+			// Don't block planning when SABnzbd is unreachable; apply will report it.
+			tflog.Debug(ctx, "unable to read web server settings while planning", map[string]interface{}{"error": err.Error()})
+			return
+		}
+
+		current = webServerAddress(webServer.Host, int64(webServer.Port), webServer.EnableHTTPS == 1, int64(webServer.HTTPSPort))
+	}
+
+	if planned == current {
+		return
+	}
+
+	providerURL := "its configured url"
+	if r.client != nil {
+		providerURL = r.client.BaseURL()
+	}
+
+	if !plan.AllowConnectionChange.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_connection_change"),
+			"Web Server Connection Change Not Allowed",
+			fmt.Sprintf("This change moves SABnzbd's web interface from %s to %s. The provider reaches SABnzbd at %s "+
+				"and will lose its connection once SABnzbd restarts. Set allow_connection_change = true to apply it, "+
+				"and update the provider url to the new address.", current, planned, providerURL),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("allow_connection_change"),
+		"Provider URL Must Be Updated",
+		fmt.Sprintf("This change moves SABnzbd's web interface from %s to %s once SABnzbd restarts. "+
+			"The provider reaches SABnzbd at %s; update its url to the new address before the next plan.",
+			current, planned, providerURL),
+	)
+}
+
+// webServerAddress describes where the web interface listens, for use in
+// diagnostics.
+func webServerAddress(host string, port int64, https bool, httpsPort int64) string {
+	address := "http://" + net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	switch {
+	case https && httpsPort == 0:
+		address = "https://" + net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	case https:
+		address += " and https://" + net.JoinHostPort(host, strconv.FormatInt(httpsPort, 10))
+	}
+
+	return address
+}
+
+func (r *WebServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *WebServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetWebServer(ctx, webServerInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "create web server configuration", err)
+		return
+	}
+
+	webServer, err := r.client.GetWebServer(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read web server configuration", err)
+		return
+	}
+	setWebServerModel(&data, webServer)
+
+	data.ID = types.StringValue(webServerID)
+	tflog.Trace(ctx, "created web server resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebServerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webServer, err := r.client.GetWebServer(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read web server configuration", err)
+		return
+	}
+	setWebServerModel(&data, webServer)
+
+	// Imported state has no value for the settings that only exist in
+	// Terraform.
+	data.ID = types.StringValue(webServerID)
+	if data.Password.IsNull() {
+		data.Password = types.StringValue("")
+	}
+	if data.AllowConnectionChange.IsNull() {
+		data.AllowConnectionChange = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WebServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetWebServer(ctx, webServerInputFromModel(&data)); err != nil {
+		addClientError(&resp.Diagnostics, "update web server configuration", err)
+		return
+	}
+
+	webServer, err := r.client.GetWebServer(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read web server configuration", err)
+		return
+	}
+	setWebServerModel(&data, webServer)
+
+	data.ID = types.StringValue(webServerID)
+	tflog.Trace(ctx, "updated web server resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Resetting the web interface could lock the provider out, so the
+	// settings are kept and the resource is only removed from state.
+	tflog.Trace(ctx, "deleted web server resource from state")
+}
+
+func (r *WebServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), webServerID)...)
+}
+
+// webServerInputFromModel converts the resource model into a client input.
+func webServerInputFromModel(data *WebServerResourceModel) *client.WebServerInput {
+	return &client.WebServerInput{
+		Host:        data.Host.ValueString(),
+		Port:        int(data.Port.ValueInt64()),
+		EnableHTTPS: data.EnableHTTPS.ValueBool(),
+		HTTPSPort:   int(data.HTTPSPort.ValueInt64()),
+		Username:    data.Username.ValueString(),
+		Password:    data.Password.ValueString(),
+		Language:    data.Language.ValueString(),
+	}
+}
+
+// setWebServerModel copies the web server settings SABnzbd stores into data.
+// The password is left alone, since SABnzbd masks it.
+func setWebServerModel(data *WebServerResourceModel, webServer *client.WebServer) {
+	data.Host = types.StringValue(webServer.Host)
+	data.Port = types.Int64Value(int64(webServer.Port))
+	data.EnableHTTPS = types.BoolValue(webServer.EnableHTTPS == 1)
+	data.HTTPSPort = types.Int64Value(int64(webServer.HTTPSPort))
+	data.Username = types.StringValue(webServer.Username)
+	data.Language = types.StringValue(webServer.Language)
+}
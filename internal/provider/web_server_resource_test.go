@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testWebServerModel returns a fully populated web server model.
+func testWebServerModel() WebServerResourceModel {
+	return WebServerResourceModel{
+		ID:                    types.StringUnknown(),
+		Host:                  types.StringValue("0.0.0.0"),
+		Port:                  types.Int64Value(8080),
+		EnableHTTPS:           types.BoolValue(false),
+		HTTPSPort:             types.Int64Value(0),
+		Username:              types.StringValue("admin"),
+		Password:              types.StringValue("secret"),
+		Language:              types.StringValue("en"),
+		AllowConnectionChange: types.BoolValue(false),
+	}
+}
+
+func TestWebServerResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc = map[string]interface{}{"host": "0.0.0.0", "port": "8080", "password": ""}
+
+	r := &WebServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testWebServerModel()
+	createResp := resource.CreateResponse{State: newState(t, s, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if f.misc["username"] != "admin" || f.misc["password"] != "secret" {
+		t.Errorf("expected the login to be stored, got %v", f.misc)
+	}
+
+	// SABnzbd masks the password; the state keeps the configured one.
+	f.misc["password"] = "*****"
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var read WebServerResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ID.ValueString() != webServerID || read.Port.ValueInt64() != 8080 || read.Password.ValueString() != "secret" {
+		t.Errorf("unexpected state after read: %+v", read)
+	}
+}
+
+func TestWebServerResourceModifyPlanGuardsConnection(t *testing.T) {
+	ctx := context.Background()
+	_, c := newFakeSabnzbd(t)
+
+	r := &WebServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	state := testWebServerModel()
+	state.ID = types.StringValue(webServerID)
+
+	cases := map[string]struct {
+		change       func(*WebServerResourceModel)
+		wantError    bool
+		wantWarnings int
+	}{
+		"login only": {
+			change: func(m *WebServerResourceModel) { m.Password = types.StringValue("changed") },
+		},
+		"port refused": {
+			change:    func(m *WebServerResourceModel) { m.Port = types.Int64Value(9090) },
+			wantError: true,
+		},
+		"https refused": {
+			change:    func(m *WebServerResourceModel) { m.EnableHTTPS = types.BoolValue(true) },
+			wantError: true,
+		},
+		"port allowed": {
+			change: func(m *WebServerResourceModel) {
+				m.Port = types.Int64Value(9090)
+				m.AllowConnectionChange = types.BoolValue(true)
+			},
+			wantWarnings: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		plan := state
+		tc.change(&plan)
+
+		req := resource.ModifyPlanRequest{
+			Plan:   newPlan(t, s, &plan),
+			State:  newState(t, s, &state),
+			Config: newConfig(t, s, &plan),
+		}
+		resp := resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, &resp)
+
+		if resp.Diagnostics.HasError() != tc.wantError {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantError, resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != tc.wantWarnings {
+			t.Errorf("%s: expected %d warnings, got %v", name, tc.wantWarnings, resp.Diagnostics)
+		}
+		if tc.wantError && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), c.BaseURL()) {
+			t.Errorf("%s: expected the error to name the provider url, got %q", name, resp.Diagnostics.Errors()[0].Detail())
+		}
+	}
+}
+
+func TestWebServerResourceModifyPlanCreateComparesSABnzbd(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeSabnzbd(t)
+	f.misc = map[string]interface{}{"host": "::", "port": "8080", "enable_https": 0, "https_port": ""}
+
+	r := &WebServerResource{client: c}
+	s := resourceSchema(t, r)
+
+	plan := testWebServerModel()
+	req := resource.ModifyPlanRequest{
+		Plan:   newPlan(t, s, &plan),
+		State:  newState(t, s, nil),
+		Config: newConfig(t, s, &plan),
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when adopting a different host, got %v", resp.Diagnostics)
+	}
+
+	plan.Host = types.StringValue("::")
+	req.Plan = newPlan(t, s, &plan)
+	req.Config = newConfig(t, s, &plan)
+	resp = resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, &resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics when the address is unchanged, got %v", resp.Diagnostics)
+	}
+}
+
+func TestWebServerResourceValidateConfig(t *testing.T) {
+	r := &WebServerResource{}
+	s := resourceSchema(t, r)
+
+	cases := map[string]struct {
+		change  func(*WebServerResourceModel)
+		wantErr bool
+	}{
+		"valid":            {change: func(m *WebServerResourceModel) {}},
+		"no login":         {change: func(m *WebServerResourceModel) { m.Username, m.Password = types.StringValue(""), types.StringValue("") }},
+		"missing password": {change: func(m *WebServerResourceModel) { m.Password = types.StringValue("") }, wantErr: true},
+		"port zero":        {change: func(m *WebServerResourceModel) { m.Port = types.Int64Value(0) }, wantErr: true},
+		"https port range": {change: func(m *WebServerResourceModel) { m.HTTPSPort = types.Int64Value(70000) }, wantErr: true},
+		"unknown language": {change: func(m *WebServerResourceModel) { m.Language = types.StringValue("xx") }, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		config := testWebServerModel()
+		tc.change(&config)
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newConfig(t, s, &config)}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", name, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}